@@ -1,6 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/AsaHero/movie-app-server/delivery/cli"
 	"github.com/AsaHero/movie-app-server/internal/app"
 	"github.com/joho/godotenv"
 )
@@ -8,5 +12,15 @@ import (
 func main() {
 	// Load environment variables and start the application
 	godotenv.Load()
+
+	// A known subcommand runs instead of the server, e.g. `import-dataset`, other arguments are left to the server
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		if err := app.RunCommand(os.Args[1], os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	app.Run()
 }
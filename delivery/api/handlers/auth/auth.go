@@ -8,6 +8,7 @@ import (
 	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
 	"github.com/AsaHero/movie-app-server/delivery/api/validation"
 	"github.com/AsaHero/movie-app-server/internal/service/auth"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
 	"github.com/AsaHero/movie-app-server/internal/service/users"
 	"github.com/AsaHero/movie-app-server/pkg/config"
 	"github.com/AsaHero/movie-app-server/pkg/security"
@@ -15,18 +16,20 @@ import (
 )
 
 type handler struct {
-	config       *config.Config
-	validator    *validation.Validator
-	authService  auth.Service
-	usersService users.Service
+	config          *config.Config
+	validator       *validation.Validator
+	authService     auth.Service
+	usersService    users.Service
+	profilesService profiles.Service
 }
 
 func New(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
 	handler := handler{
-		config:       opt.Config,
-		validator:    opt.Validator,
-		authService:  opt.AuthService,
-		usersService: opt.UsersService,
+		config:          opt.Config,
+		validator:       opt.Validator,
+		authService:     opt.AuthService,
+		usersService:    opt.UsersService,
+		profilesService: opt.ProfilesService,
 	}
 
 	router.POST("/login", handler.Login)
@@ -117,7 +120,16 @@ func (h *handler) Refresh(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := security.GenerateTokenPair(user.ID, h.config.Token.Secret)
+	// keep the selected profile, a deleted one must be selected again
+	if tokenClaims.ProfileID != "" {
+		profileCtx := security.WithProfileID(security.WithUserID(ctx, user.ID), tokenClaims.ProfileID)
+		if _, err := h.profilesService.Active(profileCtx); err != nil {
+			outerr.HandleError(c, err)
+			return
+		}
+	}
+
+	accessToken, refreshToken, err := security.GenerateProfileTokenPair(user.ID, tokenClaims.ProfileID, h.config.Token.Secret)
 	if err != nil {
 		outerr.HandleError(c, err)
 		return
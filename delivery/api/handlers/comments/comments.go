@@ -0,0 +1,641 @@
+package comments
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
+	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/delivery/api/validation"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/service/comments"
+	"github.com/AsaHero/movie-app-server/internal/service/users"
+	"github.com/AsaHero/movie-app-server/pkg/config"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shogo82148/pointer"
+)
+
+type handler struct {
+	config          *config.Config
+	validator       *validation.Validator
+	commentsService comments.Service
+	usersService    users.Service
+}
+
+func newHandler(opt *handlers.HandlerOptions) *handler {
+	return &handler{
+		config:          opt.Config,
+		validator:       opt.Validator,
+		commentsService: opt.CommentsService,
+		usersService:    opt.UsersService,
+	}
+}
+
+// New registers the routes of single comments and the moderation routes
+func New(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := newHandler(opt)
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	moderate := middlewares.RequireAdmin(opt.UsersService, "Only admins can moderate comments")
+
+	router.GET("/reports", moderate, handler.GetCommentReports)
+	router.POST("/bans/:user_id", moderate, handler.BanCommenter)
+	router.DELETE("/bans/:user_id", moderate, handler.UnbanCommenter)
+	router.GET("/:id/replies", handler.GetCommentReplies)
+	router.PUT("/:id", handler.UpdateComment)
+	router.DELETE("/:id", handler.DeleteComment)
+	router.POST("/:id/like", handler.LikeComment)
+	router.DELETE("/:id/like", handler.UnlikeComment)
+	router.POST("/:id/report", handler.ReportComment)
+	router.POST("/:id/hide", moderate, handler.HideComment)
+	router.POST("/:id/unhide", moderate, handler.UnhideComment)
+	router.POST("/:id/dismiss-reports", moderate, handler.DismissCommentReports)
+	router.POST("/:id/lock", moderate, handler.LockCommentThread)
+	router.POST("/:id/unlock", moderate, handler.UnlockCommentThread)
+}
+
+// NewMovieThreads registers the discussion routes of a movie, router is the group of /movies/:id/comments
+func NewMovieThreads(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := newHandler(opt)
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	router.GET("/", handler.GetMovieComments)
+	router.POST("/", handler.CreateComment)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get movie comments
+// @Description Get the discussion of a movie, newest threads first, each with its first replies. Pass next_cursor back as cursor for the next page.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} models.GetCommentsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/comments [get]
+func (h *handler) GetMovieComments(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	movieID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.GetCommentsRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	page, err := h.commentsService.Threads(ctx, movieID, req.Cursor, uint64(pointer.IntValueWithDefault(req.Limit, 20)))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentsResponse(page))
+}
+
+// @Security ApiKeyAuth
+// @Summary Create comment
+// @Description Comment on a movie, or reply to one of its comments with parent_id. Banned users and locked threads are refused.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param request body models.CreateCommentRequest true "Create comment request"
+// @Success 201 {object} models.Comment
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/comments [post]
+func (h *handler) CreateComment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	movieID, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateCommentRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	comment := &entity.MovieComments{
+		MovieID:  movieID,
+		ParentID: req.ParentID,
+		Body:     strings.TrimSpace(req.Body),
+	}
+
+	if comment.Body == "" {
+		outerr.BadRequest(c, "body is required")
+		return
+	}
+
+	if err := h.commentsService.Create(ctx, comment); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCommentModel(comment, false))
+}
+
+// @Security ApiKeyAuth
+// @Summary Get comment replies
+// @Description Get the replies of the thread a comment belongs to, oldest first. Pass next_cursor back as cursor for the next page.
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} models.GetCommentsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/replies [get]
+func (h *handler) GetCommentReplies(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.GetCommentsRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	page, err := h.commentsService.Replies(ctx, id, req.Cursor, uint64(pointer.IntValueWithDefault(req.Limit, 20)))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentsResponse(page))
+}
+
+// @Security ApiKeyAuth
+// @Summary Update comment
+// @Description Edit a comment of the caller, unless it was removed or its thread is locked
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Param request body models.UpdateCommentRequest true "Update comment request"
+// @Success 200 {object} models.Comment
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id} [put]
+func (h *handler) UpdateComment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCommentRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		outerr.BadRequest(c, "body is required")
+		return
+	}
+
+	comment, err := h.commentsService.Edit(ctx, id, body)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentModel(comment, false))
+}
+
+// @Security ApiKeyAuth
+// @Summary Delete comment
+// @Description Delete a comment of the caller, its replies stay under a placeholder
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id} [delete]
+func (h *handler) DeleteComment(c *gin.Context) {
+	h.commentAction(c, h.commentsService.Delete)
+}
+
+// @Security ApiKeyAuth
+// @Summary Like comment
+// @Description Like a comment, liking twice is a no-op
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/like [post]
+func (h *handler) LikeComment(c *gin.Context) {
+	h.commentAction(c, h.commentsService.Like)
+}
+
+// @Security ApiKeyAuth
+// @Summary Unlike comment
+// @Description Take back a like
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/like [delete]
+func (h *handler) UnlikeComment(c *gin.Context) {
+	h.commentAction(c, h.commentsService.Unlike)
+}
+
+// @Security ApiKeyAuth
+// @Summary Report comment
+// @Description Flag a comment to the moderators, reporting it again replaces the reason
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Param request body models.ReportCommentRequest true "Report comment request"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/report [post]
+func (h *handler) ReportComment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReportCommentRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.commentsService.Report(ctx, id, strings.TrimSpace(req.Reason)); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Get comment reports
+// @Description Get the open reports, oldest first, with the full comment (admin only)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} models.GetCommentReportsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/reports [get]
+func (h *handler) GetCommentReports(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetCommentReportsRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	total, reports, err := h.commentsService.Reports(ctx,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)),
+		uint64(pointer.IntValueWithDefault(req.Page, 1)),
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetCommentReportsResponse{
+		Reports: make([]models.CommentReport, 0, len(reports)),
+		Total:   total,
+	}
+
+	for _, report := range reports {
+		if report.Comment == nil {
+			continue
+		}
+
+		response.Reports = append(response.Reports, models.CommentReport{
+			Comment:    toCommentModel(report.Comment, true),
+			ReporterID: report.UserID,
+			Reason:     report.Reason,
+			CreatedAt:  report.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Hide comment
+// @Description Take a comment out of the discussion and resolve its reports (admin only)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/hide [post]
+func (h *handler) HideComment(c *gin.Context) {
+	h.commentAction(c, h.commentsService.Hide)
+}
+
+// @Security ApiKeyAuth
+// @Summary Unhide comment
+// @Description Put a hidden comment back in the discussion and resolve its reports (admin only)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/unhide [post]
+func (h *handler) UnhideComment(c *gin.Context) {
+	h.commentAction(c, h.commentsService.Unhide)
+}
+
+// @Security ApiKeyAuth
+// @Summary Dismiss comment reports
+// @Description Resolve the open reports of a comment and leave it as it is (admin only)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/dismiss-reports [post]
+func (h *handler) DismissCommentReports(c *gin.Context) {
+	h.commentAction(c, h.commentsService.DismissReports)
+}
+
+// @Security ApiKeyAuth
+// @Summary Lock comment thread
+// @Description Stop replies and edits in the thread of a comment (admin only)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/lock [post]
+func (h *handler) LockCommentThread(c *gin.Context) {
+	h.commentAction(c, h.commentsService.Lock)
+}
+
+// @Security ApiKeyAuth
+// @Summary Unlock comment thread
+// @Description Allow replies and edits in the thread of a comment again (admin only)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Comment id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/{id}/unlock [post]
+func (h *handler) UnlockCommentThread(c *gin.Context) {
+	h.commentAction(c, h.commentsService.Unlock)
+}
+
+// @Security ApiKeyAuth
+// @Summary Ban commenter
+// @Description Keep a user from posting and editing comments until expires_at, for good without it. Banning again replaces the ban (admin only)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User id"
+// @Param request body models.BanCommenterRequest true "Ban commenter request"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/bans/{user_id} [post]
+func (h *handler) BanCommenter(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.BanCommenterRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	if userID == c.GetString("user_id") {
+		outerr.BadRequest(c, "You cannot ban yourself")
+		return
+	}
+
+	if _, err := h.usersService.GetByID(ctx, userID); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if err := h.commentsService.Ban(ctx, userID, req.Reason, req.ExpiresAt); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Unban commenter
+// @Description Let a banned user comment again (admin only)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /comments/bans/{user_id} [delete]
+func (h *handler) UnbanCommenter(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.commentsService.Unban(ctx, userID); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// commentAction runs fn on the comment of the path and answers with an empty body
+func (h *handler) commentAction(c *gin.Context, fn func(ctx context.Context, id int64) error) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	if err := fn(c.Request.Context(), id); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func (h *handler) bindQuery(c *gin.Context, req any) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return false
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *handler) bindJSON(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return false
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func idParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return 0, false
+	}
+	return id, true
+}
+
+func userIDParam(c *gin.Context) (string, bool) {
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		outerr.BadRequest(c, "Invalid user_id")
+		return "", false
+	}
+	return userID, true
+}
+
+func toCommentsResponse(page *entity.CommentPage) models.GetCommentsResponse {
+	response := models.GetCommentsResponse{
+		Comments: make([]models.Comment, 0, len(page.Comments)),
+	}
+
+	if page.NextCursor != "" {
+		response.NextCursor = &page.NextCursor
+	}
+
+	for _, comment := range page.Comments {
+		response.Comments = append(response.Comments, toCommentModel(comment, false))
+	}
+
+	return response
+}
+
+// toCommentModel renders a comment, removed ones lose their body and author
+// unless moderator is set
+func toCommentModel(comment *entity.MovieComments, moderator bool) models.Comment {
+	result := models.Comment{
+		ID:           comment.ID,
+		MovieID:      comment.MovieID,
+		ParentID:     comment.ParentID,
+		Status:       "visible",
+		LikesCount:   comment.LikesCount,
+		Liked:        comment.Liked,
+		RepliesCount: comment.RepliesCount,
+		Locked:       comment.LockedAt != nil,
+		EditedAt:     comment.EditedAt,
+		CreatedAt:    comment.CreatedAt,
+	}
+
+	switch {
+	case comment.DeletedAt != nil:
+		result.Status = "deleted"
+	case comment.HiddenAt != nil:
+		result.Status = "hidden"
+	}
+
+	if !comment.IsRemoved() || moderator {
+		result.Body = comment.Body
+		result.Author = &models.CommentAuthor{ID: comment.UserID}
+		if comment.User != nil {
+			result.Author.Username = comment.User.Username
+		}
+	}
+
+	for _, reply := range comment.Replies {
+		result.Replies = append(result.Replies, toCommentModel(reply, moderator))
+	}
+
+	return result
+}
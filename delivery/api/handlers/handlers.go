@@ -3,17 +3,33 @@ package handlers
 import (
 	"github.com/AsaHero/movie-app-server/delivery/api/validation"
 	"github.com/AsaHero/movie-app-server/internal/service/auth"
+	"github.com/AsaHero/movie-app-server/internal/service/charts"
+	"github.com/AsaHero/movie-app-server/internal/service/comments"
 	"github.com/AsaHero/movie-app-server/internal/service/genres"
+	"github.com/AsaHero/movie-app-server/internal/service/lists"
 	"github.com/AsaHero/movie-app-server/internal/service/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/internal/service/progress"
+	"github.com/AsaHero/movie-app-server/internal/service/ratings"
+	"github.com/AsaHero/movie-app-server/internal/service/recommendations"
+	"github.com/AsaHero/movie-app-server/internal/service/titles"
 	"github.com/AsaHero/movie-app-server/internal/service/users"
 	"github.com/AsaHero/movie-app-server/pkg/config"
 )
 
 type HandlerOptions struct {
-	Config        *config.Config
-	Validator     *validation.Validator
-	AuthService   auth.Service
-	UsersService  users.Service
-	MoviesSerive  movies.Service
-	GenresService genres.Service
+	Config                 *config.Config
+	Validator              *validation.Validator
+	AuthService            auth.Service
+	UsersService           users.Service
+	MoviesSerive           movies.Service
+	GenresService          genres.Service
+	ProfilesService        profiles.Service
+	TitlesService          titles.Service
+	ListsService           lists.Service
+	ProgressService        progress.Service
+	RatingsService         ratings.Service
+	RecommendationsService recommendations.Service
+	ChartsService          charts.Service
+	CommentsService        comments.Service
 }
@@ -0,0 +1,113 @@
+package movies
+
+import (
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/pkg/locale"
+	"github.com/gin-gonic/gin"
+)
+
+// audience is who a response is rendered for: the locales they read, most
+// preferred first, and the country whose releases and ratings apply to them
+type audience struct {
+	locales []string
+	country string
+}
+
+// audience reads the lang query parameter and then the Accept-Language header for
+// the locales, and the country query parameter, the region of the preferred locale
+// or the configured default for the country. Responses vary on the header.
+func (h *handler) audience(c *gin.Context) (audience, bool) {
+	c.Header("Vary", "Accept-Language")
+
+	var aud audience
+	for _, value := range parseStringList(c.Query("lang")) {
+		tag, ok := locale.Normalize(value)
+		if !ok {
+			outerr.BadRequest(c, "Invalid lang: "+value)
+			return audience{}, false
+		}
+		aud.locales = append(aud.locales, tag)
+	}
+	aud.locales = append(aud.locales, locale.ParseAcceptLanguage(c.GetHeader("Accept-Language"))...)
+
+	if value := c.Query("country"); value != "" {
+		country, ok := locale.NormalizeCountry(value)
+		if !ok {
+			outerr.BadRequest(c, "Invalid country, expected an ISO 3166-1 alpha-2 code")
+			return audience{}, false
+		}
+		aud.country = country
+		return aud, true
+	}
+
+	for _, tag := range aud.locales {
+		if region := locale.Region(tag); region != "" {
+			aud.country = region
+			return aud, true
+		}
+	}
+
+	aud.country = h.config.I18n.DefaultCountry
+	return aud, true
+}
+
+// toLocalizedMovieModel is toMovieModel with the title, plot, tagline and genre
+// names taken from the best translation, falling back to the base fields, and
+// the release in the audience's country.
+func (h *handler) toLocalizedMovieModel(movie *entity.Movies, aud audience) models.Movie {
+	mov := toMovieModel(movie)
+	mov.Locale = h.config.I18n.DefaultLocale
+
+	if translation := movie.Translation(aud.locales, h.config.I18n.DefaultLocale); translation != nil {
+		mov.Locale = translation.Locale
+		mov.Title = translation.Title
+		mov.Tagline = translation.Tagline
+		if translation.Plot != nil {
+			mov.Plot = translation.Plot
+		}
+	}
+
+	mov.Genres = mov.Genres[:0]
+	for _, genre := range movie.MovieGenres {
+		if genre.Genre != nil {
+			mov.Genres = append(mov.Genres, h.localizedGenreName(genre.Genre, aud))
+		}
+	}
+
+	if release := movie.LocalRelease(aud.country); release != nil {
+		local := toMovieReleaseModel(release)
+		mov.LocalRelease = &local
+	}
+
+	if member := movie.Collection; member != nil && member.Collection != nil {
+		mov.Collection = &models.MovieCollection{
+			ID:       member.CollectionID,
+			Name:     member.Collection.Name,
+			Position: member.Position,
+			Previous: h.toCollectionEntry(member.Previous, aud),
+			Next:     h.toCollectionEntry(member.Next, aud),
+		}
+	}
+
+	return mov
+}
+
+func (h *handler) localizedGenreName(genre *entity.Genres, aud audience) string {
+	if translation := genre.Translation(aud.locales, h.config.I18n.DefaultLocale); translation != nil {
+		return translation.Name
+	}
+	return genre.Name
+}
+
+func toMovieReleaseModel(release *entity.MovieReleases) models.MovieRelease {
+	return models.MovieRelease{
+		Country:       release.Country,
+		Type:          string(release.Type),
+		Date:          release.ReleaseDate.Format(time.DateOnly),
+		Certification: release.Certification,
+	}
+}
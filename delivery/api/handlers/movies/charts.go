@@ -0,0 +1,154 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
+	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+)
+
+func NewCharts(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := newHandler(opt)
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	router.GET("/trending", handler.GetTrendingChart)
+	router.GET("/popular", handler.GetPopularChart)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get trending movies
+// @Description Get the movies picking up views and opens, recent activity counts the most and a movie that was already as busy in the window before ranks lower. Scores are recomputed every few minutes.
+// @Tags Charts
+// @Accept json
+// @Produce json
+// @Param window query string false "How far back the chart looks" Enums(day,week,month,year) default(week)
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Success 200 {object} models.GetChartResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /charts/trending [get]
+func (h *handler) GetTrendingChart(c *gin.Context) {
+	h.writeChart(c, entity.ChartTrending, entity.ChartWeek)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get popular movies
+// @Description Get the movies with the most views and opens over the window, older activity counting less. Scores are recomputed every few minutes.
+// @Tags Charts
+// @Accept json
+// @Produce json
+// @Param window query string false "How far back the chart looks" Enums(day,week,month,year) default(month)
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Success 200 {object} models.GetChartResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /charts/popular [get]
+func (h *handler) GetPopularChart(c *gin.Context) {
+	h.writeChart(c, entity.ChartPopular, entity.ChartMonth)
+}
+
+func (h *handler) writeChart(c *gin.Context, chart entity.Chart, defaultPeriod entity.ChartPeriod) {
+	ctx := c.Request.Context()
+
+	var req models.GetChartRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	period := entity.ChartPeriod(pointer.StringValueWithDefault(req.Window, string(defaultPeriod)))
+	page := pointer.IntValueWithDefault(req.Page, 1)
+	limit := pointer.IntValueWithDefault(req.Limit, 20)
+
+	total, ranked, err := h.chartsService.Top(ctx, chart, period, uint64(limit), uint64(page))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetChartResponse{
+		Window: string(period),
+		Total:  total,
+		Movies: make([]models.ChartMovie, 0, len(ranked)),
+	}
+
+	for i, popularity := range ranked {
+		if popularity.Movie == nil {
+			continue
+		}
+
+		score := popularity.Score
+		if chart == entity.ChartTrending {
+			score = popularity.TrendingScore
+		}
+
+		response.Movies = append(response.Movies, models.ChartMovie{
+			Rank:      (page-1)*limit + i + 1,
+			ID:        popularity.MovieID,
+			Title:     h.localizedTitle(popularity.Movie, aud),
+			Release:   popularity.Movie.Release.Format(time.RFC3339),
+			PosterURL: popularity.Movie.PosterURL,
+			Score:     score,
+			Events:    popularity.Events,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Open movie
+// @Description Record that the caller opened the movie's player, it counts more than a view in the charts. Events are written in the background.
+// @Tags Charts
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/open [post]
+func (h *handler) OpenMovie(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	// hidden and deleted movies are not found, so they cannot be pushed up the charts
+	movie, err := h.moviesService.GetByID(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.chartsService.Record(ctx, movie.ID, entity.MovieEventOpen)
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
@@ -0,0 +1,317 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
+	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+)
+
+// NewCollections registers the collection routes, they share the movie handler
+// to render member titles for the caller's locale
+func NewCollections(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := newHandler(opt)
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	manage := middlewares.RequireAdmin(opt.UsersService, "Only admins can manage collections")
+
+	router.GET("/", handler.GetAllCollections)
+	router.POST("/", manage, handler.CreateCollection)
+	router.GET("/:id", handler.GetCollection)
+	router.PUT("/:id", manage, handler.UpdateCollection)
+	router.DELETE("/:id", manage, handler.DeleteCollection)
+	router.PUT("/:id/movies", manage, handler.SetCollectionMovies)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get all collections
+// @Description Get collections ordered by name, without their movies
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} models.GetAllCollectionsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /collections [get]
+func (h *handler) GetAllCollections(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetAllCollectionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	total, collections, err := h.moviesService.ListCollections(ctx,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)), uint64(pointer.IntValueWithDefault(req.Page, 1)),
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetAllCollectionsResponse{
+		Collections: make([]models.Collection, 0, len(collections)),
+		Total:       total,
+	}
+
+	for _, collection := range collections {
+		response.Collections = append(response.Collections, toCollectionModel(collection))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get collection by id
+// @Description Get a collection with its member movies in order, titles localized like movie responses
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection id"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Success 200 {object} models.Collection
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /collections/{id} [get]
+func (h *handler) GetCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	collection, err := h.moviesService.GetCollection(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := toCollectionModel(collection)
+	response.Movies = make([]models.CollectionEntry, 0, len(collection.Members))
+	for i := range collection.Members {
+		if entry := h.toCollectionEntry(&collection.Members[i], aud); entry != nil {
+			response.Movies = append(response.Movies, *entry)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Create collection
+// @Description Create a collection (admin only), members are set with PUT /collections/{id}/movies
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param request body models.CollectionRequest true "Collection"
+// @Success 201 {object} models.Collection
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /collections [post]
+func (h *handler) CreateCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	collection, ok := h.bindCollection(c)
+	if !ok {
+		return
+	}
+
+	if err := h.moviesService.CreateCollection(ctx, collection); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toCollectionModel(collection))
+}
+
+// @Security ApiKeyAuth
+// @Summary Update collection
+// @Description Replace a collection's name, description and artwork (admin only)
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection id"
+// @Param request body models.CollectionRequest true "Collection"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /collections/{id} [put]
+func (h *handler) UpdateCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	collection, ok := h.bindCollection(c)
+	if !ok {
+		return
+	}
+	collection.ID = id
+
+	if err := h.moviesService.UpdateCollection(ctx, collection); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Delete collection
+// @Description Delete a collection, its movies are kept (admin only)
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /collections/{id} [delete]
+func (h *handler) DeleteCollection(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	if err := h.moviesService.DeleteCollection(ctx, id); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Set collection movies
+// @Description Replace the member movies of a collection, in collection order (admin only). A movie belongs to at most one collection.
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection id"
+// @Param request body models.SetCollectionMoviesRequest true "Movie ids in order"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /collections/{id}/movies [put]
+func (h *handler) SetCollectionMovies(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	var req models.SetCollectionMoviesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if err := h.moviesService.SetCollectionMovies(ctx, id, req.MovieIDs); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func (h *handler) bindCollection(c *gin.Context) (*entity.Collections, bool) {
+	var req models.CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return nil, false
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return nil, false
+	}
+
+	return &entity.Collections{
+		Name:        req.Name,
+		Description: req.Description,
+		PosterURL:   req.PosterURL,
+		BackdropURL: req.BackdropURL,
+	}, true
+}
+
+// toCollectionEntry renders a member with its movie's title for aud, nil when
+// there is no member or its movie was not loaded
+func (h *handler) toCollectionEntry(member *entity.CollectionMovies, aud audience) *models.CollectionEntry {
+	if member == nil || member.Movie == nil {
+		return nil
+	}
+
+	entry := &models.CollectionEntry{
+		Position:  member.Position,
+		ID:        member.MovieID,
+		Title:     member.Movie.Title,
+		Release:   member.Movie.Release.Format(time.RFC3339),
+		PosterURL: member.Movie.PosterURL,
+	}
+
+	if translation := member.Movie.Translation(aud.locales, h.config.I18n.DefaultLocale); translation != nil {
+		entry.Title = translation.Title
+	}
+
+	return entry
+}
+
+func toCollectionModel(collection *entity.Collections) models.Collection {
+	return models.Collection{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		PosterURL:   collection.PosterURL,
+		BackdropURL: collection.BackdropURL,
+		CreatedAt:   collection.CreatedAt,
+		UpdatedAt:   collection.UpdatedAt,
+	}
+}
@@ -0,0 +1,150 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Summary Get movie duplicates
+// @Description List pairs of movies that are likely the same one: titles equal once lowercased and stripped of punctuation, released at most a year apart, scored by how close their release years and durations are. Admins only.
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} models.GetMovieDuplicatesResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/duplicates [get]
+func (h *handler) GetMovieDuplicates(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetMovieDuplicatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	total, candidates, err := h.moviesService.Duplicates(ctx, uint64(*req.Limit), uint64(*req.Page))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetMovieDuplicatesResponse{
+		Total:      total,
+		Duplicates: make([]models.MovieDuplicate, 0, len(candidates)),
+	}
+
+	for _, candidate := range candidates {
+		// either movie may have been deleted since the pairs were listed
+		if candidate.Movie == nil || candidate.Duplicate == nil {
+			continue
+		}
+
+		response.Duplicates = append(response.Duplicates, models.MovieDuplicate{
+			Movie:        toMovieModel(candidate.Movie),
+			Duplicate:    toMovieModel(candidate.Duplicate),
+			Score:        candidate.Score,
+			YearDiff:     candidate.YearDiff,
+			DurationDiff: candidate.DurationDiff,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Dismiss movie duplicate
+// @Description Mark a pair of movies as different ones so it is no longer listed as a duplicate. Admins only.
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param request body models.DismissMovieDuplicateRequest true "Dismiss movie duplicate request"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/duplicates/dismiss [post]
+func (h *handler) DismissMovieDuplicate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.DismissMovieDuplicateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if err := h.moviesService.DismissDuplicate(ctx, req.MovieID, req.DuplicateID); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Merge movie
+// @Description Merge a duplicate into this movie in one transaction: its genres, ratings, comments, list entries and external ids move over, entries this movie already has win. The duplicate goes to the trash and GET on its id redirects here. Admins only.
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Id of the movie that is kept"
+// @Param request body models.MergeMovieRequest true "Merge movie request"
+// @Success 200 {object} models.Movie
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/merge [post]
+func (h *handler) MergeMovie(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	var req models.MergeMovieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	movie, err := h.moviesService.Merge(ctx, id, req.DuplicateID)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.Header("ETag", localizedMovieETag(movie.Version, aud))
+	c.JSON(http.StatusOK, h.toLocalizedMovieModel(movie, aud))
+}
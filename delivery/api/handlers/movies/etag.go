@@ -0,0 +1,92 @@
+package movies
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/gin-gonic/gin"
+)
+
+// localizedMovieETag tags the rendering of a version for an audience, since
+// translations and local releases differ between audiences. The version stays
+// in front so the tag still works in If-Match. Reads and writes send the same
+// tag so a cached write response revalidates.
+func localizedMovieETag(version int64, aud audience) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.Join(aud.locales, ",") + ";" + aud.country))
+
+	return `"` + strconv.FormatInt(version, 10) + "-" + strconv.FormatUint(uint64(hash.Sum32()), 36) + `"`
+}
+
+// ifMatchVersion reads the If-Match header and returns the version the client
+// expects, 0 meaning any version. It writes the error response itself when ok is false.
+func (h *handler) ifMatchVersion(c *gin.Context) (version int64, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		if h.config.Concurrency.RequireIfMatch {
+			outerr.PreconditionRequired(c, "If-Match header is required")
+			return 0, false
+		}
+		return 0, true
+	}
+
+	if header == "*" {
+		return 0, true
+	}
+
+	tags := splitETags(header)
+	if len(tags) != 1 {
+		outerr.BadRequest(c, "If-Match must contain a single entity tag")
+		return 0, false
+	}
+
+	// If-Match uses strong comparison, weak tags never match
+	if strings.HasPrefix(tags[0], "W/") {
+		outerr.PreconditionFailed(c, "If-Match does not match the current version")
+		return 0, false
+	}
+
+	// a localized tag carries its audience after the version
+	value, _, _ := strings.Cut(strings.Trim(tags[0], `"`), "-")
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version < 1 {
+		outerr.PreconditionFailed(c, "If-Match does not match the current version")
+		return 0, false
+	}
+
+	return version, true
+}
+
+// notModified reports whether If-None-Match matches the current entity tag
+func notModified(c *gin.Context, etag string) bool {
+	header := strings.TrimSpace(c.GetHeader("If-None-Match"))
+	if header == "" {
+		return false
+	}
+
+	if header == "*" {
+		return true
+	}
+
+	// If-None-Match uses weak comparison
+	for _, tag := range splitETags(header) {
+		if strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitETags(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,111 @@
+package movies
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AsaHero/movie-app-server/pkg/config"
+	"github.com/gin-gonic/gin"
+)
+
+// etagContext returns a gin context for a request carrying the given headers
+func etagContext(headers map[string]string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/movies/1", nil)
+	for name, value := range headers {
+		c.Request.Header.Set(name, value)
+	}
+
+	return c, recorder
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	aud := audience{locales: []string{"de-DE"}, country: "DE"}
+
+	tests := []struct {
+		name        string
+		header      string
+		requireIt   bool
+		wantVersion int64
+		wantOK      bool
+		wantStatus  int
+	}{
+		{"missing", "", false, 0, true, http.StatusOK},
+		{"missing but required", "", true, 0, false, http.StatusPreconditionRequired},
+		{"any version", "*", true, 0, true, http.StatusOK},
+		{"plain tag", `"7"`, false, 7, true, http.StatusOK},
+		{"localized tag", localizedMovieETag(7, aud), false, 7, true, http.StatusOK},
+		{"surrounding spaces", `  "3"  `, false, 3, true, http.StatusOK},
+		{"weak tag", `W/"7"`, false, 0, false, http.StatusPreconditionFailed},
+		{"several tags", `"7", "8"`, false, 0, false, http.StatusBadRequest},
+		{"not a version", `"abc"`, false, 0, false, http.StatusPreconditionFailed},
+		{"zero version", `"0"`, false, 0, false, http.StatusPreconditionFailed},
+		{"negative version", `"-1"`, false, 0, false, http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handler{config: &config.Config{}}
+			h.config.Concurrency.RequireIfMatch = tt.requireIt
+
+			c, recorder := etagContext(map[string]string{"If-Match": tt.header})
+
+			version, ok := h.ifMatchVersion(c)
+			if version != tt.wantVersion || ok != tt.wantOK {
+				t.Fatalf("ifMatchVersion(%q) = %d, %v, want %d, %v", tt.header, version, ok, tt.wantVersion, tt.wantOK)
+			}
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	etag := localizedMovieETag(4, audience{locales: []string{"en-US"}, country: "US"})
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{"missing", "", false},
+		{"any", "*", true},
+		{"same tag", etag, true},
+		{"weak same tag", "W/" + etag, true},
+		{"in a list", `"1", ` + etag, true},
+		{"other version", localizedMovieETag(5, audience{locales: []string{"en-US"}, country: "US"}), false},
+		{"other audience", localizedMovieETag(4, audience{locales: []string{"fr-FR"}, country: "FR"}), false},
+		{"bare version", `"4"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := etagContext(map[string]string{"If-None-Match": tt.header})
+
+			if got := notModified(c, etag); got != tt.want {
+				t.Fatalf("notModified(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizedMovieETag(t *testing.T) {
+	us := audience{locales: []string{"en-US"}, country: "US"}
+	gb := audience{locales: []string{"en-US"}, country: "GB"}
+
+	if localizedMovieETag(1, us) != localizedMovieETag(1, us) {
+		t.Fatal("the same version and audience must give the same tag")
+	}
+	if localizedMovieETag(1, us) == localizedMovieETag(1, gb) {
+		t.Fatal("audiences in different countries must get different tags")
+	}
+	if !strings.HasPrefix(localizedMovieETag(12, us), `"12-`) {
+		t.Fatalf("the tag %s must start with the version", localizedMovieETag(12, us))
+	}
+}
@@ -0,0 +1,234 @@
+package movies
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/pkg/logger"
+	"github.com/AsaHero/movie-app-server/pkg/xlsx"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+	"github.com/sirupsen/logrus"
+)
+
+// exportColumns are the CSV/XLSX header, the same names the import accepts
+var exportColumns = []string{
+	"id",
+	"external_id",
+	"title",
+	"release",
+	"plot",
+	"duration_minutes",
+	"poster_url",
+	"trailer_url",
+	"genres",
+	"created_at",
+	"updated_at",
+}
+
+var exportContentTypes = map[string]string{
+	"csv":   "text/csv; charset=utf-8",
+	"jsonl": "application/x-ndjson",
+	"xlsx":  xlsx.ContentType,
+}
+
+// movieRowWriter writes export rows in one of the supported formats
+type movieRowWriter interface {
+	Write(movie models.ExportMovie) error
+	Close() error
+}
+
+// @Security ApiKeyAuth
+// @Summary Export movies
+// @Description Download the catalogue as CSV, JSON Lines or XLSX, filtered like the movie listing. Genres are joined with "|", so CSV exports can be imported back.
+// @Tags Movies
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string true "Export format" Enums(csv, jsonl, xlsx)
+// @Param search query string false "Search term"
+// @Param genres query []string false "Filter by genres" collectionFormat(csv)
+// @Param decades query []int false "Filter by release decades, e.g. 1990,2010" collectionFormat(csv)
+// @Param years query []int false "Filter by release years" collectionFormat(csv)
+// @Param durations query []string false "Filter by duration buckets" collectionFormat(csv) Enums(short, medium, long, epic)
+// @Param country query string false "Only movies released in this country"
+// @Param max_certification query string false "Strictest certification allowed in the country, e.g. PG-13 or 16"
+// @Success 200 {file} file
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/export [get]
+func (h *handler) ExportMovies(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.ExportMoviesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	filters, err := parseMovieFilters(req.MovieFilterQuery, aud)
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("movies-%s.%s", time.Now().Format("20060102-150405"), req.Format)
+	c.Header("Content-Type", exportContentTypes[req.Format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	// From here on the status is sent, failures can only cut the download short
+	writer, err := newMovieRowWriter(req.Format, c.Writer)
+	if err != nil {
+		logExportError(err)
+		return
+	}
+
+	err = h.moviesService.Export(ctx, filters, func(movies []entity.Movies) error {
+		for i := range movies {
+			if err := writer.Write(toExportMovie(&movies[i])); err != nil {
+				return err
+			}
+		}
+
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		logExportError(err)
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		logExportError(err)
+	}
+}
+
+func logExportError(err error) {
+	logger.Error("movie export aborted", logrus.Fields{
+		"error": err.Error(),
+	})
+}
+
+func newMovieRowWriter(format string, w http.ResponseWriter) (movieRowWriter, error) {
+	switch format {
+	case "jsonl":
+		return &jsonlRowWriter{encoder: json.NewEncoder(w)}, nil
+	case "xlsx":
+		xw, err := xlsx.NewWriter(w, "Movies")
+		if err != nil {
+			return nil, err
+		}
+		header := make([]any, len(exportColumns))
+		for i, column := range exportColumns {
+			header[i] = column
+		}
+		return &xlsxRowWriter{writer: xw}, xw.WriteRow(header...)
+	default:
+		cw := csv.NewWriter(w)
+		return &csvRowWriter{writer: cw}, cw.Write(exportColumns)
+	}
+}
+
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvRowWriter) Write(movie models.ExportMovie) error {
+	return w.writer.Write([]string{
+		strconv.FormatInt(movie.ID, 10),
+		pointer.StringValue(movie.ExternalID),
+		movie.Title,
+		movie.Release,
+		pointer.StringValue(movie.Plot),
+		strconv.Itoa(int(movie.DurationMinutes)),
+		movie.PosterURL,
+		movie.TrailerURL,
+		strings.Join(movie.Genres, importGenreSeparator),
+		movie.CreatedAt.Format(time.RFC3339),
+		movie.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+func (w *csvRowWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type jsonlRowWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonlRowWriter) Write(movie models.ExportMovie) error {
+	return w.encoder.Encode(movie)
+}
+
+func (w *jsonlRowWriter) Close() error {
+	return nil
+}
+
+type xlsxRowWriter struct {
+	writer *xlsx.Writer
+}
+
+func (w *xlsxRowWriter) Write(movie models.ExportMovie) error {
+	return w.writer.WriteRow(
+		movie.ID,
+		pointer.StringValue(movie.ExternalID),
+		movie.Title,
+		movie.Release,
+		pointer.StringValue(movie.Plot),
+		movie.DurationMinutes,
+		movie.PosterURL,
+		movie.TrailerURL,
+		strings.Join(movie.Genres, importGenreSeparator),
+		movie.CreatedAt,
+		movie.UpdatedAt,
+	)
+}
+
+func (w *xlsxRowWriter) Close() error {
+	return w.writer.Close()
+}
+
+func toExportMovie(movie *entity.Movies) models.ExportMovie {
+	export := models.ExportMovie{
+		ID:              movie.ID,
+		ExternalID:      movie.ImportKey(),
+		Title:           movie.Title,
+		Release:         movie.Release.Format(time.DateOnly),
+		Plot:            movie.Plot,
+		DurationMinutes: movie.DurationMinutes,
+		PosterURL:       movie.PosterURL,
+		TrailerURL:      movie.TrailerURL,
+		Genres:          make([]string, 0, len(movie.MovieGenres)),
+		CreatedAt:       movie.CreatedAt,
+		UpdatedAt:       movie.UpdatedAt,
+	}
+
+	for _, genre := range movie.MovieGenres {
+		if genre.Genre != nil {
+			export.Genres = append(export.Genres, genre.Genre.Name)
+		}
+	}
+
+	return export
+}
@@ -0,0 +1,157 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Summary Get movie by external id
+// @Description Look up a movie by its id in a partner catalogue, e.g. /movies/by-external/imdb/tt0078748
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param source path string true "External id source" Enums(imdb, tmdb)
+// @Param external_id path string true "External id"
+// @Success 200 {object} models.Movie
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/by-external/{source}/{external_id} [get]
+func (h *handler) GetMovieByExternalID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	source, ok := externalSourceParam(c)
+	if !ok {
+		return
+	}
+
+	value := c.Param("external_id")
+	if !source.IsValidValue(value) {
+		outerr.BadRequest(c, "Invalid "+string(source)+" id")
+		return
+	}
+
+	movie, err := h.moviesService.GetByExternalID(ctx, source, value)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	etag := localizedMovieETag(movie.Version, aud)
+	c.Header("ETag", etag)
+
+	if notModified(c, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toLocalizedMovieModel(movie, aud))
+}
+
+// @Security ApiKeyAuth
+// @Summary Set movie external id
+// @Description Attach or replace the movie's id for a source. Returns 409 when the id is attached to another movie.
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param source path string true "External id source" Enums(imdb, tmdb)
+// @Param request body models.SetExternalIDRequest true "External id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/external-ids/{source} [put]
+func (h *handler) SetMovieExternalID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	source, ok := externalSourceParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetExternalIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if !source.IsValidValue(req.Value) {
+		outerr.BadRequest(c, "Invalid "+string(source)+" id")
+		return
+	}
+
+	if err := h.moviesService.SetExternalID(ctx, id, source, req.Value); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Remove movie external id
+// @Description Detach the movie's id for a source
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param source path string true "External id source" Enums(imdb, tmdb)
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/external-ids/{source} [delete]
+func (h *handler) RemoveMovieExternalID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	source, ok := externalSourceParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.moviesService.RemoveExternalID(ctx, id, source); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func externalSourceParam(c *gin.Context) (entity.ExternalSource, bool) {
+	source := entity.ExternalSource(c.Param("source"))
+	if !source.IsValid() {
+		outerr.BadRequest(c, "Unknown external id source, expected imdb or tmdb")
+		return "", false
+	}
+	return source, true
+}
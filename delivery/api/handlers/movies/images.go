@@ -0,0 +1,194 @@
+package movies
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImagePixels rejects decompression bombs before the image is decoded
+const maxImagePixels = 50_000_000
+
+var imageContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+// @Security ApiKeyAuth
+// @Summary Upload movie image
+// @Description Upload a poster or backdrop (JPEG or PNG). The type is detected from the content, resized variants are generated and the previous image of the same kind is replaced.
+// @Tags Movies
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param kind path string true "Image kind" Enums(poster, backdrop)
+// @Param file formData file true "Image file"
+// @Success 201 {object} models.UploadMovieImageResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 413 {object} outerr.ErrorResponse
+// @Failure 415 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/images/{kind} [post]
+func (h *handler) UploadMovieImage(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	kind, ok := imageKindParam(c)
+	if !ok {
+		return
+	}
+
+	maxSize, err := strconv.ParseInt(h.config.Media.MaxUploadSize, 10, 64)
+	if err != nil {
+		outerr.Internal(c, "Invalid media max upload size")
+		return
+	}
+
+	// Leave room for the multipart envelope, the file itself is checked below
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			outerr.PayloadTooLarge(c, "File is too large")
+			return
+		}
+		outerr.BadRequest(c, "File is required")
+		return
+	}
+
+	if fileHeader.Size > maxSize {
+		outerr.PayloadTooLarge(c, "File is too large")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if int64(len(data)) > maxSize {
+		outerr.PayloadTooLarge(c, "File is too large")
+		return
+	}
+
+	// The declared Content-Type is ignored, only the bytes are trusted
+	contentType := http.DetectContentType(data)
+	if !imageContentTypes[contentType] {
+		outerr.UnsupportedMediaType(c, "Image must be a JPEG or PNG")
+		return
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		outerr.BadRequest(c, "Invalid image")
+		return
+	}
+
+	if config.Width*config.Height > maxImagePixels {
+		outerr.PayloadTooLarge(c, "Image dimensions are too large")
+		return
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		outerr.BadRequest(c, "Invalid image")
+		return
+	}
+
+	images, err := h.moviesService.UploadImage(ctx, id, kind, entity.ImageUpload{
+		Data:        data,
+		ContentType: contentType,
+		Image:       img,
+	})
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.UploadMovieImageResponse{
+		Kind:     string(kind),
+		Variants: make([]models.MovieImageVariant, 0, len(images)),
+	}
+
+	for _, image := range images {
+		response.Variants = append(response.Variants, models.MovieImageVariant{
+			Variant: image.Variant,
+			URL:     image.URL,
+			Width:   image.Width,
+			Height:  image.Height,
+			Size:    image.Size,
+		})
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Delete movie image
+// @Description Delete the poster or backdrop with all its variants
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param kind path string true "Image kind" Enums(poster, backdrop)
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/images/{kind} [delete]
+func (h *handler) DeleteMovieImage(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	kind, ok := imageKindParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.moviesService.DeleteImage(ctx, id, kind); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func imageKindParam(c *gin.Context) (entity.ImageKind, bool) {
+	kind := entity.ImageKind(c.Param("kind"))
+	if !kind.IsValid() {
+		outerr.BadRequest(c, "Unknown image kind, expected poster or backdrop")
+		return "", false
+	}
+	return kind, true
+}
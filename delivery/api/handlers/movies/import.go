@@ -0,0 +1,450 @@
+package movies
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	importFormatCSV   = "csv"
+	importFormatJSONL = "jsonl"
+
+	// importMaxFileSize caps uploads at 64 MiB
+	importMaxFileSize = 64 << 20
+
+	// importGenreSeparator separates genre names inside a single CSV cell
+	importGenreSeparator = "|"
+)
+
+// @Security ApiKeyAuth
+// @Summary Import movies
+// @Description Import movies from a CSV (header row with external_id,title,release,plot,duration_minutes,poster_url,trailer_url,genres; genres separated by "|") or JSON Lines file.
+// @Description Rows with an external_id update the movie with the same external id, others are created. Large files are processed in the background, poll the returned job for progress.
+// @Tags Movies
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or JSON Lines file"
+// @Param format query string false "File format, inferred from the file extension when omitted" Enums(csv, jsonl)
+// @Param dry_run query bool false "Validate and report without saving"
+// @Param create_genres query bool false "Create genres that don't exist yet"
+// @Success 200 {object} models.ImportJob
+// @Success 202 {object} models.ImportJob
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/import [post]
+func (h *handler) ImportMovies(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.ImportMoviesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	asyncThreshold, err := strconv.Atoi(h.config.Import.AsyncThreshold)
+	if err != nil {
+		outerr.Internal(c, "Invalid import async threshold")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, importMaxFileSize)
+
+	file, filename, err := spoolImportUpload(c.Request)
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	rows := &importRows{handler: h, file: file}
+
+	format := req.Format
+	if format == "" {
+		format = importFormatFromName(filename)
+		if format == "" {
+			rows.Close()
+			outerr.BadRequest(c, "Unknown file format, use format=csv or format=jsonl")
+			return
+		}
+	}
+
+	// a first pass rejects malformed files before a job is created
+	total, err := countImportRows(format, file)
+	if err != nil {
+		rows.Close()
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if total == 0 {
+		rows.Close()
+		outerr.BadRequest(c, "File contains no rows")
+		return
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		rows.Close()
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if rows.decode, err = newImportDecoder(format, file); err != nil {
+		rows.Close()
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	opts := entity.ImportOptions{
+		DryRun:       req.DryRun,
+		CreateGenres: req.CreateGenres,
+		Async:        total > asyncThreshold,
+	}
+
+	job, err := h.moviesService.Import(ctx, total, rows, opts)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if opts.Async {
+		status = http.StatusAccepted
+	}
+
+	c.JSON(status, toImportJobModel(job))
+}
+
+// @Security ApiKeyAuth
+// @Summary Get import job
+// @Description Get progress and the error report of a movie import
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param job_id path string true "Import job id"
+// @Success 200 {object} models.ImportJob
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/import/{job_id} [get]
+func (h *handler) GetImportJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jobID := c.Param("job_id")
+	if _, err := uuid.Parse(jobID); err != nil {
+		outerr.BadRequest(c, "Invalid job id")
+		return
+	}
+
+	job, err := h.moviesService.GetImportJob(ctx, jobID)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toImportJobModel(job))
+}
+
+// toImportRow runs a row through the same validation as CreateMovieRequest
+func (h *handler) toImportRow(number int, row models.ImportMovieRow) (entity.MovieImportRow, error) {
+	req := models.CreateMovieRequest{
+		Title:           row.Title,
+		Release:         row.Release,
+		Plot:            row.Plot,
+		DurationMinutes: row.DurationMinutes,
+		PosterURL:       row.PosterURL,
+		TrailerURL:      row.TrailerURL,
+		Genres:          make([]int, len(row.Genres)),
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return entity.MovieImportRow{}, errors.New(outerr.ValidationMessage(err))
+	}
+
+	release, err := parseReleaseDate(req.Release)
+	if err != nil {
+		return entity.MovieImportRow{}, errors.New("Invalid release date, format should be YYYY-MM-DD")
+	}
+
+	externalID := row.ExternalID
+	if externalID != nil {
+		trimmed := strings.TrimSpace(*externalID)
+		externalID = &trimmed
+		if trimmed == "" {
+			externalID = nil
+		}
+	}
+
+	return entity.MovieImportRow{
+		Row:        number,
+		ExternalID: externalID,
+		Movie: entity.Movies{
+			Title:           req.Title,
+			Release:         release,
+			Plot:            req.Plot,
+			DurationMinutes: req.DurationMinutes,
+			PosterURL:       req.PosterURL,
+			TrailerURL:      req.TrailerURL,
+		},
+		GenreNames: row.Genres,
+	}, nil
+}
+
+func importFormatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return importFormatCSV
+	case ".jsonl", ".ndjson":
+		return importFormatJSONL
+	default:
+		return ""
+	}
+}
+
+// importRows reads a spooled upload row by row and removes it once closed
+type importRows struct {
+	handler *handler
+	file    *os.File
+	decode  func() (models.ImportMovieRow, error)
+	row     int
+}
+
+func (r *importRows) Next() (*entity.MovieImportRow, error) {
+	raw, err := r.decode()
+	if err != nil {
+		return nil, err
+	}
+	r.row++
+
+	row, err := r.handler.toImportRow(r.row, raw)
+	if err != nil {
+		return &entity.MovieImportRow{
+			Row:        r.row,
+			ExternalID: raw.ExternalID,
+			Err:        err,
+		}, nil
+	}
+
+	return &row, nil
+}
+
+func (r *importRows) Close() error {
+	err := r.file.Close()
+	os.Remove(r.file.Name())
+	return err
+}
+
+// spoolImportUpload streams the file part of a multipart upload to a temporary
+// file, so large uploads are never held in memory and outlive the request
+func spoolImportUpload(r *http.Request) (*os.File, string, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", errors.New("File is required")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, "", errors.New("File is required")
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		file, err := os.CreateTemp("", "movie-import-*")
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _, err := io.Copy(file, part); err != nil {
+			file.Close()
+			os.Remove(file.Name())
+			return nil, "", err
+		}
+
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			file.Close()
+			os.Remove(file.Name())
+			return nil, "", err
+		}
+
+		return file, part.FileName(), nil
+	}
+}
+
+func countImportRows(format string, r io.Reader) (int, error) {
+	decode, err := newImportDecoder(format, r)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		_, err := decode()
+		if errors.Is(err, io.EOF) {
+			return total, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		total++
+	}
+}
+
+// newImportDecoder returns a function reading one raw row per call, io.EOF after the last one
+func newImportDecoder(format string, r io.Reader) (func() (models.ImportMovieRow, error), error) {
+	if format == importFormatCSV {
+		return newImportCSVDecoder(r)
+	}
+	return newImportJSONLDecoder(r), nil
+}
+
+// newImportCSVDecoder maps columns by header name so column order doesn't matter.
+// Cells that can't be converted are left empty and reported by validation.
+func newImportCSVDecoder(r io.Reader) (func() (models.ImportMovieRow, error), error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return func() (models.ImportMovieRow, error) { return models.ImportMovieRow{}, io.EOF }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("CSV header must contain a title column")
+	}
+
+	return func() (models.ImportMovieRow, error) {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return models.ImportMovieRow{}, io.EOF
+		}
+		if err != nil {
+			return models.ImportMovieRow{}, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		cell := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row := models.ImportMovieRow{
+			Title:      cell("title"),
+			Release:    cell("release"),
+			PosterURL:  cell("poster_url"),
+			TrailerURL: cell("trailer_url"),
+		}
+
+		if value := cell("external_id"); value != "" {
+			row.ExternalID = &value
+		}
+
+		if value := cell("plot"); value != "" {
+			row.Plot = &value
+		}
+
+		if value, err := strconv.ParseInt(cell("duration_minutes"), 10, 16); err == nil {
+			row.DurationMinutes = int16(value)
+		}
+
+		if value := cell("genres"); value != "" {
+			row.Genres = strings.Split(value, importGenreSeparator)
+		}
+
+		return row, nil
+	}, nil
+}
+
+func newImportJSONLDecoder(r io.Reader) func() (models.ImportMovieRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	line := 0
+	return func() (models.ImportMovieRow, error) {
+		for scanner.Scan() {
+			line++
+
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+
+			var row models.ImportMovieRow
+			if err := json.Unmarshal([]byte(text), &row); err != nil {
+				return models.ImportMovieRow{}, fmt.Errorf("invalid JSON on line %d: %w", line, err)
+			}
+
+			return row, nil
+		}
+
+		if err := scanner.Err(); err != nil {
+			return models.ImportMovieRow{}, err
+		}
+
+		return models.ImportMovieRow{}, io.EOF
+	}
+}
+
+func toImportJobModel(job *entity.ImportJobs) models.ImportJob {
+	rowErrors := make([]models.ImportRowError, 0, len(job.Errors))
+	for _, rowErr := range job.Errors {
+		rowErrors = append(rowErrors, models.ImportRowError{
+			Row:        rowErr.Row,
+			ExternalID: rowErr.ExternalID,
+			Message:    rowErr.Message,
+		})
+	}
+
+	return models.ImportJob{
+		ID:           job.ID,
+		Status:       string(job.Status),
+		DryRun:       job.DryRun,
+		CreateGenres: job.CreateGenres,
+		Total:        job.Total,
+		Processed:    job.Processed,
+		Created:      job.Created,
+		Updated:      job.Updated,
+		Skipped:      job.Skipped,
+		Failed:       job.Failed,
+		Errors:       rowErrors,
+		CreatedAt:    job.CreatedAt,
+		UpdatedAt:    job.UpdatedAt,
+		FinishedAt:   job.FinishedAt,
+	}
+}
@@ -0,0 +1,225 @@
+package movies
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+)
+
+// decodeAll reads every row of an upload, stopping at the first error
+func decodeAll(format, input string) ([]models.ImportMovieRow, error) {
+	decode, err := newImportDecoder(format, strings.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ImportMovieRow
+	for {
+		row, err := decode()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestImportFormatFromName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"movies.csv", importFormatCSV},
+		{"MOVIES.CSV", importFormatCSV},
+		{"movies.jsonl", importFormatJSONL},
+		{"movies.ndjson", importFormatJSONL},
+		{"movies.json", ""},
+		{"movies", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := importFormatFromName(tt.name); got != tt.want {
+				t.Errorf("importFormatFromName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportCSVDecoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []models.ImportMovieRow
+		wantErr bool
+	}{
+		{
+			name:  "empty file",
+			input: "",
+		},
+		{
+			name:  "header only",
+			input: "title,release\n",
+		},
+		{
+			name:    "missing title column",
+			input:   "name,release\nHeat,1995-12-15\n",
+			wantErr: true,
+		},
+		{
+			name:  "columns matched by name",
+			input: "release, Title ,duration_minutes\n1995-12-15,Heat,170\n",
+			want: []models.ImportMovieRow{
+				{Title: "Heat", Release: "1995-12-15", DurationMinutes: 170},
+			},
+		},
+		{
+			name:  "byte order mark",
+			input: "\ufefftitle\nHeat\n",
+			want:  []models.ImportMovieRow{{Title: "Heat"}},
+		},
+		{
+			name:  "optional cells",
+			input: "title,external_id,plot,genres,poster_url,trailer_url\nHeat,tt0113277,A heist,Crime|Drama,https://p,https://t\n",
+			want: []models.ImportMovieRow{{
+				ExternalID: stringPtr("tt0113277"),
+				Title:      "Heat",
+				Plot:       stringPtr("A heist"),
+				PosterURL:  "https://p",
+				TrailerURL: "https://t",
+				Genres:     []string{"Crime", "Drama"},
+			}},
+		},
+		{
+			name:  "empty optional cells",
+			input: "title,external_id,plot,genres\nHeat,,,\n",
+			want:  []models.ImportMovieRow{{Title: "Heat"}},
+		},
+		{
+			name:  "invalid duration left for validation",
+			input: "title,duration_minutes\nHeat,long\nRonin,99999\n",
+			want:  []models.ImportMovieRow{{Title: "Heat"}, {Title: "Ronin"}},
+		},
+		{
+			name:  "short record",
+			input: "title,release\nHeat\n",
+			want:  []models.ImportMovieRow{{Title: "Heat"}},
+		},
+		{
+			name:    "malformed quoting",
+			input:   "title\n\"Heat\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeAll(importFormatCSV, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decode error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportJSONLDecoder(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []models.ImportMovieRow
+		wantErr string
+	}{
+		{
+			name:  "empty file",
+			input: "",
+		},
+		{
+			name:  "blank lines skipped",
+			input: "\n{\"title\":\"Heat\"}\n   \n{\"title\":\"Ronin\",\"genres\":[\"Action\"]}\n",
+			want: []models.ImportMovieRow{
+				{Title: "Heat"},
+				{Title: "Ronin", Genres: []string{"Action"}},
+			},
+		},
+		{
+			name:  "all fields",
+			input: `{"external_id":"tt0113277","title":"Heat","release":"1995-12-15","plot":"A heist","duration_minutes":170,"poster_url":"https://p","trailer_url":"https://t"}`,
+			want: []models.ImportMovieRow{{
+				ExternalID:      stringPtr("tt0113277"),
+				Title:           "Heat",
+				Release:         "1995-12-15",
+				Plot:            stringPtr("A heist"),
+				DurationMinutes: 170,
+				PosterURL:       "https://p",
+				TrailerURL:      "https://t",
+			}},
+		},
+		{
+			name:    "invalid line reported by number",
+			input:   "{\"title\":\"Heat\"}\n\n{title}\n",
+			wantErr: "line 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeAll(importFormatJSONL, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decode error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decode error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountImportRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"csv", importFormatCSV, "title\nHeat\nRonin\n", 2, false},
+		{"csv header only", importFormatCSV, "title\n", 0, false},
+		{"csv without title", importFormatCSV, "name\nHeat\n", 0, true},
+		{"jsonl", importFormatJSONL, "{\"title\":\"Heat\"}\n\n{\"title\":\"Ronin\"}\n", 2, false},
+		{"jsonl invalid", importFormatJSONL, "{\"title\":\"Heat\"}\nnope\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := countImportRows(tt.format, strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("countImportRows error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("countImportRows = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,598 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
+	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+)
+
+// NewLists registers the user list routes, they share the movie handler to
+// render item titles for the caller's locale
+func NewLists(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := newHandler(opt)
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	router.GET("/", handler.BrowseLists)
+	router.POST("/", handler.CreateList)
+	router.GET("/mine", handler.GetMyLists)
+	router.GET("/following", handler.GetFollowedLists)
+	router.GET("/by-slug/:slug", handler.GetListBySlug)
+	router.GET("/:id", handler.GetList)
+	router.PUT("/:id", handler.UpdateList)
+	router.DELETE("/:id", handler.DeleteList)
+	router.PUT("/:id/order", handler.ReorderList)
+	router.PUT("/:id/items/:movie_id", handler.SetListItem)
+	router.DELETE("/:id/items/:movie_id", handler.RemoveListItem)
+	router.POST("/:id/follow", handler.FollowList)
+	router.DELETE("/:id/follow", handler.UnfollowList)
+}
+
+// @Security ApiKeyAuth
+// @Summary Browse lists
+// @Description Browse public lists, the most followed first by default
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Param order_by query string false "popular (default) or recent"
+// @Param search query string false "Search term matched against list names"
+// @Success 200 {object} models.GetUserListsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists [get]
+func (h *handler) BrowseLists(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetUserListsRequest
+	if !h.bindListsQuery(c, &req) {
+		return
+	}
+
+	total, lists, err := h.listsService.Browse(ctx,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)), uint64(pointer.IntValueWithDefault(req.Page, 1)),
+		entity.ListOrder(pointer.StringValue(req.OrderBy)), strings.TrimSpace(pointer.StringValue(req.Search)),
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserListsResponse(total, lists))
+}
+
+// @Security ApiKeyAuth
+// @Summary Get my lists
+// @Description Get the caller's lists, whatever their visibility, most recently updated first
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} models.GetUserListsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/mine [get]
+func (h *handler) GetMyLists(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetUserListsRequest
+	if !h.bindListsQuery(c, &req) {
+		return
+	}
+
+	total, lists, err := h.listsService.Mine(ctx,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)), uint64(pointer.IntValueWithDefault(req.Page, 1)),
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserListsResponse(total, lists))
+}
+
+// @Security ApiKeyAuth
+// @Summary Get followed lists
+// @Description Get the lists the caller follows, most recently updated first. Lists made private since are left out.
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} models.GetUserListsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/following [get]
+func (h *handler) GetFollowedLists(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetUserListsRequest
+	if !h.bindListsQuery(c, &req) {
+		return
+	}
+
+	total, lists, err := h.listsService.Following(ctx,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)), uint64(pointer.IntValueWithDefault(req.Page, 1)),
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserListsResponse(total, lists))
+}
+
+// @Security ApiKeyAuth
+// @Summary Get list by slug
+// @Description Get a shared list and a page of its movies. Public and unlisted lists are open to anyone with the slug, private ones only to their owner.
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param slug path string true "List slug"
+// @Param page query int false "Page of items"
+// @Param limit query int false "Items per page"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Success 200 {object} models.GetUserListResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/by-slug/{slug} [get]
+func (h *handler) GetListBySlug(c *gin.Context) {
+	list, err := h.listsService.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.writeList(c, list)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get list by id
+// @Description Get a list and a page of its movies, private lists are only open to their owner
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List id"
+// @Param page query int false "Page of items"
+// @Param limit query int false "Items per page"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Success 200 {object} models.GetUserListResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/{id} [get]
+func (h *handler) GetList(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	list, err := h.listsService.Get(c.Request.Context(), id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.writeList(c, list)
+}
+
+// @Security ApiKeyAuth
+// @Summary Create list
+// @Description Create a list, its slug is derived from the name
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param request body models.UserListRequest true "List"
+// @Success 201 {object} models.UserList
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists [post]
+func (h *handler) CreateList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	list, ok := h.bindList(c)
+	if !ok {
+		return
+	}
+
+	if err := h.listsService.Create(ctx, list); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toUserListModel(list))
+}
+
+// @Security ApiKeyAuth
+// @Summary Update list
+// @Description Replace the name, description and visibility of one of the caller's lists, the slug is kept
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List id"
+// @Param request body models.UserListRequest true "List"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/{id} [put]
+func (h *handler) UpdateList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	list, ok := h.bindList(c)
+	if !ok {
+		return
+	}
+	list.ID = id
+
+	if err := h.listsService.Update(ctx, list); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Delete list
+// @Description Delete one of the caller's lists
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/{id} [delete]
+func (h *handler) DeleteList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	if err := h.listsService.Delete(ctx, id); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Set list item
+// @Description Add a movie at the end of one of the caller's lists, or replace its note when it is already there
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List id"
+// @Param movie_id path int true "Movie id"
+// @Param request body models.SetListItemRequest true "Note"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/{id}/items/{movie_id} [put]
+func (h *handler) SetListItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, movieID, ok := listItemParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	err := h.listsService.SetItem(ctx, &entity.UserListItems{
+		ListID:  id,
+		MovieID: movieID,
+		Note:    req.Note,
+	})
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Remove list item
+// @Description Remove a movie from one of the caller's lists
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List id"
+// @Param movie_id path int true "Movie id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/{id}/items/{movie_id} [delete]
+func (h *handler) RemoveListItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, movieID, ok := listItemParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.listsService.RemoveItem(ctx, id, movieID); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Reorder list
+// @Description Set the order of the movies of one of the caller's lists, every movie of the list must be named once
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List id"
+// @Param request body models.ReorderListRequest true "Movie ids in order"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/{id}/order [put]
+func (h *handler) ReorderList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	var req models.ReorderListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if err := h.listsService.Reorder(ctx, id, req.MovieIDs); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Follow list
+// @Description Follow a public or unlisted list of another user, following twice is a no-op
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/{id}/follow [post]
+func (h *handler) FollowList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	if err := h.listsService.Follow(ctx, id); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Unfollow list
+// @Description Stop following a list
+// @Tags Lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /lists/{id}/follow [delete]
+func (h *handler) UnfollowList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	if err := h.listsService.Unfollow(ctx, id); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// writeList responds with list and the page of its items asked for in the query
+func (h *handler) writeList(c *gin.Context, list *entity.UserLists) {
+	var req models.GetUserListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	total, items, err := h.listsService.Items(c.Request.Context(), list.ID,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)), uint64(pointer.IntValueWithDefault(req.Page, 1)),
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetUserListResponse{
+		List:  toUserListModel(list),
+		Items: make([]models.ListItem, 0, len(items)),
+		Total: total,
+	}
+
+	for _, item := range items {
+		if item.Movie == nil {
+			continue
+		}
+
+		entry := models.ListItem{
+			Position:  item.Position,
+			Note:      item.Note,
+			AddedAt:   item.CreatedAt,
+			ID:        item.MovieID,
+			Title:     item.Movie.Title,
+			Release:   item.Movie.Release.Format(time.RFC3339),
+			PosterURL: item.Movie.PosterURL,
+		}
+		if translation := item.Movie.Translation(aud.locales, h.config.I18n.DefaultLocale); translation != nil {
+			entry.Title = translation.Title
+		}
+
+		response.Items = append(response.Items, entry)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *handler) bindListsQuery(c *gin.Context, req *models.GetUserListsRequest) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return false
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *handler) bindList(c *gin.Context) (*entity.UserLists, bool) {
+	var req models.UserListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return nil, false
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return nil, false
+	}
+
+	return &entity.UserLists{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Visibility:  entity.ListVisibility(req.Visibility),
+	}, true
+}
+
+func listItemParams(c *gin.Context) (int64, int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return 0, 0, false
+	}
+
+	movieID, err := strconv.ParseInt(c.Param("movie_id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid movie id")
+		return 0, 0, false
+	}
+
+	return id, movieID, true
+}
+
+func toUserListsResponse(total int64, lists []*entity.UserLists) models.GetUserListsResponse {
+	response := models.GetUserListsResponse{
+		Lists: make([]models.UserList, 0, len(lists)),
+		Total: total,
+	}
+
+	for _, list := range lists {
+		response.Lists = append(response.Lists, toUserListModel(list))
+	}
+
+	return response
+}
+
+func toUserListModel(list *entity.UserLists) models.UserList {
+	result := models.UserList{
+		ID:             list.ID,
+		Name:           list.Name,
+		Slug:           list.Slug,
+		Description:    list.Description,
+		Visibility:     string(list.Visibility),
+		Owner:          models.ListOwner{ID: list.UserID},
+		FollowersCount: list.FollowersCount,
+		CreatedAt:      list.CreatedAt,
+		UpdatedAt:      list.UpdatedAt,
+	}
+
+	if list.User != nil {
+		result.Owner.Username = list.User.Username
+	}
+
+	return result
+}
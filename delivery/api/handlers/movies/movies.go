@@ -1,6 +1,7 @@
 package movies
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,37 +13,97 @@ import (
 	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
 	"github.com/AsaHero/movie-app-server/delivery/api/validation"
 	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/service/charts"
 	"github.com/AsaHero/movie-app-server/internal/service/genres"
+	"github.com/AsaHero/movie-app-server/internal/service/lists"
 	"github.com/AsaHero/movie-app-server/internal/service/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/progress"
+	"github.com/AsaHero/movie-app-server/internal/service/ratings"
+	"github.com/AsaHero/movie-app-server/internal/service/recommendations"
+	"github.com/AsaHero/movie-app-server/internal/service/users"
 	"github.com/AsaHero/movie-app-server/pkg/config"
+	"github.com/AsaHero/movie-app-server/pkg/video"
 	"github.com/gin-gonic/gin"
 	"github.com/shogo82148/pointer"
 )
 
 type handler struct {
-	config        *config.Config
-	validator     *validation.Validator
-	moviesService movies.Service
-	genresService genres.Service
+	config                 *config.Config
+	validator              *validation.Validator
+	moviesService          movies.Service
+	genresService          genres.Service
+	usersService           users.Service
+	listsService           lists.Service
+	progressService        progress.Service
+	ratingsService         ratings.Service
+	recommendationsService recommendations.Service
+	chartsService          charts.Service
 }
 
-func New(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
-	handler := handler{
-		config:        opt.Config,
-		validator:     opt.Validator,
-		moviesService: opt.MoviesSerive,
-		genresService: opt.GenresService,
+func newHandler(opt *handlers.HandlerOptions) *handler {
+	return &handler{
+		config:                 opt.Config,
+		validator:              opt.Validator,
+		moviesService:          opt.MoviesSerive,
+		genresService:          opt.GenresService,
+		usersService:           opt.UsersService,
+		listsService:           opt.ListsService,
+		progressService:        opt.ProgressService,
+		ratingsService:         opt.RatingsService,
+		recommendationsService: opt.RecommendationsService,
+		chartsService:          opt.ChartsService,
 	}
+}
+
+func New(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := newHandler(opt)
 
 	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
 
+	reviewDuplicates := middlewares.RequireAdmin(opt.UsersService, "Only admins can review duplicates")
+	mergeMovies := middlewares.RequireAdmin(opt.UsersService, "Only admins can merge movies")
+	manageTranslations := middlewares.RequireAdmin(opt.UsersService, "Only admins can manage translations")
+	manageReleases := middlewares.RequireAdmin(opt.UsersService, "Only admins can manage releases")
+	manageTrash := middlewares.RequireAdmin(opt.UsersService, "Only admins can manage the trash")
+	readHistory := middlewares.RequireAdmin(opt.UsersService, "Only admins can read movie history")
+	revertMovies := middlewares.RequireAdmin(opt.UsersService, "Only admins can revert movies")
+
 	router.POST("/", handler.CreateMovie)
 	router.GET("/", handler.GetAllMovies)
+	router.GET("/trash", manageTrash, handler.GetMovieTrash)
+	router.GET("/export", handler.ExportMovies)
+	router.GET("/duplicates", reviewDuplicates, handler.GetMovieDuplicates)
+	router.POST("/duplicates/dismiss", reviewDuplicates, handler.DismissMovieDuplicate)
+	router.GET("/by-external/:source/:external_id", handler.GetMovieByExternalID)
+	router.POST("/import", handler.ImportMovies)
+	router.GET("/import/:job_id", handler.GetImportJob)
 	router.GET("/:id", handler.GetMovie)
 	router.PUT("/:id", handler.UpdateMovie)
+	router.PATCH("/:id", handler.PatchMovie)
 	router.DELETE("/:id", handler.DeleteMovie)
+	router.POST("/:id/restore", manageTrash, handler.RestoreMovie)
+	router.PUT("/:id/status", handler.SetMovieStatus)
+	router.POST("/:id/merge", mergeMovies, handler.MergeMovie)
+	router.PUT("/:id/external-ids/:source", handler.SetMovieExternalID)
+	router.POST("/:id/images/:kind", handler.UploadMovieImage)
+	router.DELETE("/:id/images/:kind", handler.DeleteMovieImage)
+	router.DELETE("/:id/external-ids/:source", handler.RemoveMovieExternalID)
+	router.GET("/:id/translations", manageTranslations, handler.GetMovieTranslations)
+	router.PUT("/:id/translations/:locale", manageTranslations, handler.SetMovieTranslation)
+	router.DELETE("/:id/translations/:locale", manageTranslations, handler.RemoveMovieTranslation)
+	router.GET("/:id/releases", manageReleases, handler.GetMovieReleases)
+	router.PUT("/:id/releases/:country/:type", manageReleases, handler.SetMovieRelease)
+	router.DELETE("/:id/releases/:country/:type", manageReleases, handler.RemoveMovieRelease)
+	router.GET("/:id/similar", handler.GetSimilarMovies)
+	router.POST("/:id/open", handler.OpenMovie)
+	router.GET("/:id/history", readHistory, handler.GetMovieHistory)
+	router.POST("/:id/history/:revision_id/revert", revertMovies, handler.RevertMovie)
 
 	router.GET("/genres", handler.GetAllGenres)
+	router.GET("/genres/:id/translations", manageTranslations, handler.GetGenreTranslations)
+	router.PUT("/genres/:id/translations/:locale", manageTranslations, handler.SetGenreTranslation)
+	router.DELETE("/genres/:id/translations/:locale", manageTranslations, handler.RemoveGenreTranslation)
 }
 
 // @Security ApiKeyAuth
@@ -84,10 +145,10 @@ func (h *handler) CreateMovie(c *gin.Context) {
 		TrailerURL:      req.TrailerURL,
 	}
 
-	genres := make([]*entity.MovieGenres, 0, len(req.Genres))
+	genres := make([]*entity.TitleGenres, 0, len(req.Genres))
 
 	for _, genreID := range req.Genres {
-		genres = append(genres, &entity.MovieGenres{
+		genres = append(genres, &entity.TitleGenres{
 			GenreID: int64(genreID),
 		})
 	}
@@ -107,12 +168,21 @@ func (h *handler) CreateMovie(c *gin.Context) {
 // @Tags Movies
 // @Accept json
 // @Produce json
-// @Param search query string false "Search term"
+// @Param search query string false "Search term, matched against translated titles too"
 // @Param genres query []string false "Filter by genres" collectionFormat(csv)
+// @Param decades query []int false "Filter by release decades, e.g. 1990,2010" collectionFormat(csv)
+// @Param years query []int false "Filter by release years" collectionFormat(csv)
+// @Param durations query []string false "Filter by duration buckets" collectionFormat(csv) Enums(short,medium,long,epic)
+// @Param facets query []string false "Facet counts to return" collectionFormat(csv) Enums(genres,decades,years,durations)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param order_by query string false "Order by field" Enums(title,release,created_at)
-// @Param order_dir query string false "Order direction" Enums(asc,desc)
+// @Param order_by query string false "Order by field, popularity is the activity of the last month" Enums(title,release,created_at,popularity)
+// @Param order_dir query string false "Order direction, popularity defaults to desc" Enums(asc,desc)
+// @Param country query string false "Only movies released in this country, also picks local_release" example(US)
+// @Param max_certification query string false "Strictest certification allowed in the country, e.g. PG-13 or 16"
+// @Param status query []string false "Workflow stages to list instead of published movies, admins only" collectionFormat(csv) Enums(draft,in_review,scheduled,published)
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
 // @Success 200 {object} models.GetAllMoviesResponse
 // @Failure 400 {object} outerr.ErrorResponse
 // @Failure 500 {object} outerr.ErrorResponse
@@ -131,26 +201,44 @@ func (h *handler) GetAllMovies(c *gin.Context) {
 		return
 	}
 
-	var genreIDs []int
-	if req.Genres != "" {
-		genresList := strings.Split(req.Genres, ",")
-		for _, g := range genresList {
-			id, err := strconv.Atoi(g)
-			if err != nil {
-				outerr.BadRequest(c, "Invalid genre ID format")
-				return
-			}
-			genreIDs = append(genreIDs, id)
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	filters, err := parseMovieFilters(req.MovieFilterQuery, aud)
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	for _, name := range parseStringList(req.Status) {
+		status := entity.MovieStatus(name)
+		if !status.IsValid() {
+			outerr.BadRequest(c, "Invalid status: "+name)
+			return
 		}
+		filters.Statuses = append(filters.Statuses, status)
+	}
+
+	if len(filters.Statuses) > 0 && !middlewares.IsAdmin(c, h.usersService, "Only admins can list unpublished movies") {
+		return
+	}
+
+	var facets []entity.MovieFacet
+	for _, name := range parseStringList(req.Facets) {
+		facet := entity.MovieFacet(name)
+		if !facet.IsValid() {
+			outerr.BadRequest(c, "Invalid facet: "+name)
+			return
+		}
+		facets = append(facets, facet)
 	}
 
 	total, movies, err := h.moviesService.List(ctx,
 		uint64(*req.Limit), uint64(*req.Page),
 		pointer.StringValue(req.OrderBy), pointer.StringValue(req.OrderDir),
-		entity.MovieFilters{
-			Search: req.Search,
-			Genres: genreIDs,
-		},
+		filters,
 	)
 	if err != nil {
 		outerr.HandleError(c, err)
@@ -162,23 +250,23 @@ func (h *handler) GetAllMovies(c *gin.Context) {
 		Movies: make([]models.Movie, 0, len(movies)),
 	}
 
-	for _, movie := range movies {
-		mov := models.Movie{
-			ID:              movie.ID,
-			Title:           movie.Title,
-			Release:         movie.Release.Format(time.RFC3339),
-			Plot:            movie.Plot,
-			DurationMinutes: movie.DurationMinutes,
-			PosterURL:       movie.PosterURL,
-			TrailerURL:      movie.TrailerURL,
-			Genres:          make([]string, 0, len(movie.MovieGenres)),
+	if len(facets) > 0 {
+		movieFacets, err := h.moviesService.Facets(ctx, filters, facets)
+		if err != nil {
+			outerr.HandleError(c, err)
+			return
 		}
 
-		for _, genre := range movie.MovieGenres {
-			mov.Genres = append(mov.Genres, genre.Genre.Name)
+		response.Facets = &models.MovieFacets{
+			Genres:    toFacetBuckets(movieFacets.Genres),
+			Decades:   toFacetBuckets(movieFacets.Decades),
+			Years:     toFacetBuckets(movieFacets.Years),
+			Durations: toFacetBuckets(movieFacets.Durations),
 		}
+	}
 
-		response.Movies = append(response.Movies, mov)
+	for i := range movies {
+		response.Movies = append(response.Movies, h.toLocalizedMovieModel(&movies[i], aud))
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -191,8 +279,16 @@ func (h *handler) GetAllMovies(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Movie id"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Param country query string false "Country of local_release, defaults to the region of the preferred locale"
+// @Param preview query bool false "Return the movie whatever its workflow status, admins only"
 // @Success 200 {object} models.Movie
+// @Success 302 "Merged into another movie, Location points at it"
+// @Success 304 "Not modified"
 // @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
 // @Failure 500 {object} outerr.ErrorResponse
 // @Router /movies/{id} [get]
 func (h *handler) GetMovie(c *gin.Context) {
@@ -216,28 +312,59 @@ func (h *handler) GetMovie(c *gin.Context) {
 		return
 	}
 
-	movie, err := h.moviesService.GetByID(ctx, id)
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	preview := false
+	if raw := c.Query("preview"); raw != "" {
+		if preview, err = strconv.ParseBool(raw); err != nil {
+			outerr.BadRequest(c, "Invalid preview")
+			return
+		}
+	}
+
+	var movie *entity.Movies
+	if preview {
+		if !middlewares.IsAdmin(c, h.usersService, "Only admins can preview movies") {
+			return
+		}
+
+		movie, err = h.moviesService.Preview(ctx, id)
+	} else {
+		movie, err = h.moviesService.GetByID(ctx, id)
+	}
+	if inerr.IsErrNotFound(err) && !preview {
+		// the movie may have been merged into another one. The redirect is not
+		// permanent since restoring it from the trash makes the id live again.
+		if targetID, redirectErr := h.moviesService.RedirectTarget(ctx, id); redirectErr == nil {
+			location := *c.Request.URL
+			location.Path = strings.TrimSuffix(location.Path, value) + strconv.FormatInt(targetID, 10)
+			c.Redirect(http.StatusFound, location.String())
+			return
+		}
+	}
 	if err != nil {
 		outerr.HandleError(c, err)
 		return
 	}
 
-	response := models.Movie{
-		ID:              movie.ID,
-		Title:           movie.Title,
-		Release:         movie.Release.Format(time.RFC3339),
-		Plot:            movie.Plot,
-		DurationMinutes: movie.DurationMinutes,
-		PosterURL:       movie.PosterURL,
-		TrailerURL:      movie.TrailerURL,
-		Genres:          make([]string, 0, len(movie.MovieGenres)),
+	if !preview {
+		h.chartsService.Record(ctx, movie.ID, entity.MovieEventView)
 	}
 
-	for _, genre := range movie.MovieGenres {
-		response.Genres = append(response.Genres, genre.Genre.Name)
+	etag := localizedMovieETag(movie.Version, aud)
+	c.Header("ETag", etag)
+
+	if notModified(c, etag) {
+		c.Status(http.StatusNotModified)
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	mov := h.toLocalizedMovieModel(movie, aud)
+	c.Header("Content-Language", mov.Locale)
+	c.JSON(http.StatusOK, mov)
 }
 
 // @Security ApiKeyAuth
@@ -248,13 +375,21 @@ func (h *handler) GetMovie(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Movie id"
 // @Param request body models.UpdateMovieRequest true "Update movie request"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Success 200 {object} models.Empty
 // @Failure 400 {object} outerr.ErrorResponse
+// @Failure 412 {object} outerr.ErrorResponse
+// @Failure 428 {object} outerr.ErrorResponse
 // @Failure 500 {object} outerr.ErrorResponse
 // @Router /movies/{id} [put]
 func (h *handler) UpdateMovie(c *gin.Context) {
 	ctx := c.Request.Context()
 
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
 	userID := c.GetString("user_id")
 	if userID == "" {
 		outerr.Unauthorized(c, "user_id is required")
@@ -273,6 +408,11 @@ func (h *handler) UpdateMovie(c *gin.Context) {
 		return
 	}
 
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req models.UpdateMovieRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		outerr.BadRequest(c, err.Error())
@@ -298,12 +438,14 @@ func (h *handler) UpdateMovie(c *gin.Context) {
 		DurationMinutes: req.DurationMinutes,
 		PosterURL:       req.PosterURL,
 		TrailerURL:      req.TrailerURL,
+		Version:         version,
 	}
 
 	for _, genreID := range req.Genres {
-		movie.MovieGenres = append(movie.MovieGenres, entity.MovieGenres{
-			MovieID: movie.ID,
-			GenreID: int64(genreID),
+		movie.MovieGenres = append(movie.MovieGenres, entity.TitleGenres{
+			TitleType: entity.TitleTypeMovie,
+			TitleID:   movie.ID,
+			GenreID:   int64(genreID),
 		})
 	}
 
@@ -312,6 +454,7 @@ func (h *handler) UpdateMovie(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", localizedMovieETag(movie.Version, aud))
 	c.JSON(http.StatusOK, models.Empty{})
 }
 
@@ -322,8 +465,13 @@ func (h *handler) UpdateMovie(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Movie id"
+// @Param hard query bool false "Delete permanently instead of moving to trash (admin only)"
+// @Param If-Match header string false "ETag of the version being deleted"
 // @Success 200 {object} models.Empty
 // @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 412 {object} outerr.ErrorResponse
+// @Failure 428 {object} outerr.ErrorResponse
 // @Failure 500 {object} outerr.ErrorResponse
 // @Router /movies/{id} [delete]
 func (h *handler) DeleteMovie(c *gin.Context) {
@@ -347,7 +495,107 @@ func (h *handler) DeleteMovie(c *gin.Context) {
 		return
 	}
 
-	if err := h.moviesService.Delete(ctx, id); err != nil {
+	var req models.DeleteMovieRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if req.Hard {
+		if !middlewares.IsAdmin(c, h.usersService, "Only admins can delete movies permanently") {
+			return
+		}
+
+		if err := h.moviesService.HardDelete(ctx, id, version); err != nil {
+			outerr.HandleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, models.Empty{})
+		return
+	}
+
+	if err := h.moviesService.Delete(ctx, id, version); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Get deleted movies
+// @Description Get movies in the trash, most recently deleted first (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} models.GetAllMoviesResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/trash [get]
+func (h *handler) GetMovieTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetMovieTrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	total, movies, err := h.moviesService.ListTrash(ctx, uint64(*req.Limit), uint64(*req.Page))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetAllMoviesResponse{
+		Total:  total,
+		Movies: make([]models.Movie, 0, len(movies)),
+	}
+
+	for i := range movies {
+		response.Movies = append(response.Movies, toMovieModel(&movies[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Restore movie
+// @Description Restore a movie from the trash (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/restore [post]
+func (h *handler) RestoreMovie(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	if err := h.moviesService.Restore(ctx, id); err != nil {
 		outerr.HandleError(c, err)
 		return
 	}
@@ -361,6 +609,8 @@ func (h *handler) DeleteMovie(c *gin.Context) {
 // @Tags Genres
 // @Accept json
 // @Produce json
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
 // @Success 200 {object} models.GetAllGenresResponse
 // @Failure 400 {object} outerr.ErrorResponse
 // @Failure 500 {object} outerr.ErrorResponse
@@ -368,6 +618,11 @@ func (h *handler) DeleteMovie(c *gin.Context) {
 func (h *handler) GetAllGenres(r *gin.Context) {
 	ctx := r.Request.Context()
 
+	aud, ok := h.audience(r)
+	if !ok {
+		return
+	}
+
 	genres, err := h.genresService.GetAll(ctx)
 	if err != nil {
 		outerr.HandleError(r, err)
@@ -381,9 +636,278 @@ func (h *handler) GetAllGenres(r *gin.Context) {
 	for _, genre := range genres {
 		response.Genres = append(response.Genres, models.Gener{
 			ID:   genre.ID,
-			Name: genre.Name,
+			Name: h.localizedGenreName(genre, aud),
 		})
 	}
 
 	r.JSON(http.StatusOK, response)
 }
+
+// @Security ApiKeyAuth
+// @Summary Get movie history
+// @Description Get the audit trail of a movie, newest revision first (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} models.GetMovieHistoryResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/history [get]
+func (h *handler) GetMovieHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	var req models.GetMovieHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	total, revisions, err := h.moviesService.History(ctx, id, uint64(*req.Limit), uint64(*req.Page))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetMovieHistoryResponse{
+		Total:     total,
+		Revisions: make([]models.MovieRevision, 0, len(revisions)),
+	}
+
+	for _, revision := range revisions {
+		changes, err := revision.Diff()
+		if err != nil {
+			outerr.HandleError(c, err)
+			return
+		}
+
+		rev := models.MovieRevision{
+			ID:        revision.ID,
+			Action:    string(revision.Action),
+			ActorID:   revision.ActorID,
+			Before:    revision.Before,
+			After:     revision.After,
+			Changes:   make([]models.FieldChange, 0, len(changes)),
+			CreatedAt: revision.CreatedAt,
+		}
+
+		for _, change := range changes {
+			rev.Changes = append(rev.Changes, models.FieldChange{
+				Field:  change.Field,
+				Before: change.Before,
+				After:  change.After,
+			})
+		}
+
+		response.Revisions = append(response.Revisions, rev)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Revert movie
+// @Description Revert a movie to the state recorded by a revision, reverting a delete restores it from the trash (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param revision_id path int true "Revision id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/history/{revision_id}/revert [post]
+func (h *handler) RevertMovie(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	revisionID, err := strconv.ParseInt(c.Param("revision_id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid revision id")
+		return
+	}
+
+	if err := h.moviesService.Revert(ctx, id, revisionID); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// parseMovieFilters converts the comma separated query values into MovieFilters.
+// max_certification is read in the audience's country.
+func parseMovieFilters(query models.MovieFilterQuery, aud audience) (entity.MovieFilters, error) {
+	genreIDs, err := parseIntList(query.Genres)
+	if err != nil {
+		return entity.MovieFilters{}, errors.New("Invalid genre ID format")
+	}
+
+	decades, err := parseIntList(query.Decades)
+	if err != nil {
+		return entity.MovieFilters{}, errors.New("Invalid decade format")
+	}
+
+	years, err := parseIntList(query.Years)
+	if err != nil {
+		return entity.MovieFilters{}, errors.New("Invalid year format")
+	}
+
+	durations := parseStringList(query.Durations)
+	for _, key := range durations {
+		if _, ok := entity.FindDurationBucket(key); !ok {
+			return entity.MovieFilters{}, errors.New("Invalid duration bucket: " + key)
+		}
+	}
+
+	filters := entity.MovieFilters{
+		Search:    query.Search,
+		Genres:    genreIDs,
+		Decades:   decades,
+		Years:     years,
+		Durations: durations,
+	}
+
+	// audience already validated the country parameter
+	if query.Country != nil && *query.Country != "" {
+		filters.Country = aud.country
+	}
+
+	if query.MaxCertification != nil && *query.MaxCertification != "" {
+		if aud.country == "" {
+			return entity.MovieFilters{}, errors.New("max_certification requires a country")
+		}
+
+		age, ok := entity.CertificationAge(aud.country, *query.MaxCertification)
+		if !ok {
+			return entity.MovieFilters{}, errors.New("Unknown certification " + *query.MaxCertification + " for " + aud.country)
+		}
+
+		filters.AgeLimits = append(filters.AgeLimits, entity.AgeLimit{Country: aud.country, MaxAge: age})
+	}
+
+	return filters, nil
+}
+
+func parseStringList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseIntList(value string) ([]int, error) {
+	var items []int
+	for _, item := range parseStringList(value) {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, n)
+	}
+	return items, nil
+}
+
+func toFacetBuckets(buckets []entity.FacetBucket) []models.FacetBucket {
+	if buckets == nil {
+		return nil
+	}
+
+	result := make([]models.FacetBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, models.FacetBucket{
+			Value: bucket.Value,
+			Label: bucket.Label,
+			Count: bucket.Count,
+		})
+	}
+	return result
+}
+
+func toMovieModel(movie *entity.Movies) models.Movie {
+	mov := models.Movie{
+		ID:              movie.ID,
+		Title:           movie.Title,
+		Release:         movie.Release.Format(time.RFC3339),
+		Plot:            movie.Plot,
+		DurationMinutes: movie.DurationMinutes,
+		PosterURL:       movie.PosterURL,
+		TrailerURL:      movie.TrailerURL,
+		Genres:          make([]string, 0, len(movie.MovieGenres)),
+		ExternalIDs:     make(map[string]string, len(movie.ExternalIDs)),
+		Version:         movie.Version,
+		Status:          string(movie.Status),
+		PublishAt:       movie.PublishAt,
+		PublishedAt:     movie.PublishedAt,
+		PublishedBy:     movie.PublishedBy,
+		CreatedAt:       movie.CreatedAt,
+		UpdatedAt:       movie.UpdatedAt,
+	}
+
+	if movie.DeletedAt.Valid {
+		mov.DeletedAt = &movie.DeletedAt.Time
+	}
+
+	for _, genre := range movie.MovieGenres {
+		if genre.Genre != nil {
+			mov.Genres = append(mov.Genres, genre.Genre.Name)
+		}
+	}
+
+	if movie.TrailerProvider != nil && movie.TrailerVideoID != nil {
+		trailer := video.Video{Provider: video.Provider(*movie.TrailerProvider), ID: *movie.TrailerVideoID}
+		mov.Trailer = &models.Trailer{
+			Provider: *movie.TrailerProvider,
+			VideoID:  *movie.TrailerVideoID,
+			EmbedURL: trailer.EmbedURL(),
+		}
+	}
+
+	for _, externalID := range movie.ExternalIDs {
+		mov.ExternalIDs[string(externalID.Source)] = externalID.Value
+	}
+
+	for _, image := range movie.Images {
+		var variants *map[string]string
+		switch image.Kind {
+		case entity.ImageKindPoster:
+			variants = &mov.Images.Poster
+		case entity.ImageKindBackdrop:
+			variants = &mov.Images.Backdrop
+		default:
+			continue
+		}
+
+		if *variants == nil {
+			*variants = make(map[string]string)
+		}
+		(*variants)[image.Variant] = image.URL
+	}
+
+	return mov
+}
@@ -0,0 +1,155 @@
+package movies
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// patchableFields lists the merge patch members and whether they accept null
+var patchableFields = map[string]bool{
+	"title":            false,
+	"release":          false,
+	"plot":             true,
+	"duration_minutes": false,
+	"poster_url":       false,
+	"trailer_url":      false,
+	"genres":           true,
+}
+
+// @Security ApiKeyAuth
+// @Summary Patch movie
+// @Description Partially update a movie with a JSON Merge Patch (RFC 7396). Only supplied fields are validated and written, genres are replaced only when present, null clears plot or genres.
+// @Tags Movies
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param request body models.PatchMovieRequest true "Merge patch"
+// @Param If-Match header string false "ETag of the version being updated"
+// @Success 200 {object} models.Movie
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 412 {object} outerr.ErrorResponse
+// @Failure 415 {object} outerr.ErrorResponse
+// @Failure 428 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id} [patch]
+func (h *handler) PatchMovie(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	switch c.ContentType() {
+	case "application/merge-patch+json", "application/json":
+	default:
+		outerr.UnsupportedMediaType(c, "Content-Type must be application/merge-patch+json")
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		outerr.BadRequest(c, "Merge patch must be a JSON object")
+		return
+	}
+
+	for field, value := range members {
+		nullable, known := patchableFields[field]
+		if !known {
+			outerr.BadRequest(c, "Unknown field: "+field)
+			return
+		}
+
+		if !nullable && bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			outerr.BadRequest(c, "Field cannot be removed: "+field)
+			return
+		}
+	}
+
+	var req models.PatchMovieRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	patch := entity.MoviePatch{
+		Title:           req.Title,
+		Plot:            req.Plot,
+		DurationMinutes: req.DurationMinutes,
+		PosterURL:       req.PosterURL,
+		TrailerURL:      req.TrailerURL,
+	}
+
+	if _, ok := members["plot"]; ok && req.Plot == nil {
+		patch.ClearPlot = true
+	}
+
+	if req.Release != nil {
+		release, err := parseReleaseDate(*req.Release)
+		if err != nil {
+			outerr.BadRequest(c, "Invalid release date, format should be YYYY-MM-DD")
+			return
+		}
+		patch.Release = &release
+	}
+
+	if _, ok := members["genres"]; ok {
+		genreIDs := make([]int64, 0)
+		if req.Genres != nil {
+			for _, genreID := range *req.Genres {
+				genreIDs = append(genreIDs, int64(genreID))
+			}
+		}
+		patch.GenreIDs = &genreIDs
+	}
+
+	movie, err := h.moviesService.Patch(ctx, id, version, patch)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.Header("ETag", localizedMovieETag(movie.Version, aud))
+	c.JSON(http.StatusOK, h.toLocalizedMovieModel(movie, aud))
+}
+
+// parseReleaseDate accepts both the create (YYYY-MM-DD) and update (RFC 3339) formats
+func parseReleaseDate(value string) (time.Time, error) {
+	release, err := time.Parse(time.DateOnly, value)
+	if err == nil {
+		return release, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,272 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
+	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+)
+
+// watchableTypes maps the path segments of /me/progress to the kind of video
+var watchableTypes = map[string]entity.WatchableType{
+	"movies":   entity.WatchableMovie,
+	"episodes": entity.WatchableEpisode,
+}
+
+// NewMe registers the routes about the caller's own viewing, they share the
+// movie handler to render titles for the caller's locale
+func NewMe(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := newHandler(opt)
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	router.GET("/continue-watching", handler.GetContinueWatching)
+	router.GET("/progress/:type/:id", handler.GetProgress)
+	router.PUT("/progress/:type/:id", handler.SaveProgress)
+	router.DELETE("/progress/:type/:id", handler.RemoveProgress)
+	router.GET("/ratings/:movie_id", handler.GetRating)
+	router.PUT("/ratings/:movie_id", handler.RateMovie)
+	router.DELETE("/ratings/:movie_id", handler.RemoveRating)
+	router.GET("/recommendations", handler.GetRecommendations)
+}
+
+// @Security ApiKeyAuth
+// @Summary Continue watching
+// @Description Get the movies and episodes the caller started and did not finish, most recently watched first
+// @Tags Progress
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Success 200 {object} models.GetContinueWatchingResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /me/continue-watching [get]
+func (h *handler) GetContinueWatching(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetContinueWatchingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	total, progress, err := h.progressService.ContinueWatching(ctx,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)), uint64(pointer.IntValueWithDefault(req.Page, 1)),
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetContinueWatchingResponse{
+		Entries: make([]models.ContinueWatchingEntry, 0, len(progress)),
+		Total:   total,
+	}
+
+	for _, p := range progress {
+		if entry := h.toContinueWatchingEntry(p, aud); entry != nil {
+			response.Entries = append(response.Entries, *entry)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get watch progress
+// @Description Get the caller's playback position in a movie or an episode, to resume it
+// @Tags Progress
+// @Accept json
+// @Produce json
+// @Param type path string true "movies or episodes"
+// @Param id path int true "Movie or episode id"
+// @Success 200 {object} models.WatchProgress
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /me/progress/{type}/{id} [get]
+func (h *handler) GetProgress(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	watchableType, id, ok := progressParams(c)
+	if !ok {
+		return
+	}
+
+	progress, err := h.progressService.Get(ctx, watchableType, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWatchProgressModel(progress))
+}
+
+// @Security ApiKeyAuth
+// @Summary Save watch progress
+// @Description Record the caller's playback position, meant to be sent as a heartbeat while playing. Past WATCH_COMPLETE_PERCENT of the duration the video is marked as watched.
+// @Tags Progress
+// @Accept json
+// @Produce json
+// @Param type path string true "movies or episodes"
+// @Param id path int true "Movie or episode id"
+// @Param request body models.SaveProgressRequest true "Position"
+// @Success 200 {object} models.WatchProgress
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /me/progress/{type}/{id} [put]
+func (h *handler) SaveProgress(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	watchableType, id, ok := progressParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.SaveProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	completePercent, err := strconv.ParseFloat(h.config.Progress.CompletePercent, 64)
+	if err != nil || completePercent <= 0 || completePercent > 100 {
+		outerr.Internal(c, "Invalid watch complete percent")
+		return
+	}
+
+	progress := &entity.WatchProgress{
+		WatchableType:   watchableType,
+		WatchableID:     id,
+		PositionSeconds: pointer.IntValue(req.PositionSeconds),
+		Completed:       req.Completed,
+	}
+
+	if err := h.progressService.Save(ctx, progress, completePercent); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWatchProgressModel(progress))
+}
+
+// @Security ApiKeyAuth
+// @Summary Remove watch progress
+// @Description Forget the caller's playback position in a movie or an episode, e.g. to drop it from continue watching
+// @Tags Progress
+// @Accept json
+// @Produce json
+// @Param type path string true "movies or episodes"
+// @Param id path int true "Movie or episode id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /me/progress/{type}/{id} [delete]
+func (h *handler) RemoveProgress(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	watchableType, id, ok := progressParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.progressService.Remove(ctx, watchableType, id); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func progressParams(c *gin.Context) (entity.WatchableType, int64, bool) {
+	watchableType, ok := watchableTypes[c.Param("type")]
+	if !ok {
+		outerr.BadRequest(c, "Invalid type, expected movies or episodes")
+		return "", 0, false
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return "", 0, false
+	}
+
+	return watchableType, id, true
+}
+
+// toContinueWatchingEntry renders a position with its video, nil when the
+// video was not loaded
+func (h *handler) toContinueWatchingEntry(progress *entity.WatchProgress, aud audience) *models.ContinueWatchingEntry {
+	entry := &models.ContinueWatchingEntry{
+		Type:            string(progress.WatchableType),
+		ID:              progress.WatchableID,
+		PositionSeconds: progress.PositionSeconds,
+		UpdatedAt:       progress.UpdatedAt,
+	}
+
+	switch {
+	case progress.Movie != nil:
+		entry.Title = progress.Movie.Title
+		entry.PosterURL = progress.Movie.PosterURL
+		if translation := progress.Movie.Translation(aud.locales, h.config.I18n.DefaultLocale); translation != nil {
+			entry.Title = translation.Title
+		}
+		if progress.Movie.DurationMinutes > 0 {
+			entry.DurationSeconds = pointer.Int(int(progress.Movie.DurationMinutes) * 60)
+		}
+	case progress.Episode != nil && progress.Episode.Season != nil && progress.Episode.Season.Show != nil:
+		season := progress.Episode.Season
+		entry.Title = progress.Episode.Title
+		entry.PosterURL = season.Show.PosterURL
+		if progress.Episode.RuntimeMinutes != nil {
+			entry.DurationSeconds = pointer.Int(int(*progress.Episode.RuntimeMinutes) * 60)
+		}
+		entry.Show = &models.ContinueWatchingShow{
+			ID:            season.ShowID,
+			Title:         season.Show.Title,
+			SeasonNumber:  season.SeasonNumber,
+			EpisodeNumber: progress.Episode.EpisodeNumber,
+		}
+	default:
+		return nil
+	}
+
+	return entry
+}
+
+func toWatchProgressModel(progress *entity.WatchProgress) models.WatchProgress {
+	return models.WatchProgress{
+		Type:            string(progress.WatchableType),
+		ID:              progress.WatchableID,
+		PositionSeconds: progress.PositionSeconds,
+		Completed:       progress.Completed,
+		WatchedAt:       progress.WatchedAt,
+		UpdatedAt:       progress.UpdatedAt,
+	}
+}
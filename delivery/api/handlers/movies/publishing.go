@@ -0,0 +1,74 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Summary Set movie status
+// @Description Move a movie through the editorial workflow: draft, in_review, then scheduled or published. Anyone can send a draft to review, every other move, including taking a scheduled movie back to review, is for admins. Scheduled movies are published by a background job once publish_at has passed.
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param request body models.SetMovieStatusRequest true "Set movie status request"
+// @Param If-Match header string false "ETag of the version being updated"
+// @Success 200 {object} models.Movie
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 412 {object} outerr.ErrorResponse
+// @Failure 428 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/status [put]
+func (h *handler) SetMovieStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	var req models.SetMovieStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.usersService.GetByID(ctx, c.GetString("user_id"))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	movie, err := h.moviesService.SetStatus(ctx, id, version, entity.MovieStatus(req.Status), req.PublishAt, user.IsAdmin())
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.Header("ETag", localizedMovieETag(movie.Version, aud))
+	c.JSON(http.StatusOK, h.toLocalizedMovieModel(movie, aud))
+}
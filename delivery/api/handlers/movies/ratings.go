@@ -0,0 +1,124 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Summary Get movie rating
+// @Description Get the caller's score for a movie
+// @Tags Ratings
+// @Accept json
+// @Produce json
+// @Param movie_id path int true "Movie id"
+// @Success 200 {object} models.MovieRating
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /me/ratings/{movie_id} [get]
+func (h *handler) GetRating(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	movieID, err := strconv.ParseInt(c.Param("movie_id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid movie id")
+		return
+	}
+
+	rating, err := h.ratingsService.Get(ctx, movieID)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMovieRatingModel(rating))
+}
+
+// @Security ApiKeyAuth
+// @Summary Rate movie
+// @Description Set the caller's score for a movie, from 1 to 10. Scores of 6 and more count as liking it for recommendations.
+// @Tags Ratings
+// @Accept json
+// @Produce json
+// @Param movie_id path int true "Movie id"
+// @Param request body models.RateMovieRequest true "Score"
+// @Success 200 {object} models.MovieRating
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /me/ratings/{movie_id} [put]
+func (h *handler) RateMovie(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	movieID, err := strconv.ParseInt(c.Param("movie_id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid movie id")
+		return
+	}
+
+	var req models.RateMovieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	rating := &entity.MovieRatings{
+		MovieID: movieID,
+		Score:   req.Score,
+	}
+
+	if err := h.ratingsService.Rate(ctx, rating); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMovieRatingModel(rating))
+}
+
+// @Security ApiKeyAuth
+// @Summary Remove movie rating
+// @Description Remove the caller's score for a movie
+// @Tags Ratings
+// @Accept json
+// @Produce json
+// @Param movie_id path int true "Movie id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /me/ratings/{movie_id} [delete]
+func (h *handler) RemoveRating(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	movieID, err := strconv.ParseInt(c.Param("movie_id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid movie id")
+		return
+	}
+
+	if err := h.ratingsService.Remove(ctx, movieID); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func toMovieRatingModel(rating *entity.MovieRatings) models.MovieRating {
+	return models.MovieRating{
+		MovieID:   rating.MovieID,
+		Score:     rating.Score,
+		UpdatedAt: rating.UpdatedAt,
+	}
+}
@@ -0,0 +1,100 @@
+package movies
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+)
+
+// @Security ApiKeyAuth
+// @Summary Get recommendations
+// @Description Recommend movies the caller has not started nor rated, from the movies they rated highly or watched through. Callers with little history get more of what is popular. Each recommendation says why it was picked.
+// @Tags Progress
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Success 200 {object} models.GetRecommendationsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /me/recommendations [get]
+func (h *handler) GetRecommendations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetRecommendationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	recommendations, err := h.recommendationsService.Recommend(ctx, uint64(pointer.IntValueWithDefault(req.Limit, 20)))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetRecommendationsResponse{
+		Recommendations: make([]models.Recommendation, 0, len(recommendations)),
+	}
+
+	for _, recommendation := range recommendations {
+		if recommendation.Movie == nil {
+			continue
+		}
+
+		response.Recommendations = append(response.Recommendations, models.Recommendation{
+			ID:        recommendation.MovieID,
+			Title:     h.localizedTitle(recommendation.Movie, aud),
+			Release:   recommendation.Movie.Release.Format(time.RFC3339),
+			PosterURL: recommendation.Movie.PosterURL,
+			Score:     recommendation.Score,
+			Reason:    h.toRecommendationReason(recommendation, aud),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *handler) toRecommendationReason(recommendation *entity.Recommendation, aud audience) models.RecommendationReason {
+	if recommendation.Because == nil {
+		return models.RecommendationReason{
+			Type:    "popular",
+			Message: "Popular with other viewers",
+		}
+	}
+
+	title := h.localizedTitle(recommendation.Because, aud)
+
+	return models.RecommendationReason{
+		Type:    "similar",
+		Message: "Because you liked " + title,
+		Movie: &models.RecommendationSource{
+			ID:    recommendation.Because.ID,
+			Title: title,
+		},
+	}
+}
+
+// localizedTitle returns the movie's title in the audience's preferred locale
+func (h *handler) localizedTitle(movie *entity.Movies, aud audience) string {
+	if translation := movie.Translation(aud.locales, h.config.I18n.DefaultLocale); translation != nil {
+		return translation.Title
+	}
+	return movie.Title
+}
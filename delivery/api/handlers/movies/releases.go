@@ -0,0 +1,178 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/pkg/locale"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Summary Get movie releases
+// @Description Get the release dates and certifications of a movie in every country (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Success 200 {object} models.GetMovieReleasesResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/releases [get]
+func (h *handler) GetMovieReleases(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	releases, err := h.moviesService.ListReleases(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetMovieReleasesResponse{
+		Releases: make([]models.MovieRelease, 0, len(releases)),
+	}
+
+	for _, release := range releases {
+		response.Releases = append(response.Releases, toMovieReleaseModel(release))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Set movie release
+// @Description Create or replace the movie's release of a type in a country (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param country path string true "ISO 3166-1 alpha-2 country code" example(US)
+// @Param type path string true "Release type" Enums(theatrical, digital)
+// @Param request body models.SetMovieReleaseRequest true "Release"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/releases/{country}/{type} [put]
+func (h *handler) SetMovieRelease(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	country, releaseType, ok := releaseParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetMovieReleaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	releaseDate, err := time.Parse(time.DateOnly, req.Date)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid release date, format should be YYYY-MM-DD")
+		return
+	}
+
+	if req.Certification != nil {
+		certification := strings.ToUpper(strings.TrimSpace(*req.Certification))
+		req.Certification = &certification
+
+		if _, ok := entity.CertificationAge(country, certification); !ok {
+			outerr.BadRequest(c, "Unknown certification "+*req.Certification+" for "+country)
+			return
+		}
+	}
+
+	err = h.moviesService.SetRelease(ctx, &entity.MovieReleases{
+		MovieID:       id,
+		Country:       country,
+		Type:          releaseType,
+		ReleaseDate:   releaseDate,
+		Certification: req.Certification,
+	})
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Remove movie release
+// @Description Remove the movie's release of a type in a country (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param country path string true "ISO 3166-1 alpha-2 country code" example(US)
+// @Param type path string true "Release type" Enums(theatrical, digital)
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/releases/{country}/{type} [delete]
+func (h *handler) RemoveMovieRelease(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	country, releaseType, ok := releaseParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.moviesService.RemoveRelease(ctx, id, country, releaseType); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func releaseParams(c *gin.Context) (string, entity.ReleaseType, bool) {
+	country, ok := locale.NormalizeCountry(c.Param("country"))
+	if !ok {
+		outerr.BadRequest(c, "Invalid country, expected an ISO 3166-1 alpha-2 code")
+		return "", "", false
+	}
+
+	releaseType := entity.ReleaseType(c.Param("type"))
+	if !releaseType.IsValid() {
+		outerr.BadRequest(c, "Unknown release type, expected theatrical or digital")
+		return "", "", false
+	}
+
+	return country, releaseType, true
+}
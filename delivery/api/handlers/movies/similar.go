@@ -0,0 +1,75 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+)
+
+// @Security ApiKeyAuth
+// @Summary Get similar movies
+// @Description Get movies related to a movie, best first, ranked by shared genres (rare genres count more), plot similarity and release proximity. Rankings are cached until the movie or its genres change.
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param limit query int false "Limit, at most 50"
+// @Param lang query string false "Preferred locales, comma separated, e.g. pt-BR,en"
+// @Param Accept-Language header string false "Preferred locales, used after lang"
+// @Success 200 {object} models.GetSimilarMoviesResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/similar [get]
+func (h *handler) GetSimilarMovies(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	var req models.GetSimilarMoviesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	aud, ok := h.audience(c)
+	if !ok {
+		return
+	}
+
+	similar, err := h.moviesService.Similar(ctx, id, uint64(pointer.IntValueWithDefault(req.Limit, 12)))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetSimilarMoviesResponse{
+		Movies: make([]models.SimilarMovie, 0, len(similar)),
+	}
+
+	for _, s := range similar {
+		response.Movies = append(response.Movies, models.SimilarMovie{
+			ID:        s.Movie.ID,
+			Title:     h.localizedTitle(s.Movie, aud),
+			Release:   s.Movie.Release.Format(time.RFC3339),
+			PosterURL: s.Movie.PosterURL,
+			Score:     s.Score,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
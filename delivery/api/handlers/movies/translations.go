@@ -0,0 +1,289 @@
+package movies
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/pkg/locale"
+	"github.com/gin-gonic/gin"
+)
+
+// @Security ApiKeyAuth
+// @Summary Get movie translations
+// @Description Get every localized title, plot and tagline of a movie (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Success 200 {object} models.GetMovieTranslationsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/translations [get]
+func (h *handler) GetMovieTranslations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	translations, err := h.moviesService.ListTranslations(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetMovieTranslationsResponse{
+		Translations: make([]models.MovieTranslation, 0, len(translations)),
+	}
+
+	for _, translation := range translations {
+		response.Translations = append(response.Translations, models.MovieTranslation{
+			Locale:    translation.Locale,
+			Title:     translation.Title,
+			Plot:      translation.Plot,
+			Tagline:   translation.Tagline,
+			UpdatedAt: translation.UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Set movie translation
+// @Description Create or replace the movie's title, plot and tagline for a locale (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param locale path string true "Locale, e.g. de or pt-BR"
+// @Param request body models.SetMovieTranslationRequest true "Translation"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/translations/{locale} [put]
+func (h *handler) SetMovieTranslation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	tag, ok := localeParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetMovieTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	err = h.moviesService.SetTranslation(ctx, &entity.MovieTranslations{
+		MovieID: id,
+		Locale:  tag,
+		Title:   req.Title,
+		Plot:    req.Plot,
+		Tagline: req.Tagline,
+	})
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Remove movie translation
+// @Description Remove the movie's translation for a locale (admin only)
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie id"
+// @Param locale path string true "Locale, e.g. de or pt-BR"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/{id}/translations/{locale} [delete]
+func (h *handler) RemoveMovieTranslation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	tag, ok := localeParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.moviesService.RemoveTranslation(ctx, id, tag); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Get genre translations
+// @Description Get every localized name of a genre (admin only)
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre id"
+// @Success 200 {object} models.GetGenreTranslationsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/genres/{id}/translations [get]
+func (h *handler) GetGenreTranslations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	translations, err := h.genresService.ListTranslations(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetGenreTranslationsResponse{
+		Translations: make([]models.GenreTranslation, 0, len(translations)),
+	}
+
+	for _, translation := range translations {
+		response.Translations = append(response.Translations, models.GenreTranslation{
+			Locale:    translation.Locale,
+			Name:      translation.Name,
+			UpdatedAt: translation.UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Set genre translation
+// @Description Create or replace the genre's name for a locale (admin only)
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre id"
+// @Param locale path string true "Locale, e.g. de or pt-BR"
+// @Param request body models.SetGenreTranslationRequest true "Translation"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/genres/{id}/translations/{locale} [put]
+func (h *handler) SetGenreTranslation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	tag, ok := localeParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetGenreTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	err = h.genresService.SetTranslation(ctx, &entity.GenreTranslations{
+		GenreID: id,
+		Locale:  tag,
+		Name:    req.Name,
+	})
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Remove genre translation
+// @Description Remove the genre's name for a locale (admin only)
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre id"
+// @Param locale path string true "Locale, e.g. de or pt-BR"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /movies/genres/{id}/translations/{locale} [delete]
+func (h *handler) RemoveGenreTranslation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid id")
+		return
+	}
+
+	tag, ok := localeParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.genresService.RemoveTranslation(ctx, id, tag); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func localeParam(c *gin.Context) (string, bool) {
+	tag, ok := locale.Normalize(c.Param("locale"))
+	if !ok {
+		outerr.BadRequest(c, "Invalid locale, expected a language tag such as de or pt-BR")
+		return "", false
+	}
+	return tag, true
+}
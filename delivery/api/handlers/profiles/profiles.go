@@ -0,0 +1,318 @@
+package profiles
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
+	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/delivery/api/validation"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/internal/service/users"
+	"github.com/AsaHero/movie-app-server/pkg/config"
+	"github.com/AsaHero/movie-app-server/pkg/locale"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// pinHeader carries the account PIN on requests that need it
+const pinHeader = "X-Account-PIN"
+
+type handler struct {
+	config          *config.Config
+	validator       *validation.Validator
+	profilesService profiles.Service
+	usersService    users.Service
+}
+
+func New(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := handler{
+		config:          opt.Config,
+		validator:       opt.Validator,
+		profilesService: opt.ProfilesService,
+		usersService:    opt.UsersService,
+	}
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	router.GET("/", handler.GetProfiles)
+	router.POST("/", handler.CreateProfile)
+	router.PUT("/pin", handler.SetPIN)
+	router.PUT("/:id", handler.UpdateProfile)
+	router.DELETE("/:id", handler.DeleteProfile)
+	router.POST("/:id/select", handler.SelectProfile)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get profiles
+// @Description Get the viewing profiles of the account, the one selected for the session is marked active
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.GetProfilesResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profiles [get]
+func (h *handler) GetProfiles(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, err := h.usersService.GetByID(ctx, c.GetString("user_id"))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	result, err := h.profilesService.List(ctx)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetProfilesResponse{
+		Profiles: make([]models.Profile, 0, len(result)),
+		HasPIN:   user.HasPIN(),
+	}
+
+	for _, profile := range result {
+		response.Profiles = append(response.Profiles, models.Profile{
+			ID:               profile.ID,
+			Name:             profile.Name,
+			MaxCertification: profile.MaxCertification,
+			Country:          profile.CertificationCountry,
+			Active:           profile.ID == c.GetString("profile_id"),
+			CreatedAt:        profile.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Create profile
+// @Description Create a viewing profile, optionally limited to a maximum certification
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param request body models.ProfileRequest true "Profile"
+// @Param X-Account-PIN header string false "Account PIN, required once one is set"
+// @Success 201 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profiles [post]
+func (h *handler) CreateProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	profile, ok := h.bindProfile(c)
+	if !ok {
+		return
+	}
+
+	if err := h.profilesService.Create(ctx, profile, c.GetHeader(pinHeader)); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Update profile
+// @Description Update a viewing profile
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param id path string true "Profile id"
+// @Param request body models.ProfileRequest true "Profile"
+// @Param X-Account-PIN header string false "Account PIN, required once one is set"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profiles/{id} [put]
+func (h *handler) UpdateProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := profileIDParam(c)
+	if !ok {
+		return
+	}
+
+	profile, ok := h.bindProfile(c)
+	if !ok {
+		return
+	}
+	profile.ID = id
+
+	if err := h.profilesService.Update(ctx, profile, c.GetHeader(pinHeader)); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Delete profile
+// @Description Delete a viewing profile
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param id path string true "Profile id"
+// @Param X-Account-PIN header string false "Account PIN, required once one is set"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profiles/{id} [delete]
+func (h *handler) DeleteProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := profileIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.profilesService.Delete(ctx, id, c.GetHeader(pinHeader)); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Select profile
+// @Description Issue tokens for a viewing profile, or for the whole account with the id "account". Movie listings and lookups made with them hide titles above the profile's certification. Switching to a less restricted profile or to the account needs the account PIN, and accounts with a PIN or a restricted profile must select one before browsing.
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param id path string true "Profile id or account"
+// @Param X-Account-PIN header string false "Account PIN, required to leave a restricted profile or select the account"
+// @Success 200 {object} models.LoginResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profiles/{id}/select [post]
+func (h *handler) SelectProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Param("id")
+	if id != security.AccountProfileID {
+		if _, ok := profileIDParam(c); !ok {
+			return
+		}
+	}
+
+	profile, err := h.profilesService.Select(ctx, id, c.GetHeader(pinHeader))
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	// a nil profile is the whole account
+	profileID := security.AccountProfileID
+	if profile != nil {
+		profileID = profile.ID
+	}
+
+	accessToken, refreshToken, err := security.GenerateProfileTokenPair(c.GetString("user_id"), profileID, h.config.Token.Secret)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+// @Security ApiKeyAuth
+// @Summary Set account PIN
+// @Description Set or, with an empty pin, remove the account PIN that protects profiles
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param request body models.SetPINRequest true "Password and new PIN"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 401 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profiles/pin [put]
+func (h *handler) SetPIN(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.SetPINRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if err := h.profilesService.SetPIN(ctx, req.Password, req.PIN); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func (h *handler) bindProfile(c *gin.Context) (*entity.Profiles, bool) {
+	var req models.ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return nil, false
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return nil, false
+	}
+
+	profile := &entity.Profiles{Name: req.Name}
+
+	if req.MaxCertification != nil {
+		country, ok := locale.NormalizeCountry(*req.Country)
+		if !ok {
+			outerr.BadRequest(c, "Invalid country, expected an ISO 3166-1 alpha-2 code")
+			return nil, false
+		}
+
+		certification := strings.ToUpper(strings.TrimSpace(*req.MaxCertification))
+		if _, ok := entity.CertificationAge(country, certification); !ok {
+			outerr.BadRequest(c, "Unknown certification "+certification+" for "+country)
+			return nil, false
+		}
+
+		profile.MaxCertification = &certification
+		profile.CertificationCountry = &country
+	}
+
+	return profile, true
+}
+
+func profileIDParam(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		outerr.BadRequest(c, "Invalid profile id")
+		return "", false
+	}
+	return id, true
+}
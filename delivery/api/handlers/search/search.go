@@ -0,0 +1,96 @@
+package search
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
+	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/delivery/api/validation"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/service/titles"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+)
+
+type handler struct {
+	validator     *validation.Validator
+	titlesService titles.Service
+}
+
+func New(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := handler{
+		validator:     opt.Validator,
+		titlesService: opt.TitlesService,
+	}
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	router.GET("/", handler.Search)
+}
+
+// @Security ApiKeyAuth
+// @Summary Search titles
+// @Description Search movies and shows by title in one list, exact and prefix matches first. Movie titles match in every translation. Restricted profiles only get the movies they may see.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search term"
+// @Param type query string false "Limit results to one title type" Enums(movie, show)
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} models.SearchResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /search [get]
+func (h *handler) Search(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	req.Query = strings.TrimSpace(req.Query)
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	search := entity.TitleSearch{Query: req.Query}
+	if req.Type != nil {
+		search.Types = []entity.TitleType{entity.TitleType(*req.Type)}
+	}
+
+	total, results, err := h.titlesService.Search(ctx,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)), uint64(pointer.IntValueWithDefault(req.Page, 1)),
+		search,
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.SearchResponse{
+		Results: make([]models.SearchResult, 0, len(results)),
+		Total:   total,
+	}
+
+	for _, result := range results {
+		item := models.SearchResult{
+			Type:      string(result.Type),
+			ID:        result.ID,
+			Title:     result.Title,
+			PosterURL: result.PosterURL,
+		}
+		if result.Released != nil {
+			item.Year = pointer.Int(result.Released.Year())
+		}
+		response.Results = append(response.Results, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,606 @@
+package shows
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
+	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
+	"github.com/AsaHero/movie-app-server/delivery/api/models"
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/delivery/api/validation"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/service/titles"
+	"github.com/gin-gonic/gin"
+	"github.com/shogo82148/pointer"
+)
+
+type handler struct {
+	validator     *validation.Validator
+	titlesService titles.Service
+}
+
+func New(router *gin.RouterGroup, opt *handlers.HandlerOptions) {
+	handler := handler{
+		validator:     opt.Validator,
+		titlesService: opt.TitlesService,
+	}
+
+	router.Use(middlewares.BearerAuth(opt.Config.Token.Secret))
+
+	manage := middlewares.RequireAdmin(opt.UsersService, "Only admins can manage shows")
+
+	router.GET("/", handler.GetAllShows)
+	router.POST("/", manage, handler.CreateShow)
+	router.GET("/:id", handler.GetShow)
+	router.PUT("/:id", manage, handler.UpdateShow)
+	router.DELETE("/:id", manage, handler.DeleteShow)
+	router.GET("/:id/seasons/:season", handler.GetSeason)
+	router.PUT("/:id/seasons/:season", manage, handler.SetSeason)
+	router.DELETE("/:id/seasons/:season", manage, handler.DeleteSeason)
+	router.GET("/:id/seasons/:season/episodes/:episode", handler.GetEpisode)
+	router.PUT("/:id/seasons/:season/episodes/:episode", manage, handler.SetEpisode)
+	router.DELETE("/:id/seasons/:season/episodes/:episode", manage, handler.DeleteEpisode)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get all shows
+// @Description Get TV shows ordered by title. Restricted profiles get an empty list since shows carry no certification.
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param search query string false "Search term"
+// @Param genres query []int false "Filter by genre ids" collectionFormat(csv)
+// @Param page query int false "Page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} models.GetAllShowsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows [get]
+func (h *handler) GetAllShows(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.GetAllShowsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	var genreIDs []int64
+	for _, item := range strings.Split(req.Genres, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			outerr.BadRequest(c, "Invalid genre ID format")
+			return
+		}
+		genreIDs = append(genreIDs, id)
+	}
+
+	total, shows, err := h.titlesService.ListShows(ctx,
+		uint64(pointer.IntValueWithDefault(req.Limit, 20)), uint64(pointer.IntValueWithDefault(req.Page, 1)),
+		strings.TrimSpace(pointer.StringValue(req.Search)), genreIDs,
+	)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	response := models.GetAllShowsResponse{
+		Shows: make([]models.Show, 0, len(shows)),
+		Total: total,
+	}
+
+	for _, show := range shows {
+		response.Shows = append(response.Shows, toShowModel(show))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Security ApiKeyAuth
+// @Summary Get show by id
+// @Description Get a show with its seasons and episodes
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Success 200 {object} models.Show
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id} [get]
+func (h *handler) GetShow(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := int64Param(c, "id")
+	if !ok {
+		return
+	}
+
+	show, err := h.titlesService.GetShow(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toShowModel(show))
+}
+
+// @Security ApiKeyAuth
+// @Summary Create show
+// @Description Create a TV show (admin only)
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param request body models.ShowRequest true "Show"
+// @Success 201 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows [post]
+func (h *handler) CreateShow(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	show, genreIDs, ok := h.bindShow(c)
+	if !ok {
+		return
+	}
+
+	if err := h.titlesService.CreateShow(ctx, show, genreIDs); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Update show
+// @Description Replace a show's fields and genres, seasons are left untouched (admin only)
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Param request body models.ShowRequest true "Show"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id} [put]
+func (h *handler) UpdateShow(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := int64Param(c, "id")
+	if !ok {
+		return
+	}
+
+	show, genreIDs, ok := h.bindShow(c)
+	if !ok {
+		return
+	}
+	show.ID = id
+
+	if err := h.titlesService.UpdateShow(ctx, show, genreIDs); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Delete show
+// @Description Delete a show with its seasons and episodes (admin only)
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id} [delete]
+func (h *handler) DeleteShow(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := int64Param(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.titlesService.DeleteShow(ctx, id); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Get season
+// @Description Get a season of a show with its episodes
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Param season path int true "Season number"
+// @Success 200 {object} models.Season
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id}/seasons/{season} [get]
+func (h *handler) GetSeason(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, number, ok := seasonParams(c)
+	if !ok {
+		return
+	}
+
+	season, err := h.titlesService.GetSeason(ctx, id, number)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSeasonModel(season))
+}
+
+// @Security ApiKeyAuth
+// @Summary Set season
+// @Description Create or replace a season of a show (admin only)
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Param season path int true "Season number"
+// @Param request body models.SeasonRequest true "Season"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id}/seasons/{season} [put]
+func (h *handler) SetSeason(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, number, ok := seasonParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.SeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	airDate, err := parseDate(req.AirDate)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid air date, format should be YYYY-MM-DD")
+		return
+	}
+
+	err = h.titlesService.SetSeason(ctx, &entity.Seasons{
+		ShowID:       id,
+		SeasonNumber: number,
+		Title:        req.Title,
+		Plot:         req.Plot,
+		AirDate:      airDate,
+	})
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Delete season
+// @Description Delete a season with its episodes (admin only)
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Param season path int true "Season number"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id}/seasons/{season} [delete]
+func (h *handler) DeleteSeason(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, number, ok := seasonParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.titlesService.DeleteSeason(ctx, id, number); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Get episode
+// @Description Get an episode of a show
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Param season path int true "Season number"
+// @Param episode path int true "Episode number"
+// @Success 200 {object} models.Episode
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id}/seasons/{season}/episodes/{episode} [get]
+func (h *handler) GetEpisode(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, season, number, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	episode, err := h.titlesService.GetEpisode(ctx, id, season, number)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toEpisodeModel(episode))
+}
+
+// @Security ApiKeyAuth
+// @Summary Set episode
+// @Description Create or replace an episode of an existing season (admin only)
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Param season path int true "Season number"
+// @Param episode path int true "Episode number"
+// @Param request body models.EpisodeRequest true "Episode"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id}/seasons/{season}/episodes/{episode} [put]
+func (h *handler) SetEpisode(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, season, number, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.EpisodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	airDate, err := parseDate(req.AirDate)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid air date, format should be YYYY-MM-DD")
+		return
+	}
+
+	err = h.titlesService.SetEpisode(ctx, id, season, &entity.Episodes{
+		EpisodeNumber:  number,
+		Title:          req.Title,
+		Plot:           req.Plot,
+		AirDate:        airDate,
+		RuntimeMinutes: req.RuntimeMinutes,
+	})
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// @Security ApiKeyAuth
+// @Summary Delete episode
+// @Description Delete an episode (admin only)
+// @Tags Shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show id"
+// @Param season path int true "Season number"
+// @Param episode path int true "Episode number"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /shows/{id}/seasons/{season}/episodes/{episode} [delete]
+func (h *handler) DeleteEpisode(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, season, number, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.titlesService.DeleteEpisode(ctx, id, season, number); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+func (h *handler) bindShow(c *gin.Context) (*entity.Shows, []int64, bool) {
+	var req models.ShowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return nil, nil, false
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return nil, nil, false
+	}
+
+	firstAirDate, err := parseDate(req.FirstAirDate)
+	if err != nil {
+		outerr.BadRequest(c, "Invalid first air date, format should be YYYY-MM-DD")
+		return nil, nil, false
+	}
+
+	genreIDs := make([]int64, 0, len(req.Genres))
+	for _, genreID := range req.Genres {
+		genreIDs = append(genreIDs, int64(genreID))
+	}
+
+	return &entity.Shows{
+		Title:        req.Title,
+		Plot:         req.Plot,
+		PosterURL:    req.PosterURL,
+		FirstAirDate: firstAirDate,
+	}, genreIDs, true
+}
+
+func toShowModel(show *entity.Shows) models.Show {
+	result := models.Show{
+		ID:           show.ID,
+		Title:        show.Title,
+		Plot:         show.Plot,
+		PosterURL:    show.PosterURL,
+		FirstAirDate: formatDate(show.FirstAirDate),
+		Genres:       make([]string, 0, len(show.ShowGenres)),
+		CreatedAt:    show.CreatedAt,
+		UpdatedAt:    show.UpdatedAt,
+	}
+
+	for _, genre := range show.ShowGenres {
+		if genre.Genre != nil {
+			result.Genres = append(result.Genres, genre.Genre.Name)
+		}
+	}
+
+	for i := range show.Seasons {
+		result.Seasons = append(result.Seasons, toSeasonModel(&show.Seasons[i]))
+	}
+
+	return result
+}
+
+func toSeasonModel(season *entity.Seasons) models.Season {
+	result := models.Season{
+		SeasonNumber: season.SeasonNumber,
+		Title:        season.Title,
+		Plot:         season.Plot,
+		AirDate:      formatDate(season.AirDate),
+		Episodes:     make([]models.Episode, 0, len(season.Episodes)),
+	}
+
+	for i := range season.Episodes {
+		result.Episodes = append(result.Episodes, toEpisodeModel(&season.Episodes[i]))
+	}
+
+	return result
+}
+
+func toEpisodeModel(episode *entity.Episodes) models.Episode {
+	return models.Episode{
+		ID:             episode.ID,
+		EpisodeNumber:  episode.EpisodeNumber,
+		Title:          episode.Title,
+		Plot:           episode.Plot,
+		AirDate:        formatDate(episode.AirDate),
+		RuntimeMinutes: episode.RuntimeMinutes,
+	}
+}
+
+func parseDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, *value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	return pointer.String(date.Format(time.DateOnly))
+}
+
+func int64Param(c *gin.Context, name string) (int64, bool) {
+	value, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || value < 1 {
+		outerr.BadRequest(c, "Invalid "+name)
+		return 0, false
+	}
+	return value, true
+}
+
+func seasonParams(c *gin.Context) (int64, int, bool) {
+	id, ok := int64Param(c, "id")
+	if !ok {
+		return 0, 0, false
+	}
+
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil || season < 0 {
+		outerr.BadRequest(c, "Invalid season number")
+		return 0, 0, false
+	}
+
+	return id, season, true
+}
+
+func episodeParams(c *gin.Context) (int64, int, int, bool) {
+	id, season, ok := seasonParams(c)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	episode, err := strconv.Atoi(c.Param("episode"))
+	if err != nil || episode < 1 {
+		outerr.BadRequest(c, "Invalid episode number")
+		return 0, 0, 0, false
+	}
+
+	return id, season, episode, true
+}
@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"github.com/AsaHero/movie-app-server/delivery/api/outerr"
+	"github.com/AsaHero/movie-app-server/internal/service/users"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin lets only admins through, it runs after BearerAuth. Others get
+// a 403 with message.
+func RequireAdmin(usersService users.Service, message string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !IsAdmin(c, usersService, message) {
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// IsAdmin checks that the authenticated user is an admin and writes the error
+// response when not, for handlers where only some requests need an admin
+func IsAdmin(c *gin.Context, usersService users.Service, message string) bool {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		outerr.Unauthorized(c, "user_id is required")
+		return false
+	}
+
+	user, err := usersService.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return false
+	}
+
+	if !user.IsAdmin() {
+		outerr.Forbidden(c, message)
+		return false
+	}
+
+	return true
+}
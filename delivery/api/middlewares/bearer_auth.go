@@ -43,6 +43,13 @@ func BearerAuth(secret string) gin.HandlerFunc {
 		}
 
 		c.Set("user_id", claims.UserID)
+		c.Set("profile_id", claims.ProfileID)
+
+		ctx := security.WithUserID(c.Request.Context(), claims.UserID)
+		if claims.ProfileID != "" {
+			ctx = security.WithProfileID(ctx, claims.ProfileID)
+		}
+		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
 	}
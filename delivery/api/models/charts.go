@@ -0,0 +1,25 @@
+package models
+
+type GetChartRequest struct {
+	Window *string `form:"window" validate:"omitnil,oneof=day week month year"`
+	Page   *int    `form:"page" validate:"omitnil,min=1"`
+	Limit  *int    `form:"limit" validate:"omitnil,min=1,max=100"`
+}
+
+// ChartMovie is a movie's place in a chart, Events is how many distinct views
+// and opens it had over the window
+type ChartMovie struct {
+	Rank      int     `json:"rank"`
+	ID        int64   `json:"id"`
+	Title     string  `json:"title"`
+	Release   string  `json:"release"`
+	PosterURL string  `json:"poster_url"`
+	Score     float64 `json:"score"`
+	Events    int64   `json:"events"`
+}
+
+type GetChartResponse struct {
+	Window string       `json:"window"`
+	Total  int64        `json:"total"`
+	Movies []ChartMovie `json:"movies"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+type Collection struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description"`
+	PosterURL   string            `json:"poster_url"`
+	BackdropURL string            `json:"backdrop_url"`
+	Movies      []CollectionEntry `json:"movies,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// CollectionEntry is a member movie of a collection
+type CollectionEntry struct {
+	Position  int    `json:"position"`
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Release   string `json:"release"`
+	PosterURL string `json:"poster_url"`
+}
+
+// MovieCollection is the collection a movie belongs to, with its neighbours there
+type MovieCollection struct {
+	ID       int64            `json:"id"`
+	Name     string           `json:"name"`
+	Position int              `json:"position"`
+	Previous *CollectionEntry `json:"previous"`
+	Next     *CollectionEntry `json:"next"`
+}
+
+type CollectionRequest struct {
+	Name        string  `json:"name" validate:"required,min=1,max=255"`
+	Description *string `json:"description"`
+	PosterURL   string  `json:"poster_url" validate:"omitempty,poster_url"`
+	BackdropURL string  `json:"backdrop_url" validate:"omitempty,poster_url"`
+}
+
+// SetCollectionMoviesRequest lists the member movies in collection order
+type SetCollectionMoviesRequest struct {
+	MovieIDs []int64 `json:"movie_ids" validate:"max=100,unique,dive,min=1"`
+}
+
+type GetAllCollectionsRequest struct {
+	Page  *int `form:"page" validate:"omitnil,min=1"`
+	Limit *int `form:"limit" validate:"omitnil,min=1,max=100"`
+}
+
+type GetAllCollectionsResponse struct {
+	Collections []Collection `json:"collections"`
+	Total       int64        `json:"total"`
+}
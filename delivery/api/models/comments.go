@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// Comment is a comment of a movie's discussion. Removed comments keep their
+// place with an empty body and no author, Status is visible, deleted or hidden.
+type Comment struct {
+	ID           int64          `json:"id"`
+	MovieID      int64          `json:"movie_id"`
+	ParentID     *int64         `json:"parent_id"`
+	Author       *CommentAuthor `json:"author"`
+	Body         string         `json:"body"`
+	Status       string         `json:"status"`
+	LikesCount   int            `json:"likes_count"`
+	Liked        bool           `json:"liked"`
+	RepliesCount int            `json:"replies_count"`
+	Locked       bool           `json:"locked"`
+	EditedAt     *time.Time     `json:"edited_at"`
+	CreatedAt    time.Time      `json:"created_at"`
+	Replies      []Comment      `json:"replies,omitempty"`
+}
+
+type CommentAuthor struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type GetCommentsRequest struct {
+	Cursor string `form:"cursor"`
+	Limit  *int   `form:"limit" validate:"omitnil,min=1,max=100"`
+}
+
+// GetCommentsResponse is a page of comments, next_cursor is null on the last page
+type GetCommentsResponse struct {
+	Comments   []Comment `json:"comments"`
+	NextCursor *string   `json:"next_cursor"`
+}
+
+type CreateCommentRequest struct {
+	Body     string `json:"body" validate:"required,max=5000"`
+	ParentID *int64 `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+type ReportCommentRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type CommentReport struct {
+	Comment    Comment   `json:"comment"`
+	ReporterID string    `json:"reporter_id"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GetCommentReportsRequest struct {
+	Page  *int `form:"page" validate:"omitnil,min=1"`
+	Limit *int `form:"limit" validate:"omitnil,min=1,max=100"`
+}
+
+type GetCommentReportsResponse struct {
+	Reports []CommentReport `json:"reports"`
+	Total   int64           `json:"total"`
+}
+
+// BanCommenterRequest bans until expires_at, for good when it is omitted
+type BanCommenterRequest struct {
+	Reason    *string    `json:"reason" validate:"omitnil,max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
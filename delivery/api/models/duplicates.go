@@ -0,0 +1,31 @@
+package models
+
+type GetMovieDuplicatesRequest struct {
+	Page  *int `form:"page,default=1" validate:"min=1"`
+	Limit *int `form:"limit,default=10" validate:"min=1,max=100"`
+}
+
+// MovieDuplicate is a pair of movies that are likely the same one, Score in
+// [0, 1] weighs how close their release years and durations are
+type MovieDuplicate struct {
+	Movie        Movie   `json:"movie"`
+	Duplicate    Movie   `json:"duplicate"`
+	Score        float64 `json:"score"`
+	YearDiff     int     `json:"year_diff"`
+	DurationDiff int     `json:"duration_diff"`
+}
+
+type GetMovieDuplicatesResponse struct {
+	Total      int64            `json:"total"`
+	Duplicates []MovieDuplicate `json:"duplicates"`
+}
+
+type DismissMovieDuplicateRequest struct {
+	MovieID     int64 `json:"movie_id" validate:"required,min=1"`
+	DuplicateID int64 `json:"duplicate_id" validate:"required,min=1"`
+}
+
+// MergeMovieRequest names the movie folded into the one in the path
+type MergeMovieRequest struct {
+	DuplicateID int64 `json:"duplicate_id" validate:"required,min=1"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+type UserList struct {
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	Slug           string    `json:"slug"`
+	Description    *string   `json:"description"`
+	Visibility     string    `json:"visibility"`
+	Owner          ListOwner `json:"owner"`
+	FollowersCount int       `json:"followers_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ListOwner struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// ListItem is a movie of a list with the curator's note
+type ListItem struct {
+	Position  int       `json:"position"`
+	Note      *string   `json:"note"`
+	AddedAt   time.Time `json:"added_at"`
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	Release   string    `json:"release"`
+	PosterURL string    `json:"poster_url"`
+}
+
+type UserListRequest struct {
+	Name        string  `json:"name" validate:"required,min=1,max=255"`
+	Description *string `json:"description" validate:"omitnil,max=2000"`
+	Visibility  string  `json:"visibility" validate:"required,oneof=public unlisted private"`
+}
+
+type SetListItemRequest struct {
+	Note *string `json:"note" validate:"omitnil,max=2000"`
+}
+
+// ReorderListRequest lists every movie of the list in the new order
+type ReorderListRequest struct {
+	MovieIDs []int64 `json:"movie_ids" validate:"required,unique,dive,min=1"`
+}
+
+type GetUserListsRequest struct {
+	Page    *int    `form:"page" validate:"omitnil,min=1"`
+	Limit   *int    `form:"limit" validate:"omitnil,min=1,max=100"`
+	OrderBy *string `form:"order_by" validate:"omitnil,oneof=popular recent"`
+	Search  *string `form:"search"`
+}
+
+type GetUserListsResponse struct {
+	Lists []UserList `json:"lists"`
+	Total int64      `json:"total"`
+}
+
+// GetUserListRequest pages through the items of a list
+type GetUserListRequest struct {
+	Page  *int `form:"page" validate:"omitnil,min=1"`
+	Limit *int `form:"limit" validate:"omitnil,min=1,max=100"`
+}
+
+type GetUserListResponse struct {
+	List  UserList   `json:"list"`
+	Items []ListItem `json:"items"`
+	Total int64      `json:"total"`
+}
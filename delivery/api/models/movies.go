@@ -1,18 +1,34 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Movie struct {
-	ID              int64     `json:"id"`
-	Title           string    `json:"title"`
-	Release         string    `json:"release"`
-	Plot            *string   `json:"plot"`
-	DurationMinutes int16     `json:"duration_minutes"`
-	PosterURL       string    `json:"poster_url"`
-	TrailerURL      string    `json:"trailer_url"`
-	Genres          []string  `json:"genres"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              int64             `json:"id"`
+	Title           string            `json:"title"`
+	Tagline         *string           `json:"tagline,omitempty"`
+	Locale          string            `json:"locale,omitempty"`
+	Release         string            `json:"release"`
+	Plot            *string           `json:"plot"`
+	DurationMinutes int16             `json:"duration_minutes"`
+	PosterURL       string            `json:"poster_url"`
+	TrailerURL      string            `json:"trailer_url"`
+	Trailer         *Trailer          `json:"trailer"`
+	LocalRelease    *MovieRelease     `json:"local_release,omitempty"`
+	Collection      *MovieCollection  `json:"collection,omitempty"`
+	Genres          []string          `json:"genres"`
+	ExternalIDs     map[string]string `json:"external_ids"`
+	Images          MovieImages       `json:"images"`
+	Version         int64             `json:"version"`
+	Status          string            `json:"status"`
+	PublishAt       *time.Time        `json:"publish_at,omitempty"`
+	PublishedAt     *time.Time        `json:"published_at,omitempty"`
+	PublishedBy     *string           `json:"published_by,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	DeletedAt       *time.Time        `json:"deleted_at,omitempty"`
 }
 
 type CreateMovieRequest struct {
@@ -20,8 +36,8 @@ type CreateMovieRequest struct {
 	Release         string  `json:"release" validate:"required"`
 	Plot            *string `json:"plot"`
 	DurationMinutes int16   `json:"duration_minutes" validate:"required,min=1,max=500"`
-	PosterURL       string  `json:"poster_url" validate:"required"`
-	TrailerURL      string  `json:"trailer_url" validate:"required"`
+	PosterURL       string  `json:"poster_url" validate:"required,poster_url"`
+	TrailerURL      string  `json:"trailer_url" validate:"required,trailer_url"`
 	Genres          []int   `json:"genres" validate:"required"`
 }
 
@@ -30,23 +46,203 @@ type UpdateMovieRequest struct {
 	Release         string  `json:"release" validate:"required"`
 	Plot            *string `json:"plot"`
 	DurationMinutes int16   `json:"duration_minutes" validate:"required,min=1,max=500"`
-	PosterURL       string  `json:"poster_url" validate:"required"`
-	TrailerURL      string  `json:"trailer_url" validate:"required"`
+	PosterURL       string  `json:"poster_url" validate:"required,poster_url"`
+	TrailerURL      string  `json:"trailer_url" validate:"required,trailer_url"`
 	Genres          []int   `json:"genres" validate:"required"`
 }
 
+// Trailer is set when trailer_url is a recognized video link
+type Trailer struct {
+	Provider string `json:"provider"`
+	VideoID  string `json:"video_id"`
+	EmbedURL string `json:"embed_url"`
+}
+
+// MovieImages maps variant names (original, w342, ...) to URLs
+type MovieImages struct {
+	Poster   map[string]string `json:"poster,omitempty"`
+	Backdrop map[string]string `json:"backdrop,omitempty"`
+}
+
+type MovieImageVariant struct {
+	Variant string `json:"variant"`
+	URL     string `json:"url"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Size    int64  `json:"size"`
+}
+
+type UploadMovieImageResponse struct {
+	Kind     string              `json:"kind"`
+	Variants []MovieImageVariant `json:"variants"`
+}
+
+type SetExternalIDRequest struct {
+	Value string `json:"value" validate:"required,max=255"`
+}
+
+// PatchMovieRequest is a JSON Merge Patch (RFC 7396) document, absent fields are left untouched
+type PatchMovieRequest struct {
+	Title           *string `json:"title" validate:"omitnil,min=2,max=255"`
+	Release         *string `json:"release"`
+	Plot            *string `json:"plot"`
+	DurationMinutes *int16  `json:"duration_minutes" validate:"omitnil,min=1,max=500"`
+	PosterURL       *string `json:"poster_url" validate:"omitnil,poster_url"`
+	TrailerURL      *string `json:"trailer_url" validate:"omitnil,trailer_url"`
+	Genres          *[]int  `json:"genres"`
+}
+
+// MovieFilterQuery holds the browse filters shared by listing and export
+type MovieFilterQuery struct {
+	Search    *string `form:"search"`
+	Genres    string  `form:"genres"`
+	Decades   string  `form:"decades"`
+	Years     string  `form:"years"`
+	Durations string  `form:"durations"`
+	// Country keeps movies released there and, like the caller's locale, picks the local release
+	Country          *string `form:"country"`
+	MaxCertification *string `form:"max_certification"`
+}
+
 type GetAllMoviesRequest struct {
 	Page     *int    `form:"page" validate:"min=1"`
 	Limit    *int    `form:"limit" validate:"min=1,max=100"`
-	OrderBy  *string `form:"order_by"`
-	OrderDir *string `form:"order_dir"`
-	Search   *string `form:"search"`
-	Genres   string  `form:"genres"`
+	OrderBy  *string `form:"order_by" validate:"omitnil,oneof=title release created_at popularity"`
+	OrderDir *string `form:"order_dir" validate:"omitnil,oneof=asc desc"`
+	MovieFilterQuery
+	Facets string `form:"facets"`
+	// Status lists workflow stages, comma separated, admins only
+	Status string `form:"status"`
+}
+
+type ExportMoviesRequest struct {
+	Format string `form:"format" validate:"required,oneof=csv jsonl xlsx"`
+	MovieFilterQuery
+}
+
+// ExportMovie is a JSON Lines export row, CSV and XLSX use the same columns
+type ExportMovie struct {
+	ID              int64     `json:"id"`
+	ExternalID      *string   `json:"external_id"`
+	Title           string    `json:"title"`
+	Release         string    `json:"release"`
+	Plot            *string   `json:"plot"`
+	DurationMinutes int16     `json:"duration_minutes"`
+	PosterURL       string    `json:"poster_url"`
+	TrailerURL      string    `json:"trailer_url"`
+	Genres          []string  `json:"genres"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 type GetAllMoviesResponse struct {
-	Movies []Movie `json:"movies"`
-	Total  int64   `json:"total"`
+	Movies []Movie      `json:"movies"`
+	Total  int64        `json:"total"`
+	Facets *MovieFacets `json:"facets,omitempty"`
+}
+
+type FacetBucket struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+type MovieFacets struct {
+	Genres    []FacetBucket `json:"genres,omitempty"`
+	Decades   []FacetBucket `json:"decades,omitempty"`
+	Years     []FacetBucket `json:"years,omitempty"`
+	Durations []FacetBucket `json:"durations,omitempty"`
+}
+
+type GetMovieTrashRequest struct {
+	Page  *int `form:"page,default=1" validate:"min=1"`
+	Limit *int `form:"limit,default=10" validate:"min=1,max=100"`
+}
+
+// SetMovieStatusRequest moves a movie through the editorial workflow,
+// PublishAt is required when scheduling
+type SetMovieStatusRequest struct {
+	Status    string     `json:"status" validate:"required,oneof=draft in_review scheduled published"`
+	PublishAt *time.Time `json:"publish_at" validate:"required_if=Status scheduled"`
+}
+
+type DeleteMovieRequest struct {
+	Hard bool `form:"hard"`
+}
+
+type GetMovieHistoryRequest struct {
+	Page  *int `form:"page,default=1" validate:"min=1"`
+	Limit *int `form:"limit,default=10" validate:"min=1,max=100"`
+}
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type MovieRevision struct {
+	ID        int64           `json:"id"`
+	Action    string          `json:"action"`
+	ActorID   *string         `json:"actor_id"`
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	Changes   []FieldChange   `json:"changes"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type GetMovieHistoryResponse struct {
+	Revisions []MovieRevision `json:"revisions"`
+	Total     int64           `json:"total"`
+}
+
+// MovieRelease is the release of a movie in one country
+type MovieRelease struct {
+	Country       string  `json:"country"`
+	Type          string  `json:"type"`
+	Date          string  `json:"date"`
+	Certification *string `json:"certification"`
+}
+
+type GetMovieReleasesResponse struct {
+	Releases []MovieRelease `json:"releases"`
+}
+
+type SetMovieReleaseRequest struct {
+	Date          string  `json:"date" validate:"required"`
+	Certification *string `json:"certification" validate:"omitnil,min=1,max=20"`
+}
+
+type MovieTranslation struct {
+	Locale    string    `json:"locale"`
+	Title     string    `json:"title"`
+	Plot      *string   `json:"plot"`
+	Tagline   *string   `json:"tagline"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GetMovieTranslationsResponse struct {
+	Translations []MovieTranslation `json:"translations"`
+}
+
+type SetMovieTranslationRequest struct {
+	Title   string  `json:"title" validate:"required,min=1,max=255"`
+	Plot    *string `json:"plot"`
+	Tagline *string `json:"tagline" validate:"omitnil,max=255"`
+}
+
+type GenreTranslation struct {
+	Locale    string    `json:"locale"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GetGenreTranslationsResponse struct {
+	Translations []GenreTranslation `json:"translations"`
+}
+
+type SetGenreTranslationRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
 }
 
 type Gener struct {
@@ -57,3 +253,61 @@ type Gener struct {
 type GetAllGenresResponse struct {
 	Genres []Gener `json:"genres"`
 }
+
+type ImportMoviesRequest struct {
+	Format       string `form:"format" validate:"omitempty,oneof=csv jsonl"`
+	DryRun       bool   `form:"dry_run"`
+	CreateGenres bool   `form:"create_genres"`
+}
+
+// ImportMovieRow is a single line of a JSON Lines import, CSV columns use the same names
+// with genres separated by "|"
+type ImportMovieRow struct {
+	ExternalID      *string  `json:"external_id"`
+	Title           string   `json:"title"`
+	Release         string   `json:"release"`
+	Plot            *string  `json:"plot"`
+	DurationMinutes int16    `json:"duration_minutes"`
+	PosterURL       string   `json:"poster_url"`
+	TrailerURL      string   `json:"trailer_url"`
+	Genres          []string `json:"genres"`
+}
+
+type ImportRowError struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Message    string `json:"message"`
+}
+
+type ImportJob struct {
+	ID           string           `json:"id"`
+	Status       string           `json:"status"`
+	DryRun       bool             `json:"dry_run"`
+	CreateGenres bool             `json:"create_genres"`
+	Total        int              `json:"total"`
+	Processed    int              `json:"processed"`
+	Created      int              `json:"created"`
+	Updated      int              `json:"updated"`
+	Skipped      int              `json:"skipped"`
+	Failed       int              `json:"failed"`
+	Errors       []ImportRowError `json:"errors"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	FinishedAt   *time.Time       `json:"finished_at"`
+}
+
+type SimilarMovie struct {
+	ID        int64   `json:"id"`
+	Title     string  `json:"title"`
+	Release   string  `json:"release"`
+	PosterURL string  `json:"poster_url"`
+	Score     float64 `json:"score"`
+}
+
+type GetSimilarMoviesRequest struct {
+	Limit *int `form:"limit" validate:"omitnil,min=1,max=50"`
+}
+
+type GetSimilarMoviesResponse struct {
+	Movies []SimilarMovie `json:"movies"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type Profile struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	MaxCertification *string   `json:"max_certification"`
+	Country          *string   `json:"country"`
+	Active           bool      `json:"active"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type GetProfilesResponse struct {
+	Profiles []Profile `json:"profiles"`
+	HasPIN   bool      `json:"has_pin"`
+}
+
+type ProfileRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+	// MaxCertification is read in Country, e.g. PG-13 in US or 12 in DE
+	MaxCertification *string `json:"max_certification" validate:"omitnil,min=1,max=20"`
+	Country          *string `json:"country" validate:"required_with=MaxCertification,omitnil,len=2"`
+}
+
+type SetPINRequest struct {
+	Password string `json:"password" validate:"required"`
+	// PIN of 4 to 8 digits, empty to remove it
+	PIN string `json:"pin" validate:"omitempty,numeric,min=4,max=8"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// SaveProgressRequest is a playback heartbeat, completed marks the video as
+// watched whatever the position
+type SaveProgressRequest struct {
+	PositionSeconds *int `json:"position_seconds" validate:"required,min=0,max=86400"`
+	Completed       bool `json:"completed"`
+}
+
+type WatchProgress struct {
+	Type            string     `json:"type"`
+	ID              int64      `json:"id"`
+	PositionSeconds int        `json:"position_seconds"`
+	Completed       bool       `json:"completed"`
+	WatchedAt       *time.Time `json:"watched_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ContinueWatchingEntry is an unfinished movie or episode, episodes name their show
+type ContinueWatchingEntry struct {
+	Type            string                `json:"type"`
+	ID              int64                 `json:"id"`
+	Title           string                `json:"title"`
+	PosterURL       string                `json:"poster_url"`
+	PositionSeconds int                   `json:"position_seconds"`
+	DurationSeconds *int                  `json:"duration_seconds"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	Show            *ContinueWatchingShow `json:"show,omitempty"`
+}
+
+type ContinueWatchingShow struct {
+	ID            int64  `json:"id"`
+	Title         string `json:"title"`
+	SeasonNumber  int    `json:"season_number"`
+	EpisodeNumber int    `json:"episode_number"`
+}
+
+type GetContinueWatchingRequest struct {
+	Page  *int `form:"page" validate:"omitnil,min=1"`
+	Limit *int `form:"limit" validate:"omitnil,min=1,max=100"`
+}
+
+type GetContinueWatchingResponse struct {
+	Entries []ContinueWatchingEntry `json:"entries"`
+	Total   int64                   `json:"total"`
+}
+
+type RateMovieRequest struct {
+	Score int `json:"score" validate:"required,min=1,max=10"`
+}
+
+type MovieRating struct {
+	MovieID   int64     `json:"movie_id"`
+	Score     int       `json:"score"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
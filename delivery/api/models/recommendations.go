@@ -0,0 +1,31 @@
+package models
+
+type Recommendation struct {
+	ID        int64                `json:"id"`
+	Title     string               `json:"title"`
+	Release   string               `json:"release"`
+	PosterURL string               `json:"poster_url"`
+	Score     float64              `json:"score"`
+	Reason    RecommendationReason `json:"reason"`
+}
+
+// RecommendationReason explains a recommendation: similar to a movie the user
+// liked, or popular with everyone while there is little to go on
+type RecommendationReason struct {
+	Type    string                `json:"type"`
+	Message string                `json:"message"`
+	Movie   *RecommendationSource `json:"movie,omitempty"`
+}
+
+type RecommendationSource struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type GetRecommendationsRequest struct {
+	Limit *int `form:"limit" validate:"omitnil,min=1,max=100"`
+}
+
+type GetRecommendationsResponse struct {
+	Recommendations []Recommendation `json:"recommendations"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+type Show struct {
+	ID           int64     `json:"id"`
+	Title        string    `json:"title"`
+	Plot         *string   `json:"plot"`
+	PosterURL    string    `json:"poster_url"`
+	FirstAirDate *string   `json:"first_air_date"`
+	Genres       []string  `json:"genres"`
+	Seasons      []Season  `json:"seasons,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type Season struct {
+	SeasonNumber int       `json:"season_number"`
+	Title        *string   `json:"title"`
+	Plot         *string   `json:"plot"`
+	AirDate      *string   `json:"air_date"`
+	Episodes     []Episode `json:"episodes"`
+}
+
+type Episode struct {
+	// ID identifies the episode for playback, e.g. in /me/progress/episodes/{id}
+	ID             int64   `json:"id"`
+	EpisodeNumber  int     `json:"episode_number"`
+	Title          string  `json:"title"`
+	Plot           *string `json:"plot"`
+	AirDate        *string `json:"air_date"`
+	RuntimeMinutes *int16  `json:"runtime_minutes"`
+}
+
+type ShowRequest struct {
+	Title        string  `json:"title" validate:"required,min=1,max=255"`
+	Plot         *string `json:"plot"`
+	PosterURL    string  `json:"poster_url" validate:"omitempty,poster_url"`
+	FirstAirDate *string `json:"first_air_date"`
+	Genres       []int   `json:"genres"`
+}
+
+type SeasonRequest struct {
+	Title   *string `json:"title" validate:"omitnil,max=255"`
+	Plot    *string `json:"plot"`
+	AirDate *string `json:"air_date"`
+}
+
+type EpisodeRequest struct {
+	Title          string  `json:"title" validate:"required,min=1,max=255"`
+	Plot           *string `json:"plot"`
+	AirDate        *string `json:"air_date"`
+	RuntimeMinutes *int16  `json:"runtime_minutes" validate:"omitnil,min=1,max=1000"`
+}
+
+type GetAllShowsRequest struct {
+	Page   *int    `form:"page" validate:"omitnil,min=1"`
+	Limit  *int    `form:"limit" validate:"omitnil,min=1,max=100"`
+	Search *string `form:"search"`
+	Genres string  `form:"genres"`
+}
+
+type GetAllShowsResponse struct {
+	Shows []Show `json:"shows"`
+	Total int64  `json:"total"`
+}
+
+type SearchRequest struct {
+	Query string `form:"q" validate:"required,min=1,max=255"`
+	// Type limits results to movie or show
+	Type  *string `form:"type" validate:"omitnil,oneof=movie show"`
+	Page  *int    `form:"page" validate:"omitnil,min=1"`
+	Limit *int    `form:"limit" validate:"omitnil,min=1,max=100"`
+}
+
+type SearchResult struct {
+	Type      string `json:"type"`
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Year      *int   `json:"year"`
+	PosterURL string `json:"poster_url"`
+}
+
+type SearchResponse struct {
+	Results []SearchResult `json:"results"`
+	Total   int64          `json:"total"`
+}
@@ -3,14 +3,20 @@ package outerr
 // API Error Codes
 const (
 	// Common errors
-	CodeBadRequest      = "BAD_REQUEST"
-	CodeUnauthorized    = "UNAUTHORIZED"
-	CodeForbidden       = "FORBIDDEN"
-	CodeNotFound        = "NOT_FOUND"
-	CodeConflict        = "CONFLICT"
-	CodeInternalError   = "INTERNAL_ERROR"
-	CodeNoChanges       = "NOT_MODIFIED"
-	CodeTooManyRequests = "TOO_MANY_REQUESTS"
+	CodeBadRequest       = "BAD_REQUEST"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeConflict         = "CONFLICT"
+	CodeInternalError    = "INTERNAL_ERROR"
+	CodeNoChanges        = "NOT_MODIFIED"
+	CodeTooManyRequests  = "TOO_MANY_REQUESTS"
+	CodeUnsupportedMedia = "UNSUPPORTED_MEDIA_TYPE"
+	CodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+
+	// Concurrency errors
+	CodePreconditionFailed   = "PRECONDITION_FAILED"
+	CodePreconditionRequired = "PRECONDITION_REQUIRED"
 
 	// Validation errors
 	CodeValidation        = "VALIDATION_ERROR"
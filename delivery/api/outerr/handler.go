@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/AsaHero/movie-app-server/internal/inerr"
 	"github.com/gin-gonic/gin"
@@ -20,6 +21,34 @@ func HandleError(c *gin.Context, err error) {
 			Code:    CodeUnauthorized,
 			Message: err.Error(),
 		})
+	case errors.Is(err, inerr.ErrorPINRequired),
+		errors.Is(err, inerr.ErrorIncorrectPIN),
+		errors.Is(err, inerr.ErrorProfileRequired),
+		errors.Is(err, inerr.ErrorCommentBanned),
+		errors.Is(err, inerr.ErrorThreadLocked),
+		errors.Is(err, inerr.ErrorNotCommentAuthor),
+		errors.Is(err, inerr.ErrorStatusNeedsAdmin):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Code:    CodeForbidden,
+			Message: err.Error(),
+		})
+	case errors.Is(err, inerr.ErrorFollowOwnList),
+		errors.Is(err, inerr.ErrorListOrderMismatch),
+		errors.Is(err, inerr.ErrorInvalidCursor),
+		errors.Is(err, inerr.ErrorReplyToOtherMovie),
+		errors.Is(err, inerr.ErrorCommentRemoved),
+		errors.Is(err, inerr.ErrorStatusTransition),
+		errors.Is(err, inerr.ErrorPublishAtInPast),
+		errors.Is(err, inerr.ErrorMergeSameMovie):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeBadRequest,
+			Message: err.Error(),
+		})
+	case errors.Is(err, inerr.ErrorTooManyPINAttempts):
+		c.JSON(http.StatusTooManyRequests, ErrorResponse{
+			Code:    CodeTooManyRequests,
+			Message: err.Error(),
+		})
 	case errors.As(err, &validationErrors):
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Code:    CodeValidation,
@@ -33,12 +62,24 @@ func HandleError(c *gin.Context, err error) {
 			Message: err.Error(),
 		})
 
+	case inerr.IsErrInvalidReference(err):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    CodeBadRequest,
+			Message: err.Error(),
+		})
+
 	case inerr.IsErrConflict(err):
 		c.JSON(http.StatusConflict, ErrorResponse{
 			Code:    CodeConflict,
 			Message: err.Error(),
 		})
 
+	case inerr.IsErrPreconditionFailed(err):
+		c.JSON(http.StatusPreconditionFailed, ErrorResponse{
+			Code:    CodePreconditionFailed,
+			Message: err.Error(),
+		})
+
 	case inerr.IsErrNoChanges(err):
 		c.JSON(http.StatusNotModified, ErrorResponse{
 			Code:    CodeNoChanges,
@@ -89,6 +130,34 @@ func TooManyRequests(c *gin.Context, message string) {
 	})
 }
 
+func UnsupportedMediaType(c *gin.Context, message string) {
+	c.JSON(http.StatusUnsupportedMediaType, ErrorResponse{
+		Code:    CodeUnsupportedMedia,
+		Message: message,
+	})
+}
+
+func PayloadTooLarge(c *gin.Context, message string) {
+	c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+		Code:    CodePayloadTooLarge,
+		Message: message,
+	})
+}
+
+func PreconditionFailed(c *gin.Context, message string) {
+	c.JSON(http.StatusPreconditionFailed, ErrorResponse{
+		Code:    CodePreconditionFailed,
+		Message: message,
+	})
+}
+
+func PreconditionRequired(c *gin.Context, message string) {
+	c.JSON(http.StatusPreconditionRequired, ErrorResponse{
+		Code:    CodePreconditionRequired,
+		Message: message,
+	})
+}
+
 func NotFound(c *gin.Context, message string) {
 	c.JSON(http.StatusNotFound, ErrorResponse{
 		Code:    CodeNotFound,
@@ -140,6 +209,18 @@ func getValidationErrorMessage(err validator.FieldError) ValidationErrorMessage
 		msg.Message = "Invalid URL format"
 		msg.Suggestion = "Please provide a valid URL (e.g., https://example.com)"
 
+	case "http_url":
+		msg.Message = fmt.Sprintf("The %s field must be an absolute http(s) URL", err.Field())
+		msg.Suggestion = "Please provide a URL such as https://example.com/image.jpg"
+
+	case "poster_url":
+		msg.Message = fmt.Sprintf("The %s field must be an http(s) URL on an allowed image host", err.Field())
+		msg.Suggestion = "Please use an allowed image host or upload the image instead"
+
+	case "trailer_url":
+		msg.Message = fmt.Sprintf("The %s field must be a YouTube or Vimeo video link", err.Field())
+		msg.Suggestion = "Please provide a link such as https://www.youtube.com/watch?v=VIDEO_ID"
+
 	case "datetime":
 		msg.Message = "Invalid datetime format"
 		msg.Suggestion = "Please provide a valid datetime (e.g., 2006-01-02T15:04:05Z)"
@@ -172,3 +253,18 @@ func formatValidationErrors(errs validator.ValidationErrors) []ValidationErrorMe
 	}
 	return validationErrors
 }
+
+// ValidationMessage flattens an error into a single line, used where a structured
+// response is not possible, e.g. per-row import reports
+func ValidationMessage(err error) string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err.Error()
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		messages = append(messages, getValidationErrorMessage(fieldErr).Message)
+	}
+	return strings.Join(messages, "; ")
+}
@@ -6,9 +6,14 @@ import (
 	"github.com/AsaHero/movie-app-server/delivery/api/docs"
 	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
 	"github.com/AsaHero/movie-app-server/delivery/api/handlers/auth"
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers/comments"
 	"github.com/AsaHero/movie-app-server/delivery/api/handlers/movies"
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers/profiles"
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers/search"
+	"github.com/AsaHero/movie-app-server/delivery/api/handlers/shows"
 	"github.com/AsaHero/movie-app-server/delivery/api/middlewares"
 	"github.com/AsaHero/movie-app-server/pkg/config"
+	"github.com/AsaHero/movie-app-server/pkg/storage"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -33,7 +38,8 @@ func NewRouter(cfg *config.Config, opt *handlers.HandlerOptions) *gin.Engine {
 	r.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*") // нужно изменить в продакшене
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-Match, If-None-Match, X-Account-PIN")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
@@ -51,6 +57,20 @@ func NewRouter(cfg *config.Config, opt *handlers.HandlerOptions) *gin.Engine {
 
 	auth.New(router.Group("/auth"), opt)
 	movies.New(router.Group("/movies"), opt)
+	movies.NewCollections(router.Group("/collections"), opt)
+	movies.NewLists(router.Group("/lists"), opt)
+	movies.NewCharts(router.Group("/charts"), opt)
+	comments.New(router.Group("/comments"), opt)
+	comments.NewMovieThreads(router.Group("/movies/:id/comments"), opt)
+	movies.NewMe(router.Group("/me"), opt)
+	profiles.New(router.Group("/profiles"), opt)
+	shows.New(router.Group("/shows"), opt)
+	search.New(router.Group("/search"), opt)
+
+	// Uploaded media, public so the URLs work in <img> tags
+	if cfg.Media.StorageDriver == storage.DriverLocal {
+		r.Static(cfg.Media.PublicPath, cfg.Media.LocalDir)
+	}
 
 	// Swagger Route
 	docs.SwaggerInfo.BasePath = middlewares.APIPrefix
@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/AsaHero/movie-app-server/pkg/config"
+	"github.com/AsaHero/movie-app-server/pkg/video"
+	"github.com/go-playground/validator/v10"
+)
+
+// validateHTTPURL accepts absolute http(s) URLs with a host
+func validateHTTPURL(fl validator.FieldLevel) bool {
+	_, ok := parseHTTPURL(fl.Field().String())
+	return ok
+}
+
+// newPosterURLValidator accepts http(s) URLs on the configured image hosts and on
+// our own host for uploaded images. An empty allow-list accepts any host.
+func newPosterURLValidator(cfg *config.Config) validator.Func {
+	hosts := make([]string, 0)
+	for _, host := range strings.Split(cfg.Media.PosterHosts, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	if len(hosts) > 0 {
+		if appURL, ok := parseHTTPURL(cfg.AppURL); ok {
+			hosts = append(hosts, strings.ToLower(appURL.Hostname()))
+		}
+	}
+
+	return func(fl validator.FieldLevel) bool {
+		u, ok := parseHTTPURL(fl.Field().String())
+		if !ok {
+			return false
+		}
+
+		return len(hosts) == 0 || hostAllowed(strings.ToLower(u.Hostname()), hosts)
+	}
+}
+
+// validateTrailerURL accepts YouTube and Vimeo video links
+func validateTrailerURL(fl validator.FieldLevel) bool {
+	_, ok := video.Parse(fl.Field().String())
+	return ok
+}
+
+func parseHTTPURL(value string) (*url.URL, bool) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+// hostAllowed matches the host itself or any of its subdomains
+func hostAllowed(host string, allowed []string) bool {
+	for _, candidate := range allowed {
+		if host == candidate || strings.HasSuffix(host, "."+candidate) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"github.com/AsaHero/movie-app-server/pkg/config"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -8,11 +9,14 @@ type Validator struct {
 	validator *validator.Validate
 }
 
-func NewValidator() *Validator {
+func NewValidator(cfg *config.Config) *Validator {
 	validator := validator.New()
 
 	validator.RegisterValidation("password", validatePassword)
 	validator.RegisterValidation("no_space", validateNoSpaces)
+	validator.RegisterValidation("http_url", validateHTTPURL)
+	validator.RegisterValidation("poster_url", newPosterURLValidator(cfg))
+	validator.RegisterValidation("trailer_url", validateTrailerURL)
 
 	return &Validator{
 		validator: validator,
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/AsaHero/movie-app-server/internal/service/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/recommendations"
+	"github.com/AsaHero/movie-app-server/pkg/config"
+)
+
+type Options struct {
+	Config                 *config.Config
+	MoviesService          movies.Service
+	RecommendationsService recommendations.Service
+	Out                    io.Writer
+}
+
+// Command runs a subcommand with its own arguments, e.g. `import-dataset -source imdb -file ...`
+type Command func(ctx context.Context, opt *Options, args []string) error
+
+var commands = map[string]Command{
+	"import-dataset":       ImportDataset,
+	"rebuild-similarities": RebuildSimilarities,
+}
+
+// Run dispatches to the named subcommand
+func Run(ctx context.Context, opt *Options, name string, args []string) error {
+	command, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q, available: %s", name, strings.Join(Names(), ", "))
+	}
+
+	return command(ctx, opt, args)
+}
+
+// IsCommand reports whether name is a known subcommand
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+func Names() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
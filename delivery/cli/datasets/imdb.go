@@ -0,0 +1,119 @@
+package datasets
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// imdbNull is how IMDb dumps spell a missing value
+const imdbNull = `\N`
+
+var imdbColumns = []string{"tconst", "titleType", "primaryTitle", "startYear", "runtimeMinutes", "genres", "isAdult"}
+
+// imdbReader reads title.basics.tsv. Only feature films are kept, the release
+// date is January 1st of startYear since the dump has no full dates.
+type imdbReader struct {
+	scanner *bufio.Scanner
+	columns map[string]int
+	line    int
+	skipped int
+}
+
+func newIMDbReader(scanner *bufio.Scanner) (*imdbReader, error) {
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("imdb dump is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range strings.Split(scanner.Text(), "\t") {
+		columns[name] = i
+	}
+
+	for _, name := range imdbColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("imdb dump has no %s column, expected title.basics.tsv", name)
+		}
+	}
+
+	return &imdbReader{
+		scanner: scanner,
+		columns: columns,
+		line:    1,
+	}, nil
+}
+
+func (r *imdbReader) Next() (*entity.MovieImportRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+
+		fields := strings.Split(r.scanner.Text(), "\t")
+		field := func(name string) string {
+			i := r.columns[name]
+			if i >= len(fields) || fields[i] == imdbNull {
+				return ""
+			}
+			return fields[i]
+		}
+
+		if field("titleType") != "movie" || field("isAdult") == "1" {
+			r.skipped++
+			continue
+		}
+
+		year, err := strconv.Atoi(field("startYear"))
+		if err != nil {
+			r.skipped++
+			continue
+		}
+
+		duration, _ := strconv.Atoi(field("runtimeMinutes"))
+		title := field("primaryTitle")
+		if !validMovie(title, duration) {
+			r.skipped++
+			continue
+		}
+
+		var genres []string
+		if value := field("genres"); value != "" {
+			for _, name := range strings.Split(value, ",") {
+				genres = append(genres, normalizeGenre(name))
+			}
+		}
+
+		tconst := field("tconst")
+
+		return &entity.MovieImportRow{
+			Row:        r.line,
+			ExternalID: externalID(SourceIMDb, tconst),
+			Movie: entity.Movies{
+				Title:           title,
+				Release:         time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+				DurationMinutes: int16(duration),
+			},
+			GenreNames: genres,
+			ExternalIDs: []entity.MovieExternalIDs{
+				{Source: entity.ExternalSourceIMDb, Value: tconst},
+			},
+		}, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("imdb dump line %d: %w", r.line+1, err)
+	}
+
+	return nil, io.EOF
+}
+
+func (r *imdbReader) Skipped() int {
+	return r.skipped
+}
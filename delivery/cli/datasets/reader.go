@@ -0,0 +1,97 @@
+package datasets
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+type Source string
+
+const (
+	SourceIMDb Source = "imdb"
+	SourceTMDB Source = "tmdb"
+)
+
+const (
+	// maxLineSize fits TMDB detail records with appended credits and videos
+	maxLineSize = 8 << 20
+
+	maxTitleLength = 255
+	maxDuration    = 500
+)
+
+// Reader yields importable rows from a dataset dump
+type Reader interface {
+	// Next returns the next row, io.EOF once the dump is exhausted
+	Next() (*entity.MovieImportRow, error)
+	// Skipped is the number of records that were not movies or lacked required data
+	Skipped() int
+}
+
+// Open wraps r in a reader for source, gzip compressed dumps are detected automatically
+func Open(source Source, r io.Reader) (Reader, error) {
+	r, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	switch source {
+	case SourceIMDb:
+		return newIMDbReader(scanner)
+	case SourceTMDB:
+		return newTMDBReader(scanner), nil
+	default:
+		return nil, fmt.Errorf("unknown dataset source %q, expected imdb or tmdb", source)
+	}
+}
+
+func decompress(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+
+	magic, err := buffered.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(buffered)
+	}
+
+	return buffered, nil
+}
+
+// externalID namespaces dataset ids so imdb and tmdb ids never collide
+func externalID(source Source, id string) *string {
+	value := string(source) + ":" + id
+	return &value
+}
+
+// genreAliases maps dataset genre names onto the names used in our catalogue
+var genreAliases = map[string]string{
+	"sci-fi":     "Science Fiction",
+	"film-noir":  "Film Noir",
+	"game-show":  "Game Show",
+	"talk-show":  "Talk Show",
+	"reality-tv": "Reality TV",
+	"tv movie":   "TV Movie",
+}
+
+func normalizeGenre(name string) string {
+	name = strings.TrimSpace(name)
+	if alias, ok := genreAliases[strings.ToLower(name)]; ok {
+		return alias
+	}
+	return name
+}
+
+func validMovie(title string, duration int) bool {
+	return title != "" && len(title) <= maxTitleLength && duration > 0 && duration <= maxDuration
+}
@@ -0,0 +1,128 @@
+package datasets
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+const (
+	tmdbImageURL    = "https://image.tmdb.org/t/p/original"
+	youtubeWatchURL = "https://www.youtube.com/watch?v="
+)
+
+// tmdbMovie is a movie details record as returned by the TMDB API, one per line.
+// The daily id exports lack release dates and runtimes, so their records are skipped.
+type tmdbMovie struct {
+	ID          int64  `json:"id"`
+	IMDbID      string `json:"imdb_id"`
+	Title       string `json:"title"`
+	ReleaseDate string `json:"release_date"`
+	Overview    string `json:"overview"`
+	Runtime     int    `json:"runtime"`
+	PosterPath  string `json:"poster_path"`
+	Adult       bool   `json:"adult"`
+	Genres      []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+	Videos struct {
+		Results []struct {
+			Site string `json:"site"`
+			Type string `json:"type"`
+			Key  string `json:"key"`
+		} `json:"results"`
+	} `json:"videos"`
+}
+
+type tmdbReader struct {
+	scanner *bufio.Scanner
+	line    int
+	skipped int
+}
+
+func newTMDBReader(scanner *bufio.Scanner) *tmdbReader {
+	return &tmdbReader{scanner: scanner}
+}
+
+func (r *tmdbReader) Next() (*entity.MovieImportRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record tmdbMovie
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("tmdb dump line %d: %w", r.line, err)
+		}
+
+		release, err := time.Parse(time.DateOnly, record.ReleaseDate)
+		if err != nil || record.ID == 0 || record.Adult || !validMovie(record.Title, record.Runtime) {
+			r.skipped++
+			continue
+		}
+
+		movie := entity.Movies{
+			Title:           record.Title,
+			Release:         release,
+			DurationMinutes: int16(record.Runtime),
+			TrailerURL:      record.trailerURL(),
+		}
+
+		if record.Overview != "" {
+			movie.Plot = &record.Overview
+		}
+
+		if record.PosterPath != "" {
+			movie.PosterURL = tmdbImageURL + record.PosterPath
+		}
+
+		genres := make([]string, 0, len(record.Genres))
+		for _, genre := range record.Genres {
+			genres = append(genres, normalizeGenre(genre.Name))
+		}
+
+		externalIDs := []entity.MovieExternalIDs{
+			{Source: entity.ExternalSourceTMDB, Value: strconv.FormatInt(record.ID, 10)},
+		}
+		if entity.ExternalSourceIMDb.IsValidValue(record.IMDbID) {
+			externalIDs = append(externalIDs, entity.MovieExternalIDs{Source: entity.ExternalSourceIMDb, Value: record.IMDbID})
+		}
+
+		return &entity.MovieImportRow{
+			Row:         r.line,
+			ExternalID:  externalID(SourceTMDB, strconv.FormatInt(record.ID, 10)),
+			Movie:       movie,
+			GenreNames:  genres,
+			ExternalIDs: externalIDs,
+		}, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("tmdb dump line %d: %w", r.line+1, err)
+	}
+
+	return nil, io.EOF
+}
+
+func (r *tmdbReader) Skipped() int {
+	return r.skipped
+}
+
+// trailerURL picks the first YouTube trailer when videos were appended to the record
+func (m *tmdbMovie) trailerURL() string {
+	for _, video := range m.Videos.Results {
+		if video.Site == "YouTube" && video.Type == "Trailer" && video.Key != "" {
+			return youtubeWatchURL + video.Key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/AsaHero/movie-app-server/delivery/cli/datasets"
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// ImportDataset loads a local IMDb title.basics.tsv(.gz) or TMDB JSON Lines dump.
+// Movies are matched by their imdb:/tmdb: external id, so re-running the same dump
+// only updates changed movies and skips the rest.
+func ImportDataset(ctx context.Context, opt *Options, args []string) error {
+	flags := flag.NewFlagSet("import-dataset", flag.ContinueOnError)
+	flags.SetOutput(opt.Out)
+
+	source := flags.String("source", "", "dataset source: imdb or tmdb")
+	path := flags.String("file", "", "path to the dump, gzip compressed files are detected automatically")
+	dryRun := flags.Bool("dry-run", false, "validate and report without saving")
+	createGenres := flags.Bool("create-genres", true, "create genres that don't exist yet")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *source == "" || *path == "" {
+		flags.Usage()
+		return errors.New("-source and -file are required")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := datasets.Open(datasets.Source(*source), file)
+	if err != nil {
+		return err
+	}
+
+	job, err := opt.MoviesService.ImportStream(ctx, reader.Next, entity.ImportOptions{
+		DryRun:       *dryRun,
+		CreateGenres: *createGenres,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opt.Out, "job:      %s (%s)\n", job.ID, job.Status)
+	fmt.Fprintf(opt.Out, "inserted: %d\n", job.Created)
+	fmt.Fprintf(opt.Out, "updated:  %d\n", job.Updated)
+	fmt.Fprintf(opt.Out, "skipped:  %d\n", job.Skipped+reader.Skipped())
+	fmt.Fprintf(opt.Out, "failed:   %d\n", job.Failed)
+
+	for _, rowErr := range job.Errors {
+		if rowErr.Row == 0 {
+			fmt.Fprintf(opt.Out, "error: %s\n", rowErr.Message)
+			continue
+		}
+		fmt.Fprintf(opt.Out, "line %d (%s): %s\n", rowErr.Row, rowErr.ExternalID, rowErr.Message)
+	}
+
+	if job.Status == entity.ImportJobStatusFailed {
+		return errors.New("import did not finish")
+	}
+
+	return nil
+}
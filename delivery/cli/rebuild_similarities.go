@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+// RebuildSimilarities recomputes the movie similarities behind recommendations
+// right away instead of waiting for the background job
+func RebuildSimilarities(ctx context.Context, opt *Options, args []string) error {
+	flags := flag.NewFlagSet("rebuild-similarities", flag.ContinueOnError)
+	flags.SetOutput(opt.Out)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	stored, err := opt.RecommendationsService.RebuildSimilarities(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opt.Out, "similarities: %d\n", stored)
+	return nil
+}
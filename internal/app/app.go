@@ -3,51 +3,135 @@ package app
 import (
 	"context"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/AsaHero/movie-app-server/delivery/api"
 	"github.com/AsaHero/movie-app-server/delivery/api/handlers"
 	"github.com/AsaHero/movie-app-server/delivery/api/validation"
+	"github.com/AsaHero/movie-app-server/delivery/cli"
+	"github.com/AsaHero/movie-app-server/internal/repository/audit_logs"
+	"github.com/AsaHero/movie-app-server/internal/repository/collection_movies"
+	"github.com/AsaHero/movie-app-server/internal/repository/collections"
+	"github.com/AsaHero/movie-app-server/internal/repository/comment_bans"
+	"github.com/AsaHero/movie-app-server/internal/repository/episodes"
+	"github.com/AsaHero/movie-app-server/internal/repository/genre_translations"
 	genres_repo "github.com/AsaHero/movie-app-server/internal/repository/genres"
-	"github.com/AsaHero/movie-app-server/internal/repository/movie_genres"
+	"github.com/AsaHero/movie-app-server/internal/repository/import_jobs"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_comment_likes"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_comment_reports"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_comments"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_duplicate_dismissals"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_events"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_external_ids"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_images"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_popularity"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_ratings"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_redirects"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_releases"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_similarities"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_translations"
 	movies_repo "github.com/AsaHero/movie-app-server/internal/repository/movies"
+	profiles_repo "github.com/AsaHero/movie-app-server/internal/repository/profiles"
+	"github.com/AsaHero/movie-app-server/internal/repository/seasons"
+	"github.com/AsaHero/movie-app-server/internal/repository/shows"
+	"github.com/AsaHero/movie-app-server/internal/repository/title_genres"
+	titles_repo "github.com/AsaHero/movie-app-server/internal/repository/titles"
+	"github.com/AsaHero/movie-app-server/internal/repository/user_list_follows"
+	"github.com/AsaHero/movie-app-server/internal/repository/user_list_items"
+	"github.com/AsaHero/movie-app-server/internal/repository/user_lists"
 	users_repo "github.com/AsaHero/movie-app-server/internal/repository/users"
+	"github.com/AsaHero/movie-app-server/internal/repository/watch_progress"
 	"github.com/AsaHero/movie-app-server/internal/service/auth"
+	"github.com/AsaHero/movie-app-server/internal/service/charts"
+	"github.com/AsaHero/movie-app-server/internal/service/comments"
 	"github.com/AsaHero/movie-app-server/internal/service/genres"
+	"github.com/AsaHero/movie-app-server/internal/service/lists"
 	"github.com/AsaHero/movie-app-server/internal/service/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/internal/service/progress"
+	"github.com/AsaHero/movie-app-server/internal/service/ratings"
+	"github.com/AsaHero/movie-app-server/internal/service/recommendations"
+	"github.com/AsaHero/movie-app-server/internal/service/titles"
 	"github.com/AsaHero/movie-app-server/internal/service/users"
 	"github.com/AsaHero/movie-app-server/pkg/config"
 	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
 	"github.com/AsaHero/movie-app-server/pkg/logger"
+	"github.com/AsaHero/movie-app-server/pkg/scheduler"
+	"github.com/AsaHero/movie-app-server/pkg/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
 
+// core provides everything shared by the HTTP server and the CLI commands
+var core = fx.Provide(
+	config.New,
+	func(cfg *config.Config) string { return cfg.APP + ".log" },
+	logger.Init,
+	postgres.New,
+	genres_repo.New,
+	title_genres.New,
+	audit_logs.New,
+	import_jobs.New,
+	movie_external_ids.New,
+	movie_images.New,
+	movie_translations.New,
+	genre_translations.New,
+	movie_releases.New,
+	storage.New,
+	users_repo.New,
+	movies_repo.New,
+	profiles_repo.New,
+	shows.New,
+	seasons.New,
+	episodes.New,
+	titles_repo.New,
+	collections.New,
+	collection_movies.New,
+	user_lists.New,
+	user_list_items.New,
+	user_list_follows.New,
+	watch_progress.New,
+	movie_ratings.New,
+	movie_similarities.New,
+	movie_events.New,
+	movie_popularity.New,
+	movie_comments.New,
+	movie_comment_likes.New,
+	movie_comment_reports.New,
+	comment_bans.New,
+	movie_redirects.New,
+	movie_duplicate_dismissals.New,
+	// timeout provider
+	func(cfg *config.Config) time.Duration {
+		d, err := time.ParseDuration(cfg.Context.Timeout)
+		if err != nil {
+			panic(err)
+		}
+		return d
+	},
+	auth.New,
+	users.New,
+	genres.New,
+	movies.New,
+	profiles.New,
+	titles.New,
+	lists.New,
+	progress.New,
+	ratings.New,
+	recommendations.New,
+	charts.New,
+	comments.New,
+)
+
 func Run() {
 	x := fx.New(
+		core,
 		fx.Provide(
-			config.New,
-			func(cfg *config.Config) string { return cfg.APP + ".log" },
-			logger.Init,
-			postgres.New,
-			genres_repo.New,
-			movie_genres.New,
-			users_repo.New,
-			movies_repo.New,
-			// timeout provider
-			func(cfg *config.Config) time.Duration {
-				d, err := time.ParseDuration(cfg.Context.Timeout)
-				if err != nil {
-					panic(err)
-				}
-				return d
-			},
-			auth.New,
-			users.New,
-			genres.New,
-			movies.New,
 			validation.NewValidator,
 			func(
 				cfg *config.Config,
@@ -56,32 +140,88 @@ func Run() {
 				userSvc users.Service,
 				movieSvc movies.Service,
 				genresSvc genres.Service,
+				profilesSvc profiles.Service,
+				titlesSvc titles.Service,
+				listsSvc lists.Service,
+				progressSvc progress.Service,
+				ratingsSvc ratings.Service,
+				recommendationsSvc recommendations.Service,
+				chartsSvc charts.Service,
+				commentsSvc comments.Service,
 			) *handlers.HandlerOptions {
 				return &handlers.HandlerOptions{
-					Config:        cfg,
-					Validator:     validator,
-					AuthService:   authSvc,
-					UsersService:  userSvc,
-					MoviesSerive:  movieSvc,
-					GenresService: genresSvc,
+					Config:                 cfg,
+					Validator:              validator,
+					AuthService:            authSvc,
+					UsersService:           userSvc,
+					MoviesSerive:           movieSvc,
+					GenresService:          genresSvc,
+					ProfilesService:        profilesSvc,
+					TitlesService:          titlesSvc,
+					ListsService:           listsSvc,
+					ProgressService:        progressSvc,
+					RatingsService:         ratingsSvc,
+					RecommendationsService: recommendationsSvc,
+					ChartsService:          chartsSvc,
+					CommentsService:        commentsSvc,
 				}
 			},
 			api.NewRouter,
 			func(router *gin.Engine) http.Handler { return router },
 			api.NewServer,
+			scheduler.New,
 		),
+		fx.Invoke(registerJobs),
 		fx.Invoke(registerHooks),
 	)
 
 	x.Run()
 }
 
+// RunCommand runs a CLI subcommand against the configured database and exits when it is done
+func RunCommand(name string, args []string) error {
+	var (
+		cfg                *config.Config
+		db                 *gorm.DB
+		movieSvc           movies.Service
+		recommendationsSvc recommendations.Service
+	)
+
+	x := fx.New(
+		core,
+		fx.NopLogger,
+		fx.Populate(&cfg, &db, &movieSvc, &recommendationsSvc),
+	)
+	if err := x.Err(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return cli.Run(ctx, &cli.Options{
+		Config:                 cfg,
+		MoviesService:          movieSvc,
+		RecommendationsService: recommendationsSvc,
+		Out:                    os.Stdout,
+	}, name, args)
+}
+
 func registerHooks(
 	lc fx.Lifecycle,
 	cfg *config.Config,
 	log *logrus.Logger,
 	server *http.Server,
 	db *gorm.DB,
+	sched *scheduler.Scheduler,
+	chartsSvc charts.Service,
+	movieSvc movies.Service,
 ) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -91,13 +231,19 @@ func registerHooks(
 					log.Error("server error:", err.Error())
 				}
 			}()
+			chartsSvc.Start()
+			sched.Start()
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
 			log.Info(cfg.APP, "shutting down...")
+			sched.Stop()
 			if err := server.Shutdown(ctx); err != nil {
 				return err
 			}
+			// requests are done, write the movie events they recorded
+			chartsSvc.Stop()
+			movieSvc.StopImports()
 			sqlDB, _ := db.DB()
 			return sqlDB.Close()
 		},
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/service/charts"
+	"github.com/AsaHero/movie-app-server/internal/service/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/recommendations"
+	"github.com/AsaHero/movie-app-server/pkg/config"
+	"github.com/AsaHero/movie-app-server/pkg/logger"
+	"github.com/AsaHero/movie-app-server/pkg/scheduler"
+	"github.com/sirupsen/logrus"
+)
+
+func registerJobs(
+	cfg *config.Config,
+	sched *scheduler.Scheduler,
+	movieSvc movies.Service,
+	recommendationsSvc recommendations.Service,
+	chartsSvc charts.Service,
+) error {
+	retention, err := positiveDuration("TRASH_RETENTION", cfg.Trash.Retention)
+	if err != nil {
+		return err
+	}
+
+	purgeInterval, err := positiveDuration("TRASH_PURGE_INTERVAL", cfg.Trash.PurgeInterval)
+	if err != nil {
+		return err
+	}
+
+	sched.Every("movies-trash-purge", purgeInterval, func(ctx context.Context) error {
+		purged, err := movieSvc.PurgeTrash(ctx, retention)
+		if err != nil {
+			return err
+		}
+
+		if purged > 0 {
+			logger.Info("purged movies from trash", logrus.Fields{"count": purged})
+		}
+		return nil
+	})
+
+	rebuildInterval, err := positiveDuration("RECOMMENDATIONS_REBUILD_INTERVAL", cfg.Recommendations.RebuildInterval)
+	if err != nil {
+		return err
+	}
+
+	sched.Every("movie-similarities-rebuild", rebuildInterval, func(ctx context.Context) error {
+		stored, err := recommendationsSvc.RebuildSimilarities(ctx)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("rebuilt movie similarities", logrus.Fields{"count": stored})
+		return nil
+	})
+
+	chartsInterval, err := positiveDuration("CHARTS_REBUILD_INTERVAL", cfg.Charts.RebuildInterval)
+	if err != nil {
+		return err
+	}
+
+	sched.Every("movie-popularity-rebuild", chartsInterval, func(ctx context.Context) error {
+		stored, err := chartsSvc.RebuildPopularity(ctx)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("rebuilt movie popularity", logrus.Fields{"count": stored})
+		return nil
+	})
+
+	publishInterval, err := positiveDuration("PUBLISH_INTERVAL", cfg.Publishing.Interval)
+	if err != nil {
+		return err
+	}
+
+	sched.Every("movies-publish-scheduled", publishInterval, func(ctx context.Context) error {
+		published, err := movieSvc.PublishScheduled(ctx)
+		if err != nil {
+			return err
+		}
+
+		if published > 0 {
+			logger.Info("published scheduled movies", logrus.Fields{"count": published})
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// positiveDuration parses a duration setting, zero or negative ones are rejected
+// since a ticker cannot run on them
+func positiveDuration(name, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %s", name, value)
+	}
+
+	return d, nil
+}
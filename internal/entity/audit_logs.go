@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/shogo82148/pointer"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate     AuditAction = "create"
+	AuditActionUpdate     AuditAction = "update"
+	AuditActionDelete     AuditAction = "delete"
+	AuditActionHardDelete AuditAction = "hard_delete"
+	AuditActionRestore    AuditAction = "restore"
+	AuditActionRevert     AuditAction = "revert"
+	AuditActionStatus     AuditAction = "status"
+	AuditActionMerge      AuditAction = "merge"
+)
+
+type AuditEntityType string
+
+const (
+	AuditEntityMovie AuditEntityType = "movie"
+	AuditEntityGenre AuditEntityType = "genre"
+)
+
+type AuditLogs struct {
+	ID         int64 `gorm:"primary_key"`
+	EntityType AuditEntityType
+	EntityID   int64
+	Action     AuditAction
+	ActorID    *string
+	Before     json.RawMessage `gorm:"type:jsonb"`
+	After      json.RawMessage `gorm:"type:jsonb"`
+	CreatedAt  time.Time
+}
+
+type AuditChange struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// Diff lists the top level fields that differ between Before and After
+func (a *AuditLogs) Diff() ([]AuditChange, error) {
+	before := map[string]any{}
+	after := map[string]any{}
+
+	if len(a.Before) > 0 {
+		if err := json.Unmarshal(a.Before, &before); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(a.After) > 0 {
+		if err := json.Unmarshal(a.After, &after); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+
+	changes := make([]AuditChange, 0, len(fields))
+	for field := range fields {
+		if reflect.DeepEqual(before[field], after[field]) {
+			continue
+		}
+		changes = append(changes, AuditChange{
+			Field:  field,
+			Before: before[field],
+			After:  after[field],
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+// GenreSnapshot is the state of a genre stored in the audit log
+type GenreSnapshot struct {
+	Name string `json:"name"`
+}
+
+func NewGenreSnapshot(g *Genres) GenreSnapshot {
+	return GenreSnapshot{Name: g.Name}
+}
+
+// MovieSnapshot is the state of a movie stored in the audit log
+type MovieSnapshot struct {
+	Title           string    `json:"title"`
+	Release         time.Time `json:"release"`
+	Plot            *string   `json:"plot"`
+	DurationMinutes int16     `json:"duration_minutes"`
+	PosterURL       string    `json:"poster_url"`
+	TrailerURL      string    `json:"trailer_url"`
+	GenreIDs        []int64   `json:"genre_ids"`
+	// Status is recorded for the history only, it is not compared nor reverted
+	// since it only changes through the editorial workflow
+	Status MovieStatus `json:"status,omitempty"`
+}
+
+func NewMovieSnapshot(m *Movies) MovieSnapshot {
+	snapshot := MovieSnapshot{
+		Title:           m.Title,
+		Release:         m.Release,
+		Plot:            m.Plot,
+		DurationMinutes: m.DurationMinutes,
+		PosterURL:       m.PosterURL,
+		TrailerURL:      m.TrailerURL,
+		GenreIDs:        make([]int64, 0, len(m.MovieGenres)),
+		Status:          m.Status,
+	}
+
+	for _, genre := range m.MovieGenres {
+		snapshot.GenreIDs = append(snapshot.GenreIDs, genre.GenreID)
+	}
+	sort.Slice(snapshot.GenreIDs, func(i, j int) bool {
+		return snapshot.GenreIDs[i] < snapshot.GenreIDs[j]
+	})
+
+	return snapshot
+}
+
+// Equal reports whether both snapshots describe the same movie state
+func (s MovieSnapshot) Equal(other MovieSnapshot) bool {
+	return s.Title == other.Title &&
+		s.Release.Equal(other.Release) &&
+		pointer.StringValue(s.Plot) == pointer.StringValue(other.Plot) &&
+		s.DurationMinutes == other.DurationMinutes &&
+		s.PosterURL == other.PosterURL &&
+		s.TrailerURL == other.TrailerURL &&
+		slices.Equal(s.GenreIDs, other.GenreIDs)
+}
+
+// Apply copies the snapshot onto the movie, replacing its genres
+func (s MovieSnapshot) Apply(m *Movies) {
+	m.Title = s.Title
+	m.Release = s.Release
+	m.Plot = s.Plot
+	m.DurationMinutes = s.DurationMinutes
+	m.PosterURL = s.PosterURL
+	m.TrailerURL = s.TrailerURL
+
+	m.MovieGenres = make([]TitleGenres, 0, len(s.GenreIDs))
+	for _, genreID := range s.GenreIDs {
+		m.MovieGenres = append(m.MovieGenres, TitleGenres{
+			TitleType: TitleTypeMovie,
+			TitleID:   m.ID,
+			GenreID:   genreID,
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func snapshotJSON(t *testing.T, snapshot any) json.RawMessage {
+	t.Helper()
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestAuditLogsDiff(t *testing.T) {
+	release := time.Date(1999, time.March, 31, 0, 0, 0, 0, time.UTC)
+	plot := "A hacker learns the truth"
+
+	before := NewMovieSnapshot(&Movies{
+		Title:           "The Matrix",
+		Release:         release,
+		DurationMinutes: 136,
+		MovieGenres:     []TitleGenres{{GenreID: 2}, {GenreID: 1}},
+	})
+
+	after := before
+	after.Title = "The Matrix (1999)"
+	after.Plot = &plot
+
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		fields []string
+	}{
+		{"update", before, after, []string{"plot", "title"}},
+		{"unchanged", before, before, []string{}},
+		{"create", nil, before, []string{"duration_minutes", "genre_ids", "poster_url", "release", "title", "trailer_url"}},
+		{"delete", before, nil, []string{"duration_minutes", "genre_ids", "poster_url", "release", "title", "trailer_url"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &AuditLogs{}
+			if tt.before != nil {
+				log.Before = snapshotJSON(t, tt.before)
+			}
+			if tt.after != nil {
+				log.After = snapshotJSON(t, tt.after)
+			}
+
+			changes, err := log.Diff()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fields := make([]string, 0, len(changes))
+			for _, change := range changes {
+				fields = append(fields, change.Field)
+			}
+
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Fatalf("changed fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestAuditLogsDiffValues(t *testing.T) {
+	log := &AuditLogs{
+		Before: json.RawMessage(`{"title":"Alien","duration_minutes":117}`),
+		After:  json.RawMessage(`{"title":"Aliens","duration_minutes":117}`),
+	}
+
+	changes, err := log.Diff()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []AuditChange{{Field: "title", Before: "Alien", After: "Aliens"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("Diff() = %+v, want %+v", changes, want)
+	}
+}
+
+func TestAuditLogsDiffInvalidJSON(t *testing.T) {
+	log := &AuditLogs{Before: json.RawMessage(`{`)}
+
+	if _, err := log.Diff(); err == nil {
+		t.Fatal("Diff() must fail on a corrupt snapshot")
+	}
+}
+
+func TestMovieSnapshotEqual(t *testing.T) {
+	release := time.Date(1979, time.May, 25, 0, 0, 0, 0, time.UTC)
+	empty := ""
+
+	base := &Movies{
+		Title:           "Alien",
+		Release:         release,
+		DurationMinutes: 117,
+		MovieGenres:     []TitleGenres{{GenreID: 3}, {GenreID: 1}},
+	}
+
+	tests := []struct {
+		name   string
+		change func(m *Movies)
+		want   bool
+	}{
+		{"identical", func(m *Movies) {}, true},
+		{"genres in another order", func(m *Movies) {
+			m.MovieGenres = []TitleGenres{{GenreID: 1}, {GenreID: 3}}
+		}, true},
+		{"empty plot equals no plot", func(m *Movies) { m.Plot = &empty }, true},
+		{"same instant in another zone", func(m *Movies) { m.Release = release.In(time.FixedZone("X", 3600)) }, true},
+		{"status is ignored", func(m *Movies) { m.Status = MovieStatusPublished }, true},
+		{"title", func(m *Movies) { m.Title = "Aliens" }, false},
+		{"duration", func(m *Movies) { m.DurationMinutes = 116 }, false},
+		{"genre removed", func(m *Movies) { m.MovieGenres = m.MovieGenres[:1] }, false},
+		{"trailer", func(m *Movies) { m.TrailerURL = "https://youtu.be/x" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := *base
+			other.MovieGenres = append([]TitleGenres(nil), base.MovieGenres...)
+			tt.change(&other)
+
+			if got := NewMovieSnapshot(base).Equal(NewMovieSnapshot(&other)); got != tt.want {
+				t.Fatalf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMovieSnapshotApply(t *testing.T) {
+	plot := "In space no one can hear you scream"
+	snapshot := MovieSnapshot{
+		Title:           "Alien",
+		Release:         time.Date(1979, time.May, 25, 0, 0, 0, 0, time.UTC),
+		Plot:            &plot,
+		DurationMinutes: 117,
+		GenreIDs:        []int64{1, 3},
+	}
+
+	movie := &Movies{ID: 9, Title: "Aliens", MovieGenres: []TitleGenres{{GenreID: 7}}}
+	snapshot.Apply(movie)
+
+	if !NewMovieSnapshot(movie).Equal(snapshot) {
+		t.Fatalf("the movie does not match the applied snapshot: %+v", NewMovieSnapshot(movie))
+	}
+
+	for _, genre := range movie.MovieGenres {
+		if genre.TitleID != 9 || genre.TitleType != TitleTypeMovie {
+			t.Fatalf("genre %+v is not attached to the movie", genre)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package entity
+
+import "time"
+
+// MovieEventKind is the engagement a movie event records
+type MovieEventKind string
+
+const (
+	// MovieEventView is a movie's details being fetched
+	MovieEventView MovieEventKind = "view"
+	// MovieEventOpen is a movie's player being opened
+	MovieEventOpen MovieEventKind = "open"
+)
+
+func (k MovieEventKind) IsValid() bool {
+	switch k {
+	case MovieEventView, MovieEventOpen:
+		return true
+	}
+	return false
+}
+
+// MovieEvents are the engagement signals charts are computed from
+type MovieEvents struct {
+	ID        int64
+	MovieID   int64
+	UserID    string
+	Kind      MovieEventKind
+	CreatedAt time.Time
+}
+
+// Chart is a ranking of movies by their recent activity
+type Chart string
+
+const (
+	// ChartTrending favours movies picking up activity at the end of the period
+	ChartTrending Chart = "trending"
+	// ChartPopular ranks movies by their activity over the whole period
+	ChartPopular Chart = "popular"
+)
+
+// ChartPeriod is how far back a chart looks
+type ChartPeriod string
+
+const (
+	ChartDay   ChartPeriod = "day"
+	ChartWeek  ChartPeriod = "week"
+	ChartMonth ChartPeriod = "month"
+	ChartYear  ChartPeriod = "year"
+)
+
+// ChartPeriods are every period popularity is computed for
+var ChartPeriods = []ChartPeriod{ChartDay, ChartWeek, ChartMonth, ChartYear}
+
+func (p ChartPeriod) IsValid() bool {
+	return p.Duration() > 0
+}
+
+// Duration returns how far back the period looks, 0 for an unknown period
+func (p ChartPeriod) Duration() time.Duration {
+	switch p {
+	case ChartDay:
+		return 24 * time.Hour
+	case ChartWeek:
+		return 7 * 24 * time.Hour
+	case ChartMonth:
+		return 30 * 24 * time.Hour
+	case ChartYear:
+		return 365 * 24 * time.Hour
+	}
+	return 0
+}
+
+// PopularityWeights tune how movie events add up to popularity scores
+type PopularityWeights struct {
+	// View and Open weigh each kind of event, a user counts once per movie, kind and day
+	View float64
+	Open float64
+	// HalfLife and TrendingHalfLife are the share of the period after which an
+	// event counts half as much in Score and in TrendingScore
+	HalfLife         float64
+	TrendingHalfLife float64
+}
+
+// MoviePopularity is how much activity a movie had over Period. Score decays
+// events slowly, TrendingScore decays them fast and is damped by the activity of
+// the period before, so a steady favourite ranks below a movie picking up.
+type MoviePopularity struct {
+	MovieID       int64       `gorm:"primary_key"`
+	Period        ChartPeriod `gorm:"primary_key"`
+	Score         float64
+	TrendingScore float64
+	// Events is how many distinct events were counted, before weighting
+	Events     int64
+	ComputedAt time.Time
+
+	Movie *Movies `gorm:"-"`
+}
+
+func (MoviePopularity) TableName() string {
+	return "movie_popularity"
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// Collections group related movies, e.g. a franchise, in a set order
+type Collections struct {
+	ID          int64 `gorm:"primary_key"`
+	Name        string
+	Description *string
+	PosterURL   string
+	BackdropURL string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	// Relations
+	Members []CollectionMovies `gorm:"foreignKey:CollectionID"`
+}
+
+// CollectionMovies places a movie in a collection, positions start at 1
+type CollectionMovies struct {
+	CollectionID int64 `gorm:"primary_key"`
+	MovieID      int64 `gorm:"primary_key"`
+	Position     int
+
+	Collection *Collections `gorm:"foreignKey:ID;references:CollectionID"`
+	Movie      *Movies      `gorm:"foreignKey:ID;references:MovieID"`
+
+	// Previous and Next are the neighbouring entries the viewer may see, set on single movie lookups
+	Previous *CollectionMovies `gorm:"-"`
+	Next     *CollectionMovies `gorm:"-"`
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MovieComments are the discussion of a movie. Top-level comments start a
+// thread, replies keep the thread's root in RootID and the comment they answer
+// in ParentID. Deleted and hidden comments stay in place so replies keep their
+// context, their body is only shown to moderators.
+type MovieComments struct {
+	ID       int64 `gorm:"primary_key"`
+	MovieID  int64
+	UserID   string
+	RootID   *int64
+	ParentID *int64
+	Body     string
+	// LikesCount is kept in step with movie_comment_likes, RepliesCount counts
+	// the visible replies of a thread and is only kept on its root
+	LikesCount   int
+	RepliesCount int
+	EditedAt     *time.Time
+	DeletedAt    *time.Time
+	HiddenAt     *time.Time
+	HiddenBy     *string
+	// LockedAt is set on the root of a thread that takes no more replies
+	LockedAt  *time.Time
+	LockedBy  *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User *Users `gorm:"foreignKey:ID;references:UserID"`
+
+	// Replies are the first replies of a thread, loaded for top-level comments
+	Replies []*MovieComments `gorm:"-"`
+	// Liked is whether the user listing the comment liked it
+	Liked bool `gorm:"-"`
+}
+
+// IsRemoved reports whether the comment was deleted by its author or hidden by a moderator
+func (c *MovieComments) IsRemoved() bool {
+	return c.DeletedAt != nil || c.HiddenAt != nil
+}
+
+// ThreadID returns the id of the top-level comment of the comment's thread
+func (c *MovieComments) ThreadID() int64 {
+	if c.RootID != nil {
+		return *c.RootID
+	}
+	return c.ID
+}
+
+type MovieCommentLikes struct {
+	CommentID int64  `gorm:"primary_key"`
+	UserID    string `gorm:"primary_key"`
+	CreatedAt time.Time
+}
+
+// MovieCommentReports flag a comment to the moderators, once per user. They
+// stay open until a moderator hides the comment or dismisses them.
+type MovieCommentReports struct {
+	CommentID  int64  `gorm:"primary_key"`
+	UserID     string `gorm:"primary_key"`
+	Reason     string
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+	ResolvedBy *string
+
+	Comment *MovieComments `gorm:"foreignKey:ID;references:CommentID"`
+}
+
+// CommentBans keep a user from commenting until ExpiresAt, forever when it is nil
+type CommentBans struct {
+	UserID    string `gorm:"primary_key"`
+	BannedBy  string
+	Reason    *string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+// IsActive reports whether the ban still applies at now
+func (b *CommentBans) IsActive(now time.Time) bool {
+	return b.ExpiresAt == nil || b.ExpiresAt.After(now)
+}
+
+// CommentPage is a slice of a comment listing, NextCursor is empty on the last page
+type CommentPage struct {
+	Comments   []*MovieComments
+	NextCursor string
+}
+
+// CommentCursor is the position of the last comment of a page. Listings are
+// ordered by creation time and id, so pages stay stable while comments are added.
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// Encode returns the cursor as an opaque, URL safe string
+func (c CommentCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCommentCursor decodes a cursor returned by Encode
+func ParseCommentCursor(value string) (*CommentCursor, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, false
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, false
+	}
+
+	cursor := CommentCursor{}
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, false
+	}
+	if cursor.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, false
+	}
+
+	return &cursor, true
+}
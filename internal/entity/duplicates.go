@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// MovieRedirects send the id of a merged movie to the movie it was merged into
+type MovieRedirects struct {
+	MovieID   int64 `gorm:"primary_key"`
+	TargetID  int64
+	MergedBy  *string
+	CreatedAt time.Time
+}
+
+// MovieDuplicateDismissals are candidate pairs an admin marked as different
+// movies, MovieID is always the lower id of the pair
+type MovieDuplicateDismissals struct {
+	MovieID     int64 `gorm:"primary_key"`
+	DuplicateID int64 `gorm:"primary_key"`
+	DismissedBy *string
+	CreatedAt   time.Time
+}
+
+// DuplicateCandidate is a pair of movies whose titles match once normalized and
+// whose releases are at most a year apart. Score in [0, 1] weighs how close the
+// release years and durations are, MovieID is the lower id of the pair.
+type DuplicateCandidate struct {
+	MovieID      int64
+	DuplicateID  int64
+	Score        float64
+	YearDiff     int
+	DurationDiff int
+
+	Movie     *Movies `gorm:"-"`
+	Duplicate *Movies `gorm:"-"`
+}
@@ -0,0 +1,55 @@
+package entity
+
+type MovieFacet string
+
+const (
+	MovieFacetGenres    MovieFacet = "genres"
+	MovieFacetDecades   MovieFacet = "decades"
+	MovieFacetYears     MovieFacet = "years"
+	MovieFacetDurations MovieFacet = "durations"
+)
+
+func (f MovieFacet) IsValid() bool {
+	switch f {
+	case MovieFacetGenres, MovieFacetDecades, MovieFacetYears, MovieFacetDurations:
+		return true
+	}
+	return false
+}
+
+// DurationBucket is a half-open [Min, Max) range of minutes, zero Max means unbounded
+type DurationBucket struct {
+	Key   string
+	Label string
+	Min   int16
+	Max   int16
+}
+
+var MovieDurationBuckets = []DurationBucket{
+	{Key: "short", Label: "Under 90 min", Min: 0, Max: 90},
+	{Key: "medium", Label: "90-120 min", Min: 90, Max: 120},
+	{Key: "long", Label: "120-150 min", Min: 120, Max: 150},
+	{Key: "epic", Label: "150+ min", Min: 150},
+}
+
+func FindDurationBucket(key string) (DurationBucket, bool) {
+	for _, bucket := range MovieDurationBuckets {
+		if bucket.Key == key {
+			return bucket, true
+		}
+	}
+	return DurationBucket{}, false
+}
+
+type FacetBucket struct {
+	Value string
+	Label string
+	Count int64
+}
+
+type MovieFacets struct {
+	Genres    []FacetBucket
+	Decades   []FacetBucket
+	Years     []FacetBucket
+	Durations []FacetBucket
+}
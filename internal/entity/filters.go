@@ -1,6 +1,22 @@
 package entity
 
 type MovieFilters struct {
-	Search *string
-	Genres []int
+	Search    *string
+	Genres    []int
+	Decades   []int
+	Years     []int
+	Durations []string
+	// Country keeps movies released in that country
+	Country string
+	// AgeLimits must all hold, e.g. the max_certification parameter and the viewing profile
+	AgeLimits []AgeLimit
+	// Statuses keeps movies in these workflow stages, only published ones when empty
+	Statuses []MovieStatus
+}
+
+// AgeLimit keeps movies whose strictest certification in Country is at most MaxAge,
+// movies without a certification there are left out
+type AgeLimit struct {
+	Country string
+	MaxAge  int16
 }
@@ -4,5 +4,6 @@ type Genres struct {
 	ID   int64 `gorm:"primary_key"`
 	Name string
 
-	MovieGenres []MovieGenres `gorm:"foreignKey:GenreID"`
+	TitleGenres  []TitleGenres       `gorm:"foreignKey:GenreID"`
+	Translations []GenreTranslations `gorm:"foreignKey:GenreID"`
 }
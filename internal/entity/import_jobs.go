@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatusPending   ImportJobStatus = "pending"
+	ImportJobStatusRunning   ImportJobStatus = "running"
+	ImportJobStatusCompleted ImportJobStatus = "completed"
+	ImportJobStatusFailed    ImportJobStatus = "failed"
+)
+
+type ImportJobs struct {
+	ID           string `gorm:"primary_key"`
+	Status       ImportJobStatus
+	DryRun       bool
+	CreateGenres bool
+	Total        int
+	Processed    int
+	Created      int
+	Updated      int
+	Skipped      int
+	Failed       int
+	Errors       ImportRowErrors `gorm:"type:jsonb"`
+	CreatedBy    *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	FinishedAt   *time.Time
+}
+
+func (j *ImportJobs) IsFinished() bool {
+	return j.Status == ImportJobStatusCompleted || j.Status == ImportJobStatusFailed
+}
+
+type ImportRowError struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Message    string `json:"message"`
+}
+
+// ImportRowErrors is stored as a jsonb array
+type ImportRowErrors []ImportRowError
+
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (e *ImportRowErrors) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return errors.New("unsupported type for ImportRowErrors")
+	}
+}
+
+type ImportOptions struct {
+	DryRun       bool
+	CreateGenres bool
+	// Async returns right after the job is created and processes rows in the background
+	Async bool
+}
+
+// MovieImportRow is a parsed and validated input row waiting to be written
+type MovieImportRow struct {
+	Row int
+	// ExternalID is the import key matching the row to an existing movie, see ParseImportKey
+	ExternalID *string
+	Movie      Movies
+	GenreNames []string
+	// ExternalIDs are attached to the movie in addition to the import key
+	ExternalIDs []MovieExternalIDs
+	// Err is set for a row that failed validation, it is reported instead of written
+	Err error
+}
+
+// ImportRows yields the rows of an upload one at a time, Next returns io.EOF
+// after the last one. Close releases the upload once the import is done.
+type ImportRows interface {
+	Next() (*MovieImportRow, error)
+	Close() error
+}
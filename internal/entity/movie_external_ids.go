@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+type ExternalSource string
+
+const (
+	ExternalSourceIMDb ExternalSource = "imdb"
+	ExternalSourceTMDB ExternalSource = "tmdb"
+	// ExternalSourceImport holds free-form import keys that name no partner catalogue
+	ExternalSourceImport ExternalSource = "import"
+)
+
+// importKeySources are tried in order when a movie's import key is exported
+var importKeySources = []ExternalSource{ExternalSourceImport, ExternalSourceTMDB, ExternalSourceIMDb}
+
+var externalValuePatterns = map[ExternalSource]*regexp.Regexp{
+	ExternalSourceIMDb: regexp.MustCompile(`^tt\d{7,}$`),
+	ExternalSourceTMDB: regexp.MustCompile(`^\d+$`),
+}
+
+func (s ExternalSource) IsValid() bool {
+	_, ok := externalValuePatterns[s]
+	return ok
+}
+
+// IsValidValue checks the id format of the source, e.g. tt0078748 for imdb
+func (s ExternalSource) IsValidValue(value string) bool {
+	pattern, ok := externalValuePatterns[s]
+	return ok && pattern.MatchString(value)
+}
+
+// MovieExternalIDs links a movie to its id in a partner catalogue, at most one per source
+type MovieExternalIDs struct {
+	ID        int64 `gorm:"primary_key"`
+	MovieID   int64
+	Source    ExternalSource
+	Value     string
+	CreatedAt time.Time
+}
+
+// ParseImportKey maps the external_id of an import row onto an external id.
+// "<source>:<value>" keys of a partner source become that source's id, e.g.
+// tmdb:603, anything else is kept whole under the import source.
+func ParseImportKey(key string) MovieExternalIDs {
+	source, value, ok := strings.Cut(key, ":")
+	if ok && ExternalSource(source).IsValid() {
+		return MovieExternalIDs{Source: ExternalSource(source), Value: value}
+	}
+
+	return MovieExternalIDs{Source: ExternalSourceImport, Value: key}
+}
+
+// ImportKey is the inverse of ParseImportKey
+func (e MovieExternalIDs) ImportKey() string {
+	if e.Source == ExternalSourceImport {
+		return e.Value
+	}
+	return string(e.Source) + ":" + e.Value
+}
+
+// ImportKey is the key an export writes for the movie so a re-import updates it,
+// nil when the movie has no external ids loaded
+func (m *Movies) ImportKey() *string {
+	for _, source := range importKeySources {
+		for _, externalID := range m.ExternalIDs {
+			if externalID.Source == source {
+				key := externalID.ImportKey()
+				return &key
+			}
+		}
+	}
+
+	return nil
+}
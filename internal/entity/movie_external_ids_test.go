@@ -0,0 +1,62 @@
+package entity
+
+import "testing"
+
+func TestParseImportKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want MovieExternalIDs
+	}{
+		{"tmdb:603", MovieExternalIDs{Source: ExternalSourceTMDB, Value: "603"}},
+		{"imdb:tt0133093", MovieExternalIDs{Source: ExternalSourceIMDb, Value: "tt0133093"}},
+		{"matrix-1999", MovieExternalIDs{Source: ExternalSourceImport, Value: "matrix-1999"}},
+		{"catalogue:42", MovieExternalIDs{Source: ExternalSourceImport, Value: "catalogue:42"}},
+		{"import:42", MovieExternalIDs{Source: ExternalSourceImport, Value: "import:42"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := ParseImportKey(tt.key)
+			if got != tt.want {
+				t.Errorf("ParseImportKey(%q) = %+v, want %+v", tt.key, got, tt.want)
+			}
+			if key := got.ImportKey(); key != tt.key {
+				t.Errorf("ImportKey() = %q, want %q", key, tt.key)
+			}
+		})
+	}
+}
+
+func TestMoviesImportKey(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []MovieExternalIDs
+		want string
+	}{
+		{"none", nil, ""},
+		{"partner id", []MovieExternalIDs{{Source: ExternalSourceIMDb, Value: "tt0133093"}}, "imdb:tt0133093"},
+		{"tmdb before imdb", []MovieExternalIDs{
+			{Source: ExternalSourceIMDb, Value: "tt0133093"},
+			{Source: ExternalSourceTMDB, Value: "603"},
+		}, "tmdb:603"},
+		{"import key first", []MovieExternalIDs{
+			{Source: ExternalSourceTMDB, Value: "603"},
+			{Source: ExternalSourceImport, Value: "matrix-1999"},
+		}, "matrix-1999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&Movies{ExternalIDs: tt.ids}).ImportKey()
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("ImportKey() = %q, want nil", *got)
+				}
+				return
+			}
+			if got == nil || *got != tt.want {
+				t.Errorf("ImportKey() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
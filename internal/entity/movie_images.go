@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"image"
+	"time"
+)
+
+type ImageKind string
+
+const (
+	ImageKindPoster   ImageKind = "poster"
+	ImageKindBackdrop ImageKind = "backdrop"
+)
+
+// ImageVariant is a stored rendition of an upload, Width 0 keeps the original size
+type ImageVariant struct {
+	Name  string
+	Width int
+}
+
+const ImageVariantOriginal = "original"
+
+var imageVariants = map[ImageKind][]ImageVariant{
+	ImageKindPoster: {
+		{Name: ImageVariantOriginal},
+		{Name: "w185", Width: 185},
+		{Name: "w342", Width: 342},
+		{Name: "w500", Width: 500},
+	},
+	ImageKindBackdrop: {
+		{Name: ImageVariantOriginal},
+		{Name: "w300", Width: 300},
+		{Name: "w780", Width: 780},
+		{Name: "w1280", Width: 1280},
+	},
+}
+
+func (k ImageKind) IsValid() bool {
+	_, ok := imageVariants[k]
+	return ok
+}
+
+func (k ImageKind) Variants() []ImageVariant {
+	return imageVariants[k]
+}
+
+// ImageUpload is a sniffed and decoded upload, Data holds the file as received
+type ImageUpload struct {
+	Data        []byte
+	ContentType string
+	Image       image.Image
+}
+
+type MovieImages struct {
+	ID          int64 `gorm:"primary_key"`
+	MovieID     int64
+	Kind        ImageKind
+	Variant     string
+	StorageKey  string
+	ContentType string
+	Width       int
+	Height      int
+	Size        int64
+	CreatedAt   time.Time
+
+	// URL is resolved from StorageKey by the storage backend, it is not stored
+	URL string `gorm:"-"`
+}
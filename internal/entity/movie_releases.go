@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ReleaseType string
+
+const (
+	ReleaseTypeTheatrical ReleaseType = "theatrical"
+	ReleaseTypeDigital    ReleaseType = "digital"
+)
+
+func (t ReleaseType) IsValid() bool {
+	switch t {
+	case ReleaseTypeTheatrical, ReleaseTypeDigital:
+		return true
+	}
+	return false
+}
+
+// MovieReleases is the release of a movie in one country, at most one per release type.
+// MinAge is derived from Certification so ratings from different systems compare.
+type MovieReleases struct {
+	ID            int64 `gorm:"primary_key"`
+	MovieID       int64
+	Country       string
+	Type          ReleaseType
+	ReleaseDate   time.Time
+	Certification *string
+	MinAge        *int16
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// certificationAges maps the ratings of the national systems we know to the age
+// they are aimed at, advisory ratings such as PG included so they rank above G
+var certificationAges = map[string]map[string]int16{
+	"US": {"G": 0, "PG": 10, "PG-13": 13, "R": 17, "NC-17": 18},
+	"GB": {"U": 0, "PG": 8, "12A": 12, "12": 12, "15": 15, "18": 18, "R18": 18},
+	"DE": {"0": 0, "6": 6, "12": 12, "16": 16, "18": 18},
+	"FR": {"U": 0, "TP": 0, "10": 10, "12": 12, "16": 16, "18": 18},
+	"AU": {"G": 0, "PG": 8, "M": 15, "MA15+": 15, "R18+": 18, "X18+": 18},
+	"BR": {"L": 0, "10": 10, "12": 12, "14": 14, "16": 16, "18": 18},
+	"JP": {"G": 0, "PG12": 12, "R15+": 15, "R18+": 18},
+	"IN": {"U": 0, "UA": 12, "A": 18},
+	"RU": {"0+": 0, "6+": 6, "12+": 12, "16+": 16, "18+": 18},
+}
+
+// ageCertification matches plain age ratings such as 16 or 16+ used by many countries
+var ageCertification = regexp.MustCompile(`^(\d{1,2})\+?$`)
+
+// CertificationAge returns the minimum age of certification in country. Plain
+// age ratings are understood for every country.
+func CertificationAge(country, certification string) (int16, bool) {
+	certification = strings.ToUpper(strings.TrimSpace(certification))
+
+	if age, ok := certificationAges[strings.ToUpper(country)][certification]; ok {
+		return age, true
+	}
+
+	if parts := ageCertification.FindStringSubmatch(certification); parts != nil {
+		age, err := strconv.ParseInt(parts[1], 10, 16)
+		if err == nil && age <= 21 {
+			return int16(age), true
+		}
+	}
+
+	return 0, false
+}
+
+// LocalRelease returns the movie's release in country, theatrical releases first
+// and then the earliest, or nil when it has none there
+func (m *Movies) LocalRelease(country string) *MovieReleases {
+	var local *MovieReleases
+	for i := range m.Releases {
+		release := &m.Releases[i]
+		if !strings.EqualFold(release.Country, country) {
+			continue
+		}
+
+		if local == nil ||
+			release.Type == ReleaseTypeTheatrical && local.Type != ReleaseTypeTheatrical ||
+			release.Type == local.Type && release.ReleaseDate.Before(local.ReleaseDate) {
+			local = release
+		}
+	}
+	return local
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Movies struct {
 	ID              int64 `gorm:"primary_key"`
@@ -10,10 +14,142 @@ type Movies struct {
 	DurationMinutes int16
 	PosterURL       string
 	TrailerURL      string
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	// TrailerProvider and TrailerVideoID are derived from TrailerURL when it is a recognized video link
+	TrailerProvider *string
+	TrailerVideoID  *string
+	Version         int64 `gorm:"default:1"`
+	// Status is where the movie is in the editorial workflow, only published movies are public
+	Status MovieStatus `gorm:"default:draft"`
+	// PublishAt is when a scheduled movie goes live, ScheduledBy who scheduled it
+	PublishAt   *time.Time
+	ScheduledBy *string
+	PublishedAt *time.Time
+	PublishedBy *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 
 	// Relations
-	MovieGenres []MovieGenres `gorm:"foreignKey:MovieID"`
-	Genres      []Genres      `gorm:"many2many:movie_genres;joinForeignKey:MovieID;joinReferences:GenreID"`
+	MovieGenres  []TitleGenres       `gorm:"polymorphic:Title;polymorphicValue:movie"`
+	ExternalIDs  []MovieExternalIDs  `gorm:"foreignKey:MovieID"`
+	Images       []MovieImages       `gorm:"foreignKey:MovieID"`
+	Translations []MovieTranslations `gorm:"foreignKey:MovieID"`
+	Releases     []MovieReleases     `gorm:"foreignKey:MovieID"`
+	Collection   *CollectionMovies   `gorm:"foreignKey:MovieID"`
+}
+
+// MovieStatus is a stage of the editorial workflow
+type MovieStatus string
+
+const (
+	MovieStatusDraft     MovieStatus = "draft"
+	MovieStatusInReview  MovieStatus = "in_review"
+	MovieStatusScheduled MovieStatus = "scheduled"
+	MovieStatusPublished MovieStatus = "published"
+)
+
+func (s MovieStatus) IsValid() bool {
+	switch s {
+	case MovieStatusDraft, MovieStatusInReview, MovieStatusScheduled, MovieStatusPublished:
+		return true
+	}
+	return false
+}
+
+// CanMoveTo reports whether the workflow allows going from s to next. Drafts are
+// sent to review, reviewed movies are published, scheduled or sent back, and
+// published movies can only be taken back to draft. A schedule can be moved.
+func (s MovieStatus) CanMoveTo(next MovieStatus) bool {
+	switch s {
+	case MovieStatusDraft:
+		return next == MovieStatusInReview
+	case MovieStatusInReview:
+		return next == MovieStatusDraft || next == MovieStatusScheduled || next == MovieStatusPublished
+	case MovieStatusScheduled:
+		return next.IsValid()
+	case MovieStatusPublished:
+		return next == MovieStatusDraft
+	}
+	return false
+}
+
+// NeedsAdmin reports whether going from s to next is an editorial decision,
+// anyone may only send a draft to review
+func (s MovieStatus) NeedsAdmin(next MovieStatus) bool {
+	return s != MovieStatusDraft || next != MovieStatusInReview
+}
+
+// Publish marks the movie published at now by userID, nil when nobody is known
+func (m *Movies) Publish(now time.Time, userID *string) {
+	m.Status = MovieStatusPublished
+	m.PublishAt = nil
+	m.ScheduledBy = nil
+	m.PublishedAt = &now
+	m.PublishedBy = userID
+}
+
+// MoviePatch is a partial update of a movie, nil fields are left untouched
+type MoviePatch struct {
+	Title           *string
+	Release         *time.Time
+	Plot            *string
+	ClearPlot       bool
+	DurationMinutes *int16
+	PosterURL       *string
+	TrailerURL      *string
+	GenreIDs        *[]int64
+}
+
+// Apply copies the set fields onto the movie and returns the columns it changed
+// and whether the genre associations have to be replaced
+func (p MoviePatch) Apply(m *Movies) (columns []string, replaceGenres bool) {
+	if p.Title != nil {
+		m.Title = *p.Title
+		columns = append(columns, "title")
+	}
+
+	if p.Release != nil {
+		m.Release = *p.Release
+		columns = append(columns, "release")
+	}
+
+	if p.Plot != nil || p.ClearPlot {
+		m.Plot = p.Plot
+		columns = append(columns, "plot")
+	}
+
+	if p.DurationMinutes != nil {
+		m.DurationMinutes = *p.DurationMinutes
+		columns = append(columns, "duration_minutes")
+	}
+
+	if p.PosterURL != nil {
+		m.PosterURL = *p.PosterURL
+		columns = append(columns, "poster_url")
+	}
+
+	if p.TrailerURL != nil {
+		m.TrailerURL = *p.TrailerURL
+		columns = append(columns, "trailer_url", "trailer_provider", "trailer_video_id")
+	}
+
+	if p.GenreIDs != nil {
+		m.MovieGenres = make([]TitleGenres, 0, len(*p.GenreIDs))
+		for _, genreID := range *p.GenreIDs {
+			m.MovieGenres = append(m.MovieGenres, TitleGenres{
+				TitleType: TitleTypeMovie,
+				TitleID:   m.ID,
+				GenreID:   genreID,
+			})
+		}
+		replaceGenres = true
+	}
+
+	return columns, replaceGenres
+}
+
+// SimilarMovie is a movie related to another one, Score in [0, 1] says how closely
+type SimilarMovie struct {
+	Movie *Movies
+	Score float64
 }
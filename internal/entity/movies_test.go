@@ -0,0 +1,48 @@
+package entity
+
+import "testing"
+
+func TestMovieStatusCanMoveTo(t *testing.T) {
+	tests := []struct {
+		from MovieStatus
+		to   MovieStatus
+		want bool
+	}{
+		{MovieStatusDraft, MovieStatusInReview, true},
+		{MovieStatusDraft, MovieStatusScheduled, false},
+		{MovieStatusDraft, MovieStatusPublished, false},
+		{MovieStatusDraft, MovieStatusDraft, false},
+		{MovieStatusInReview, MovieStatusDraft, true},
+		{MovieStatusInReview, MovieStatusScheduled, true},
+		{MovieStatusInReview, MovieStatusPublished, true},
+		{MovieStatusInReview, MovieStatusInReview, false},
+		{MovieStatusScheduled, MovieStatusDraft, true},
+		{MovieStatusScheduled, MovieStatusInReview, true},
+		{MovieStatusScheduled, MovieStatusScheduled, true},
+		{MovieStatusScheduled, MovieStatusPublished, true},
+		{MovieStatusScheduled, "archived", false},
+		{MovieStatusPublished, MovieStatusDraft, true},
+		{MovieStatusPublished, MovieStatusInReview, false},
+		{MovieStatusPublished, MovieStatusScheduled, false},
+		{"archived", MovieStatusDraft, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanMoveTo(tt.to); got != tt.want {
+			t.Errorf("%s.CanMoveTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestMovieStatusNeedsAdmin(t *testing.T) {
+	statuses := []MovieStatus{MovieStatusDraft, MovieStatusInReview, MovieStatusScheduled, MovieStatusPublished}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := !(from == MovieStatusDraft && to == MovieStatusInReview)
+			if got := from.NeedsAdmin(to); got != want {
+				t.Errorf("%s.NeedsAdmin(%s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// Profiles are the viewing profiles of an account. A profile with a maximum
+// certification only sees movies rated at most that in its country.
+type Profiles struct {
+	ID                   string
+	UserID               string
+	Name                 string
+	MaxCertification     *string
+	CertificationCountry *string
+	MaxAge               *int16
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (p *Profiles) IsRestricted() bool {
+	return p != nil && p.MaxAge != nil
+}
+
+// AgeLimit returns the movie filter of the profile, nil when it is unrestricted
+func (p *Profiles) AgeLimit() *AgeLimit {
+	if !p.IsRestricted() || p.CertificationCountry == nil {
+		return nil
+	}
+	return &AgeLimit{Country: *p.CertificationCountry, MaxAge: *p.MaxAge}
+}
+
+// Covers reports whether p may see everything other may. A nil profile is the
+// account itself and covers every profile.
+func (p *Profiles) Covers(other *Profiles) bool {
+	if !p.IsRestricted() {
+		return true
+	}
+	if !other.IsRestricted() {
+		return false
+	}
+	return *p.MaxAge >= *other.MaxAge
+}
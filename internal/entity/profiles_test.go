@@ -0,0 +1,50 @@
+package entity
+
+import "testing"
+
+func restricted(maxAge int16) *Profiles {
+	country := "US"
+	return &Profiles{MaxAge: &maxAge, CertificationCountry: &country}
+}
+
+func TestProfilesCovers(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     *Profiles
+		other *Profiles
+		want  bool
+	}{
+		{"account covers account", nil, nil, true},
+		{"account covers restricted", nil, restricted(12), true},
+		{"unrestricted covers restricted", &Profiles{}, restricted(12), true},
+		{"restricted does not cover account", restricted(18), nil, false},
+		{"restricted does not cover unrestricted", restricted(18), &Profiles{}, false},
+		{"older covers younger", restricted(16), restricted(12), true},
+		{"same age covers", restricted(12), restricted(12), true},
+		{"younger does not cover older", restricted(12), restricted(16), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Covers(tt.other); got != tt.want {
+				t.Errorf("Covers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfilesAgeLimit(t *testing.T) {
+	if limit := (*Profiles)(nil).AgeLimit(); limit != nil {
+		t.Errorf("account AgeLimit() = %+v, want nil", limit)
+	}
+
+	maxAge := int16(12)
+	if limit := (&Profiles{MaxAge: &maxAge}).AgeLimit(); limit != nil {
+		t.Errorf("AgeLimit() without a country = %+v, want nil", limit)
+	}
+
+	limit := restricted(12).AgeLimit()
+	if limit == nil || limit.Country != "US" || limit.MaxAge != 12 {
+		t.Errorf("AgeLimit() = %+v, want US/12", limit)
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// MovieRatings are the scores, 1 to 10, users give to movies
+type MovieRatings struct {
+	UserID    string `gorm:"primary_key"`
+	MovieID   int64  `gorm:"primary_key"`
+	Score     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MovieSimilarities are how close SimilarMovieID is to MovieID, in [0, 1]. They
+// blend how often both are liked by the same users with the genres they share.
+type MovieSimilarities struct {
+	MovieID        int64 `gorm:"primary_key"`
+	SimilarMovieID int64 `gorm:"primary_key"`
+	Score          float64
+	ComputedAt     time.Time
+}
+
+// SimilarityWeights tune how movie similarities are computed
+type SimilarityWeights struct {
+	// CoLiked and Genres weigh the cosine similarity of the users who liked both
+	// movies and the Jaccard index of their genres, they should add up to 1
+	CoLiked float64
+	Genres  float64
+	// Neighbours is how many similar movies are kept per movie
+	Neighbours int
+}
+
+// Recommendation is a movie picked for a user. BecauseMovieID is the liked movie
+// that contributed most to it, nil when it was picked for its popularity.
+type Recommendation struct {
+	MovieID        int64
+	Score          float64
+	BecauseMovieID *int64
+
+	Movie   *Movies `gorm:"-"`
+	Because *Movies `gorm:"-"`
+}
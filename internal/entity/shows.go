@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// Shows are TV series, made of numbered seasons of numbered episodes
+type Shows struct {
+	ID           int64 `gorm:"primary_key"`
+	Title        string
+	Plot         *string
+	PosterURL    string
+	FirstAirDate *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	// Relations
+	ShowGenres []TitleGenres `gorm:"polymorphic:Title;polymorphicValue:show"`
+	Seasons    []Seasons     `gorm:"foreignKey:ShowID"`
+}
+
+type Seasons struct {
+	ID           int64 `gorm:"primary_key"`
+	ShowID       int64
+	SeasonNumber int
+	Title        *string
+	Plot         *string
+	AirDate      *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	// Relations
+	Episodes []Episodes `gorm:"foreignKey:SeasonID"`
+	Show     *Shows     `gorm:"foreignKey:ShowID"`
+}
+
+type Episodes struct {
+	ID             int64 `gorm:"primary_key"`
+	SeasonID       int64
+	EpisodeNumber  int
+	Title          string
+	Plot           *string
+	AirDate        *time.Time
+	RuntimeMinutes *int16
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// Relations
+	Season *Seasons `gorm:"foreignKey:SeasonID"`
+}
+
+// TitleSearchResult is a movie or a show matched by the unified search
+type TitleSearchResult struct {
+	Type      TitleType
+	ID        int64
+	Title     string
+	Released  *time.Time
+	PosterURL string
+}
+
+// TitleSearch is a query against movie and show titles
+type TitleSearch struct {
+	Query string
+	// Types limits the search to some title types, empty means all
+	Types []TitleType
+	// AgeLimit keeps only the movies a restricted profile may see
+	AgeLimit *AgeLimit
+}
+
+func (s TitleSearch) Includes(titleType TitleType) bool {
+	return len(s.Types) == 0 || slices.Contains(s.Types, titleType)
+}
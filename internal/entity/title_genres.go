@@ -0,0 +1,26 @@
+package entity
+
+// TitleType tells which table a title id refers to in relations shared by movies and shows
+type TitleType string
+
+const (
+	TitleTypeMovie TitleType = "movie"
+	TitleTypeShow  TitleType = "show"
+)
+
+// TitleGenres links a movie or a show to a genre
+type TitleGenres struct {
+	TitleType TitleType `gorm:"column:title_type;primary_key"`
+	TitleID   int64     `gorm:"column:title_id;primary_key"`
+	GenreID   int64     `gorm:"column:genre_id;primary_key"`
+
+	Genre *Genres `gorm:"foreignKey:ID;references:GenreID"`
+}
+
+func (t TitleType) IsValid() bool {
+	switch t {
+	case TitleTypeMovie, TitleTypeShow:
+		return true
+	}
+	return false
+}
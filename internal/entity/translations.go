@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/AsaHero/movie-app-server/pkg/locale"
+)
+
+// MovieTranslations holds the localized title, plot and tagline of a movie, at most one per locale
+type MovieTranslations struct {
+	ID        int64 `gorm:"primary_key"`
+	MovieID   int64
+	Locale    string
+	Title     string
+	Plot      *string
+	Tagline   *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GenreTranslations holds the localized name of a genre, at most one per locale
+type GenreTranslations struct {
+	ID        int64 `gorm:"primary_key"`
+	GenreID   int64
+	Locale    string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Translation returns the movie translation best matching the preferred locales, nil
+// when none matches or when defaultLocale (the language of Title and Plot) wins first.
+func (m *Movies) Translation(preferred []string, defaultLocale string) *MovieTranslations {
+	available := make([]string, 0, len(m.Translations)+1)
+	for _, translation := range m.Translations {
+		available = append(available, translation.Locale)
+	}
+
+	match, ok := locale.Match(preferred, append(available, defaultLocale))
+	if !ok {
+		return nil
+	}
+
+	for i := range m.Translations {
+		if m.Translations[i].Locale == match {
+			return &m.Translations[i]
+		}
+	}
+
+	return nil
+}
+
+// Translation returns the genre translation best matching the preferred locales,
+// following the same rules as Movies.Translation.
+func (g *Genres) Translation(preferred []string, defaultLocale string) *GenreTranslations {
+	available := make([]string, 0, len(g.Translations)+1)
+	for _, translation := range g.Translations {
+		available = append(available, translation.Locale)
+	}
+
+	match, ok := locale.Match(preferred, append(available, defaultLocale))
+	if !ok {
+		return nil
+	}
+
+	for i := range g.Translations {
+		if g.Translations[i].Locale == match {
+			return &g.Translations[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+type ListVisibility string
+
+const (
+	// ListVisibilityPublic lists are browsable and open to anyone
+	ListVisibilityPublic ListVisibility = "public"
+	// ListVisibilityUnlisted lists are open to anyone with the link but not browsable
+	ListVisibilityUnlisted ListVisibility = "unlisted"
+	// ListVisibilityPrivate lists are only open to their owner
+	ListVisibilityPrivate ListVisibility = "private"
+)
+
+func (v ListVisibility) IsValid() bool {
+	switch v {
+	case ListVisibilityPublic, ListVisibilityUnlisted, ListVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+type ListOrder string
+
+const (
+	ListOrderPopular ListOrder = "popular"
+	ListOrderRecent  ListOrder = "recent"
+)
+
+// UserLists are named, ordered movie lists curated by users
+type UserLists struct {
+	ID          int64 `gorm:"primary_key"`
+	UserID      string
+	Name        string
+	Slug        string
+	Description *string
+	Visibility  ListVisibility
+	// FollowersCount is kept in step with user_list_follows and ranks lists by popularity
+	FollowersCount int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// Relations
+	User *Users `gorm:"foreignKey:ID;references:UserID"`
+}
+
+// OpenTo reports whether userID may read the list
+func (l *UserLists) OpenTo(userID string) bool {
+	return l.Visibility != ListVisibilityPrivate || l.UserID == userID
+}
+
+// UserListItems are the movies of a list, ordered by position
+type UserListItems struct {
+	ListID    int64 `gorm:"primary_key"`
+	MovieID   int64 `gorm:"primary_key"`
+	Position  int
+	Note      *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Movie *Movies `gorm:"foreignKey:ID;references:MovieID"`
+}
+
+type UserListFollows struct {
+	ListID    int64  `gorm:"primary_key"`
+	UserID    string `gorm:"primary_key"`
+	CreatedAt time.Time
+}
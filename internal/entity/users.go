@@ -24,15 +24,21 @@ type Users struct {
 	Username     string
 	Role         UserRole
 	PasswordHash string `gorm:"column:password"`
-	Status       UserStatus
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// PINHash protects profile switching and management, nil when no PIN is set
+	PINHash   *string `gorm:"column:pin_hash"`
+	Status    UserStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 func (u *Users) IsActive() bool {
 	return u.Status == UserStatusActive
 }
 
+func (u *Users) HasPIN() bool {
+	return u.PINHash != nil
+}
+
 func (u *Users) IsAdmin() bool {
 	return u.Role == UserRoleAdmin
 }
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// WatchableType is the kind of video a playback position is kept for
+type WatchableType string
+
+const (
+	WatchableMovie   WatchableType = "movie"
+	WatchableEpisode WatchableType = "episode"
+)
+
+func (t WatchableType) IsValid() bool {
+	switch t {
+	case WatchableMovie, WatchableEpisode:
+		return true
+	}
+	return false
+}
+
+// WatchProgress is how far a user got into a movie or an episode. Completed is
+// true while the position is past the end credits; WatchedAt remembers the last
+// time it was watched through and is kept when it is started over.
+type WatchProgress struct {
+	UserID          string        `gorm:"primary_key"`
+	WatchableType   WatchableType `gorm:"primary_key"`
+	WatchableID     int64         `gorm:"primary_key"`
+	PositionSeconds int
+	Completed       bool
+	WatchedAt       *time.Time
+	UpdatedAt       time.Time
+
+	// Movie or Episode is loaded by the service, depending on WatchableType
+	Movie   *Movies   `gorm:"-"`
+	Episode *Episodes `gorm:"-"`
+}
+
+func (WatchProgress) TableName() string {
+	return "watch_progress"
+}
+
+// CompletedAt reports whether positionSeconds is past percent of a video
+// lasting durationSeconds, videos of unknown length are never completed
+func CompletedAt(positionSeconds, durationSeconds int, percent float64) bool {
+	if durationSeconds <= 0 {
+		return false
+	}
+	return float64(positionSeconds) >= float64(durationSeconds)*percent/100
+}
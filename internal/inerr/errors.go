@@ -3,7 +3,24 @@ package inerr
 import "errors"
 
 var (
-	ErrorIncorrectPassword = errors.New("incorrect password")
+	ErrorIncorrectPassword  = errors.New("incorrect password")
+	ErrorPINRequired        = errors.New("account pin required")
+	ErrorIncorrectPIN       = errors.New("incorrect account pin")
+	ErrorTooManyPINAttempts = errors.New("too many incorrect pin attempts, try again later")
+	ErrorProfileRequired    = errors.New("select a viewing profile first, the account is protected or the selected profile no longer exists")
+	ErrorFollowOwnList      = errors.New("you cannot follow your own list")
+	ErrorListOrderMismatch  = errors.New("the new order must list every movie of the list exactly once")
+	ErrorInvalidCursor      = errors.New("invalid cursor, pass the next_cursor of a previous page")
+	ErrorReplyToOtherMovie  = errors.New("the comment replied to belongs to another movie")
+	ErrorCommentBanned      = errors.New("you are banned from commenting")
+	ErrorThreadLocked       = errors.New("this thread is locked")
+	ErrorNotCommentAuthor   = errors.New("only the author can change this comment")
+	ErrorCommentRemoved     = errors.New("this comment was removed")
+	ErrorStatusTransition   = errors.New("the movie cannot move to this status from its current one")
+	ErrorPublishAtInPast    = errors.New("publish_at must be in the future")
+	ErrorStatusNeedsAdmin   = errors.New("only admins can publish, schedule or withdraw movies")
+	ErrorMergeSameMovie     = errors.New("a movie cannot be merged into itself")
+	ErrorImportTrashedMovie = errors.New("the movie with this external id is in the trash, restore it first")
 )
 
 // error not found
@@ -42,6 +59,24 @@ func NewErrConflict(text string) *ErrConflict {
 	return &ErrConflict{text}
 }
 
+// error invalid reference, e.g. a row pointing at a record that does not exist
+type ErrInvalidReference struct {
+	name string
+}
+
+func (e *ErrInvalidReference) Error() string {
+	return e.name + " refers to a record that does not exist"
+}
+
+func IsErrInvalidReference(err error) bool {
+	_, ok := err.(*ErrInvalidReference)
+	return ok
+}
+
+func NewErrInvalidReference(text string) *ErrInvalidReference {
+	return &ErrInvalidReference{text}
+}
+
 // error no changes
 type ErrNoChanges struct {
 	name string
@@ -60,6 +95,24 @@ func NewErrNoChanges(text string) *ErrNoChanges {
 	return &ErrNoChanges{text}
 }
 
+// error precondition failed, e.g. stale version on optimistic concurrency
+type ErrPreconditionFailed struct {
+	name string
+}
+
+func (e *ErrPreconditionFailed) Error() string {
+	return e.name + " has been modified by someone else"
+}
+
+func IsErrPreconditionFailed(err error) bool {
+	_, ok := err.(*ErrPreconditionFailed)
+	return ok
+}
+
+func NewErrPreconditionFailed(text string) *ErrPreconditionFailed {
+	return &ErrPreconditionFailed{text}
+}
+
 // ErrValidation represents different types of token validation errors
 type ErrJwtValidation struct {
 	Message string
@@ -1,4 +1,4 @@
-package movie_genres
+package audit_logs
 
 import (
 	"github.com/AsaHero/movie-app-server/internal/entity"
@@ -6,5 +6,5 @@ import (
 )
 
 type Repository interface {
-	repository.BaseRepository[*entity.MovieGenres]
+	repository.BaseRepository[*entity.AuditLogs]
 }
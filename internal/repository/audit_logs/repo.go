@@ -0,0 +1,19 @@
+package audit_logs
+
+import (
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.AuditLogs]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.AuditLogs](db),
+		db:             db,
+	}
+}
@@ -0,0 +1,16 @@
+package collection_movies
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.CollectionMovies]
+	// ListMembers returns the collection's entries in order, keeping those whose movie matches filter
+	ListMembers(ctx context.Context, collectionID int64, filter repository.Filter) ([]*entity.CollectionMovies, error)
+	// Neighbours returns the closest entries before and after position whose movie matches filter
+	Neighbours(ctx context.Context, collectionID int64, position int, filter repository.Filter) (prev, next *entity.CollectionMovies, err error)
+}
@@ -0,0 +1,78 @@
+package collection_movies
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.CollectionMovies]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.CollectionMovies](db),
+		db:             db,
+	}
+}
+
+func (r *repo) ListMembers(ctx context.Context, collectionID int64, filter repository.Filter) ([]*entity.CollectionMovies, error) {
+	var members []*entity.CollectionMovies
+
+	err := r.members(ctx, collectionID, filter).
+		Order("collection_movies.position").
+		Find(&members).Error
+	if err != nil {
+		return nil, postgres.Error(err, "ListMembers", &entity.CollectionMovies{})
+	}
+
+	return members, nil
+}
+
+func (r *repo) Neighbours(ctx context.Context, collectionID int64, position int, filter repository.Filter) (*entity.CollectionMovies, *entity.CollectionMovies, error) {
+	prev, err := r.neighbour(ctx, collectionID, filter, "collection_movies.position < ?", "collection_movies.position DESC", position)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next, err := r.neighbour(ctx, collectionID, filter, "collection_movies.position > ?", "collection_movies.position", position)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return prev, next, nil
+}
+
+func (r *repo) neighbour(ctx context.Context, collectionID int64, filter repository.Filter, where, order string, position int) (*entity.CollectionMovies, error) {
+	var member entity.CollectionMovies
+
+	err := r.members(ctx, collectionID, filter).
+		Where(where, position).
+		Order(order).
+		First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, postgres.Error(err, "Neighbours", &entity.CollectionMovies{})
+	}
+
+	return &member, nil
+}
+
+// members selects the collection's entries whose movie is not trashed and matches filter
+func (r *repo) members(ctx context.Context, collectionID int64, filter repository.Filter) *gorm.DB {
+	return repository.FromContext(ctx, r.db).
+		Model(&entity.CollectionMovies{}).
+		Joins("JOIN movies ON movies.id = collection_movies.movie_id AND movies.deleted_at IS NULL").
+		Where("collection_movies.collection_id = ?", collectionID).
+		Scopes(filter.Scope).
+		Preload("Movie").
+		Preload("Movie.Translations")
+}
@@ -0,0 +1,10 @@
+package episodes
+
+import (
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.Episodes]
+}
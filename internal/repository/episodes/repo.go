@@ -0,0 +1,19 @@
+package episodes
+
+import (
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.Episodes]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.Episodes](db),
+		db:             db,
+	}
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Filter is a typed, composable WHERE condition used by BaseRepository.
+// Column names are always written as quoted identifiers and values are always
+// passed to the driver as bound parameters, so user input never ends up in the SQL text.
+// The zero value matches every row.
+type Filter struct {
+	expr clause.Expression
+	// err makes the filter invalid, queries it is applied to fail with it
+	err error
+}
+
+// Where builds a condition for column using one of the postgres operators.
+// OpBetween expects a two element slice, OpIn/OpNotIn expect a slice,
+// OpIsNull/OpIsNotNull ignore the value. An unsupported operator or a wrong
+// number of values gives an invalid filter, see Err.
+func Where(column string, op postgres.Operator, value any) Filter {
+	col := clause.Column{Name: column}
+
+	switch op {
+	case postgres.OpEquals:
+		return Filter{expr: clause.Eq{Column: col, Value: value}}
+	case postgres.OpNotEquals:
+		return Filter{expr: clause.Neq{Column: col, Value: value}}
+	case postgres.OpGreaterThan:
+		return Filter{expr: clause.Gt{Column: col, Value: value}}
+	case postgres.OpGreaterThanOrEqual:
+		return Filter{expr: clause.Gte{Column: col, Value: value}}
+	case postgres.OpLessThan:
+		return Filter{expr: clause.Lt{Column: col, Value: value}}
+	case postgres.OpLessThanOrEqual:
+		return Filter{expr: clause.Lte{Column: col, Value: value}}
+	case postgres.OpBetween:
+		values := toSlice(value)
+		if len(values) != 2 {
+			return invalid(fmt.Errorf("repository: %s expects 2 values for column %s, got %d", op, column, len(values)))
+		}
+		return Filter{expr: clause.Expr{SQL: "(? BETWEEN ? AND ?)", Vars: []any{col, values[0], values[1]}}}
+	case postgres.OpIn:
+		return Filter{expr: clause.IN{Column: col, Values: toSlice(value)}}
+	case postgres.OpNotIn:
+		return Filter{expr: notExpr{expr: clause.IN{Column: col, Values: toSlice(value)}}}
+	case postgres.OpLike:
+		return Filter{expr: clause.Like{Column: col, Value: value}}
+	case postgres.OpILike:
+		return Filter{expr: clause.Expr{SQL: "? ILIKE ?", Vars: []any{col, value}}}
+	case postgres.OpIsNull:
+		return Filter{expr: clause.Expr{SQL: "? IS NULL", Vars: []any{col}}}
+	case postgres.OpIsNotNull:
+		return Filter{expr: clause.Expr{SQL: "? IS NOT NULL", Vars: []any{col}}}
+	default:
+		return invalid(fmt.Errorf("repository: unsupported operator %q for column %s", op, column))
+	}
+}
+
+func invalid(err error) Filter {
+	return Filter{err: err}
+}
+
+func Eq(column string, value any) Filter {
+	return Where(column, postgres.OpEquals, value)
+}
+
+func Neq(column string, value any) Filter {
+	return Where(column, postgres.OpNotEquals, value)
+}
+
+func Gt(column string, value any) Filter {
+	return Where(column, postgres.OpGreaterThan, value)
+}
+
+func Gte(column string, value any) Filter {
+	return Where(column, postgres.OpGreaterThanOrEqual, value)
+}
+
+func Lt(column string, value any) Filter {
+	return Where(column, postgres.OpLessThan, value)
+}
+
+func Lte(column string, value any) Filter {
+	return Where(column, postgres.OpLessThanOrEqual, value)
+}
+
+func Between(column string, from, to any) Filter {
+	return Where(column, postgres.OpBetween, []any{from, to})
+}
+
+// In accepts either a slice or a list of values
+func In(column string, values ...any) Filter {
+	if len(values) == 1 {
+		return Where(column, postgres.OpIn, values[0])
+	}
+	return Where(column, postgres.OpIn, values)
+}
+
+// NotIn accepts either a slice or a list of values
+func NotIn(column string, values ...any) Filter {
+	if len(values) == 1 {
+		return Where(column, postgres.OpNotIn, values[0])
+	}
+	return Where(column, postgres.OpNotIn, values)
+}
+
+func Like(column string, pattern string) Filter {
+	return Where(column, postgres.OpLike, pattern)
+}
+
+func ILike(column string, pattern string) Filter {
+	return Where(column, postgres.OpILike, pattern)
+}
+
+func IsNull(column string) Filter {
+	return Where(column, postgres.OpIsNull, nil)
+}
+
+func IsNotNull(column string) Filter {
+	return Where(column, postgres.OpIsNotNull, nil)
+}
+
+// Expr wraps a raw SQL fragment for conditions the typed helpers can't express,
+// e.g. subqueries. Values must be passed as vars via "?" placeholders, never concatenated.
+func Expr(sql string, vars ...any) Filter {
+	return Filter{expr: clause.Expr{SQL: sql, Vars: vars}}
+}
+
+// TimeRange converts the legacy postgres.TimeCondition into a filter
+func TimeRange(column string, cond postgres.TimeCondition) Filter {
+	filters := make([]Filter, 0, len(cond))
+	for op, value := range cond {
+		filters = append(filters, Where(column, op, value))
+	}
+	return And(filters...)
+}
+
+// And matches rows satisfying every filter. Empty filters are skipped.
+func And(filters ...Filter) Filter {
+	return join(" AND ", filters)
+}
+
+// Or matches rows satisfying at least one filter. Empty filters are skipped.
+func Or(filters ...Filter) Filter {
+	return join(" OR ", filters)
+}
+
+// Not negates the filter. Negating an empty filter is still an empty filter.
+func Not(filter Filter) Filter {
+	if filter.IsEmpty() || filter.err != nil {
+		return filter
+	}
+	return Filter{expr: notExpr{expr: filter.expr}}
+}
+
+// And is a chaining shortcut for repository.And(f, filters...)
+func (f Filter) And(filters ...Filter) Filter {
+	return And(append([]Filter{f}, filters...)...)
+}
+
+// Or is a chaining shortcut for repository.Or(f, filters...)
+func (f Filter) Or(filters ...Filter) Filter {
+	return Or(append([]Filter{f}, filters...)...)
+}
+
+// IsEmpty reports whether the filter matches every row, an invalid filter is never empty
+func (f Filter) IsEmpty() bool {
+	return f.expr == nil && f.err == nil
+}
+
+// Err returns why the filter is invalid, nil for a valid one
+func (f Filter) Err() error {
+	return f.err
+}
+
+// Expression exposes the underlying clause for custom repository queries
+func (f Filter) Expression() clause.Expression {
+	return f.expr
+}
+
+// Scope applies the filter to a gorm query, an invalid filter fails the query with its error
+func (f Filter) Scope(db *gorm.DB) *gorm.DB {
+	if f.err != nil {
+		db.AddError(f.err)
+		return db
+	}
+	if f.IsEmpty() {
+		return db
+	}
+	return db.Where(f.expr)
+}
+
+func join(sep string, filters []Filter) Filter {
+	exprs := make([]clause.Expression, 0, len(filters))
+	for _, filter := range filters {
+		if filter.err != nil {
+			return filter
+		}
+		if !filter.IsEmpty() {
+			exprs = append(exprs, filter.expr)
+		}
+	}
+
+	switch len(exprs) {
+	case 0:
+		return Filter{}
+	case 1:
+		return Filter{expr: exprs[0]}
+	default:
+		return Filter{expr: groupExpr{sep: sep, exprs: exprs}}
+	}
+}
+
+// groupExpr always wraps its members in parentheses, unlike clause.AndConditions/OrConditions
+// which try to be clever about it and get nesting wrong in some cases.
+type groupExpr struct {
+	sep   string
+	exprs []clause.Expression
+}
+
+func (g groupExpr) Build(builder clause.Builder) {
+	builder.WriteByte('(')
+	for i, expr := range g.exprs {
+		if i > 0 {
+			builder.WriteString(g.sep)
+		}
+		expr.Build(builder)
+	}
+	builder.WriteByte(')')
+}
+
+type notExpr struct {
+	expr clause.Expression
+}
+
+func (n notExpr) Build(builder clause.Builder) {
+	builder.WriteString("NOT (")
+	n.expr.Build(builder)
+	builder.WriteByte(')')
+}
+
+func toSlice(value any) []any {
+	if values, ok := value.([]any); ok {
+		return values
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []any{value}
+	}
+
+	values := make([]any, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values
+}
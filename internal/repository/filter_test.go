@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type filterRow struct {
+	ID int64
+}
+
+// dryRun renders the query filter builds without a database and returns its SQL and bound values
+func dryRun(t *testing.T, filter Filter) (string, []any, error) {
+	t.Helper()
+
+	db, err := gorm.Open(gormpostgres.New(gormpostgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []filterRow
+	result := db.Table("rows").Scopes(filter.Scope).Find(&rows)
+	return result.Statement.SQL.String(), result.Statement.Vars, result.Error
+}
+
+// hostile is a value that would break out of a quoted literal if it reached the SQL text
+const hostile = "x' OR '1'='1"
+
+func TestFilterParameterizesValues(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		filter       Filter
+		placeholders int
+		vars         []any
+	}{
+		{"equals", Eq("title", hostile), 1, []any{hostile}},
+		{"not equals", Neq("title", hostile), 1, []any{hostile}},
+		{"greater than", Gt("release", now), 1, []any{now}},
+		{"greater than or equal", Gte("release", now), 1, []any{now}},
+		{"less than", Lt("duration", 90), 1, []any{90}},
+		{"less than or equal", Lte("duration", 90), 1, []any{90}},
+		{"between", Between("duration", 60, hostile), 2, []any{60, hostile}},
+		{"in", In("title", hostile, "b"), 2, []any{hostile, "b"}},
+		{"in slice", In("id", []int64{1, 2, 3}), 3, []any{int64(1), int64(2), int64(3)}},
+		{"not in", NotIn("title", []string{hostile}), 1, []any{hostile}},
+		{"like", Like("title", hostile), 1, []any{hostile}},
+		{"ilike", ILike("title", hostile), 1, []any{hostile}},
+		{"is null", IsNull("plot"), 0, []any{}},
+		{"is not null", IsNotNull("plot"), 0, []any{}},
+		{"expr", Expr("EXISTS (SELECT 1 FROM genres WHERE genres.name = ?)", hostile), 1, []any{hostile}},
+		{"time range", TimeRange("release", postgres.TimeCondition{postgres.OpGreaterThan: now}), 1, []any{now}},
+		{
+			"nested and or not",
+			And(
+				Eq("title", hostile),
+				Or(ILike("title", hostile), Not(In("id", []int64{7, 8}))),
+				Not(Or(Lt("duration", 30), Gt("duration", 300))),
+			),
+			6,
+			[]any{hostile, hostile, int64(7), int64(8), 30, 300},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, vars, err := dryRun(t, tt.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if strings.Contains(sql, hostile) || strings.Contains(sql, "2024-01-01") {
+				t.Errorf("value rendered into the SQL text: %s", sql)
+			}
+
+			if got := strings.Count(sql, "$"); got != tt.placeholders {
+				t.Errorf("got %d placeholders, want %d: %s", got, tt.placeholders, sql)
+			}
+
+			if !reflect.DeepEqual(vars, tt.vars) {
+				t.Errorf("got vars %#v, want %#v", vars, tt.vars)
+			}
+		})
+	}
+}
+
+func TestFilterEmptyMatchesEverything(t *testing.T) {
+	sql, vars, err := dryRun(t, And(Filter{}, Or()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(sql, "WHERE") || len(vars) != 0 {
+		t.Errorf("empty filter added a condition: %s %v", sql, vars)
+	}
+}
+
+func TestFilterInvalidFailsTheQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+	}{
+		{"unsupported operator", Where("title", postgres.Operator("; DROP TABLE movies"), hostile)},
+		{"between with one value", Where("duration", postgres.OpBetween, []any{60})},
+		{"nested in and", And(Eq("title", "a"), Or(Where("title", "~", "a")))},
+		{"negated", Not(Where("title", "~", "a"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.filter.Err() == nil || tt.filter.IsEmpty() {
+				t.Fatal("expected an invalid filter")
+			}
+
+			if _, _, err := dryRun(t, tt.filter); err == nil {
+				t.Error("expected the query to fail")
+			}
+		})
+	}
+}
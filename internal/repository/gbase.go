@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"time"
 
 	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
 	"gorm.io/gorm"
@@ -24,14 +23,14 @@ func FromContext(ctx context.Context, defaultDB *gorm.DB) *gorm.DB {
 // BaseRepository interface now includes generic type T which must satisfy Entity interface
 type BaseRepository[T any] interface {
 	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
-	FindAll(ctx context.Context, limit, page uint64, orderBy string, filter map[string]any, preloads ...string) (uint64, []T, error)
-	FindOne(ctx context.Context, filter map[string]any, preloads ...string) (T, error)
+	FindAll(ctx context.Context, limit, page uint64, orderBy string, filter Filter, preloads ...string) (uint64, []T, error)
+	FindOne(ctx context.Context, filter Filter, preloads ...string) (T, error)
 	Create(ctx context.Context, e T) error
 	Update(ctx context.Context, e T) error
-	UpdateDataWhere(ctx context.Context, data map[string]any, filter map[string]any) error
-	Upsert(ctx context.Context, columns []string, e T) error
+	UpdateDataWhere(ctx context.Context, data map[string]any, filter Filter) error
+	Upsert(ctx context.Context, columns []string, e T, conflictColumns ...string) error
 	BatchCreate(ctx context.Context, entities []T) error
-	Delete(ctx context.Context, filter map[string]any) error
+	Delete(ctx context.Context, filter Filter) error
 }
 
 type baseRepository[T any] struct {
@@ -45,13 +44,14 @@ func NewBaseRepository[T any](db *gorm.DB) BaseRepository[T] {
 }
 
 func (r *baseRepository[T]) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	// Nested calls join the outer transaction through a savepoint
+	return FromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		ctx := context.WithValue(ctx, CtxGormKey, tx)
 		return fn(ctx)
 	})
 }
 
-func (r *baseRepository[T]) FindAll(ctx context.Context, limit, page uint64, orderBy string, filter map[string]any, preloads ...string) (uint64, []T, error) {
+func (r *baseRepository[T]) FindAll(ctx context.Context, limit, page uint64, orderBy string, filter Filter, preloads ...string) (uint64, []T, error) {
 	var model T
 	var results []T
 	db := FromContext(ctx, r.db)
@@ -72,57 +72,22 @@ func (r *baseRepository[T]) FindAll(ctx context.Context, limit, page uint64, ord
 	}
 
 	// Apply filtering, pagination, and find operation
-	for key, value := range filter {
-		switch v := value.(type) {
-		case []time.Time: // Handle date range
-			if len(v) == 2 {
-				db = db.Where(key+" BETWEEN ? AND ?", v[0], v[1])
-			}
-		case time.Time: // Handle exact date
-			db = db.Where(key+" = ?", v)
-		case postgres.TimeCondition: // Handle complex date conditions
-			for cond, val := range v {
-				db = db.Where(key+" "+string(cond)+" ?", val)
-			}
-		default: // Handle all other data types
-			db = db.Where(key, value)
-		}
-	}
-
-	result := db.Find(&results)
+	result := db.Scopes(filter.Scope).Find(&results)
 	if result.Error != nil {
 		return 0, nil, postgres.Error(result.Error, "FindAll", &model)
 	}
 
-	// Clone the DB session for count to avoid reusing modified `db`
-	countDB := FromContext(ctx, r.db)
-
-	// Reapply filtering for count
-	for key, value := range filter {
-		switch v := value.(type) {
-		case []time.Time:
-			if len(v) == 2 {
-				countDB = countDB.Where(key+" BETWEEN ? AND ?", v[0], v[1])
-			}
-		case time.Time:
-			countDB = countDB.Where(key+" = ?", v)
-		case postgres.TimeCondition:
-			for cond, val := range v {
-				countDB = countDB.Where(key+" "+string(cond)+" ?", val)
-			}
-		default:
-			countDB = countDB.Where(key, value)
-		}
-	}
-
-	// Count total records matching the filter
+	// Count total records matching the filter on a fresh session
 	var total int64
-	countDB.Model(&model).Count(&total)
+	countDB := FromContext(ctx, r.db).Model(&model).Scopes(filter.Scope)
+	if err := countDB.Count(&total).Error; err != nil {
+		return 0, nil, postgres.Error(err, "FindAll", &model)
+	}
 
 	return uint64(total), results, nil
 }
 
-func (r *baseRepository[T]) FindOne(ctx context.Context, filter map[string]any, preloads ...string) (T, error) {
+func (r *baseRepository[T]) FindOne(ctx context.Context, filter Filter, preloads ...string) (T, error) {
 	var result T
 	db := FromContext(ctx, r.db)
 
@@ -132,7 +97,7 @@ func (r *baseRepository[T]) FindOne(ctx context.Context, filter map[string]any,
 	}
 
 	// Apply filtering
-	if err := db.Where(filter).First(&result).Error; err != nil {
+	if err := db.Scopes(filter.Scope).First(&result).Error; err != nil {
 		return result, postgres.Error(err, "FindOne", &result)
 	}
 	return result, nil
@@ -158,12 +123,12 @@ func (r *baseRepository[T]) Update(ctx context.Context, e T) error {
 	return nil
 }
 
-func (r *baseRepository[T]) UpdateDataWhere(ctx context.Context, data map[string]any, filter map[string]any) error {
+func (r *baseRepository[T]) UpdateDataWhere(ctx context.Context, data map[string]any, filter Filter) error {
 	db := FromContext(ctx, r.db)
 
 	var model *T
 
-	err := db.Model(model).Where(filter).Updates(data).Error
+	err := db.Model(model).Scopes(filter.Scope).Updates(data).Error
 	if err != nil {
 		return postgres.Error(err, "UpdateDataWhere", model)
 	}
@@ -171,11 +136,22 @@ func (r *baseRepository[T]) UpdateDataWhere(ctx context.Context, data map[string
 	return nil
 }
 
-func (r *baseRepository[T]) Upsert(ctx context.Context, columns []string, e T) error {
+// Upsert inserts e or updates the given columns when a row with the same conflict
+// columns already exists. Conflict columns default to the primary key "id".
+func (r *baseRepository[T]) Upsert(ctx context.Context, columns []string, e T, conflictColumns ...string) error {
 	query := FromContext(ctx, r.db)
 
+	if len(conflictColumns) == 0 {
+		conflictColumns = []string{"id"}
+	}
+
+	conflict := make([]clause.Column, 0, len(conflictColumns))
+	for _, column := range conflictColumns {
+		conflict = append(conflict, clause.Column{Name: column})
+	}
+
 	if err := query.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "id"}},
+		Columns:   conflict,
 		DoUpdates: clause.AssignmentColumns(columns),
 	}).Create(e).Error; err != nil {
 		return postgres.Error(err, "Upsert", e)
@@ -200,11 +176,11 @@ func (r *baseRepository[T]) BatchCreate(ctx context.Context, entities []T) error
 }
 
 // Then implement the Delete method in the baseRepository struct
-func (r *baseRepository[T]) Delete(ctx context.Context, filter map[string]any) error {
+func (r *baseRepository[T]) Delete(ctx context.Context, filter Filter) error {
 	db := FromContext(ctx, r.db)
 	var model T
 
-	result := db.Where(filter).Delete(&model)
+	result := db.Scopes(filter.Scope).Delete(&model)
 	if result.Error != nil {
 		return postgres.Error(result.Error, "Delete", &model)
 	}
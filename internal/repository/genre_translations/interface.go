@@ -0,0 +1,10 @@
+package genre_translations
+
+import (
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.GenreTranslations]
+}
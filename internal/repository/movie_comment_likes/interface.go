@@ -0,0 +1,14 @@
+package movie_comment_likes
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.MovieCommentLikes]
+	// Liked returns which of commentIDs userID liked
+	Liked(ctx context.Context, userID string, commentIDs []int64) ([]int64, error)
+}
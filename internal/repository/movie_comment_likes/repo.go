@@ -0,0 +1,40 @@
+package movie_comment_likes
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.MovieCommentLikes]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.MovieCommentLikes](db),
+		db:             db,
+	}
+}
+
+func (r *repo) Liked(ctx context.Context, userID string, commentIDs []int64) ([]int64, error) {
+	if len(commentIDs) == 0 {
+		return nil, nil
+	}
+
+	db := repository.FromContext(ctx, r.db)
+
+	var liked []int64
+	err := db.Model(&entity.MovieCommentLikes{}).
+		Where("user_id = ? AND comment_id IN (?)", userID, commentIDs).
+		Pluck("comment_id", &liked).Error
+	if err != nil {
+		return nil, postgres.Error(err, "Liked", &entity.MovieCommentLikes{})
+	}
+
+	return liked, nil
+}
@@ -0,0 +1,10 @@
+package movie_comment_reports
+
+import (
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.MovieCommentReports]
+}
@@ -0,0 +1,32 @@
+package movie_comments
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.MovieComments]
+	// Threads returns up to limit top-level comments of a movie created before
+	// after, newest first. Removed comments are left out unless they have replies.
+	Threads(ctx context.Context, movieID int64, after *entity.CommentCursor, limit int) ([]*entity.MovieComments, error)
+	// Replies returns up to limit replies of a thread created after after, oldest
+	// first. Removed replies are left out unless they were answered.
+	Replies(ctx context.Context, rootID int64, after *entity.CommentCursor, limit int) ([]*entity.MovieComments, error)
+	// FirstReplies returns the perThread oldest replies of each thread, as Replies would
+	FirstReplies(ctx context.Context, rootIDs []int64, perThread int) ([]*entity.MovieComments, error)
+	// RefreshLikes recounts the comment's likes into likes_count
+	RefreshLikes(ctx context.Context, id int64) error
+	// RefreshReplies recounts the visible replies of a thread into its root's replies_count
+	RefreshReplies(ctx context.Context, rootID int64) error
+}
+
+// ShownFilter matches the comments a listing shows: the ones neither deleted nor
+// hidden, and removed ones that were answered so their replies keep a parent
+func ShownFilter() repository.Filter {
+	return repository.Expr(
+		"((movie_comments.deleted_at IS NULL AND movie_comments.hidden_at IS NULL) OR EXISTS (SELECT 1 FROM movie_comments answers WHERE answers.parent_id = movie_comments.id))",
+	)
+}
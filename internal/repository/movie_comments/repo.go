@@ -0,0 +1,118 @@
+package movie_comments
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.MovieComments]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.MovieComments](db),
+		db:             db,
+	}
+}
+
+func (r *repo) Threads(ctx context.Context, movieID int64, after *entity.CommentCursor, limit int) ([]*entity.MovieComments, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	query := db.Preload("User").
+		Where("movie_comments.movie_id = ? AND movie_comments.root_id IS NULL", movieID).
+		Where("((movie_comments.deleted_at IS NULL AND movie_comments.hidden_at IS NULL) OR movie_comments.replies_count > 0)")
+	if after != nil {
+		query = query.Where("(movie_comments.created_at, movie_comments.id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var comments []*entity.MovieComments
+	err := query.Order("movie_comments.created_at DESC, movie_comments.id DESC").
+		Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, postgres.Error(err, "Threads", &entity.MovieComments{})
+	}
+
+	return comments, nil
+}
+
+func (r *repo) Replies(ctx context.Context, rootID int64, after *entity.CommentCursor, limit int) ([]*entity.MovieComments, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	query := db.Preload("User").
+		Where("movie_comments.root_id = ?", rootID).
+		Scopes(ShownFilter().Scope)
+	if after != nil {
+		query = query.Where("(movie_comments.created_at, movie_comments.id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var comments []*entity.MovieComments
+	err := query.Order("movie_comments.created_at, movie_comments.id").
+		Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, postgres.Error(err, "Replies", &entity.MovieComments{})
+	}
+
+	return comments, nil
+}
+
+func (r *repo) FirstReplies(ctx context.Context, rootIDs []int64, perThread int) ([]*entity.MovieComments, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	db := repository.FromContext(ctx, r.db)
+
+	ranked := db.Session(&gorm.Session{NewDB: true}).
+		Model(&entity.MovieComments{}).
+		Select("movie_comments.*, row_number() OVER (PARTITION BY movie_comments.root_id ORDER BY movie_comments.created_at, movie_comments.id) AS reply_rank").
+		Where("movie_comments.root_id IN (?)", rootIDs).
+		Scopes(ShownFilter().Scope)
+
+	var comments []*entity.MovieComments
+	err := db.Table("(?) AS movie_comments", ranked).
+		Preload("User").
+		Where("movie_comments.reply_rank <= ?", perThread).
+		Order("movie_comments.root_id, movie_comments.reply_rank").
+		Find(&comments).Error
+	if err != nil {
+		return nil, postgres.Error(err, "FirstReplies", &entity.MovieComments{})
+	}
+
+	return comments, nil
+}
+
+func (r *repo) RefreshLikes(ctx context.Context, id int64) error {
+	db := repository.FromContext(ctx, r.db)
+
+	err := db.Model(&entity.MovieComments{}).
+		Where("id = ?", id).
+		UpdateColumn("likes_count", gorm.Expr("(SELECT count(*) FROM movie_comment_likes WHERE movie_comment_likes.comment_id = movie_comments.id)")).
+		Error
+	if err != nil {
+		return postgres.Error(err, "RefreshLikes", &entity.MovieComments{})
+	}
+
+	return nil
+}
+
+func (r *repo) RefreshReplies(ctx context.Context, rootID int64) error {
+	db := repository.FromContext(ctx, r.db)
+
+	err := db.Model(&entity.MovieComments{}).
+		Where("id = ?", rootID).
+		UpdateColumn("replies_count", gorm.Expr("(SELECT count(*) FROM movie_comments replies WHERE replies.root_id = movie_comments.id AND replies.deleted_at IS NULL AND replies.hidden_at IS NULL)")).
+		Error
+	if err != nil {
+		return postgres.Error(err, "RefreshReplies", &entity.MovieComments{})
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package movie_events
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.MovieEvents]
+	// PurgeBefore removes the events recorded before the given time and returns how many
+	PurgeBefore(ctx context.Context, before time.Time) (int64, error)
+}
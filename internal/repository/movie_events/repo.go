@@ -0,0 +1,34 @@
+package movie_events
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.MovieEvents]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.MovieEvents](db),
+		db:             db,
+	}
+}
+
+func (r *repo) PurgeBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	result := db.Where("created_at < ?", before).Delete(&entity.MovieEvents{})
+	if result.Error != nil {
+		return 0, postgres.Error(result.Error, "PurgeBefore", &entity.MovieEvents{})
+	}
+
+	return result.RowsAffected, nil
+}
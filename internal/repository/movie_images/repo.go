@@ -1,4 +1,4 @@
-package movie_genres
+package movie_images
 
 import (
 	"github.com/AsaHero/movie-app-server/internal/entity"
@@ -7,13 +7,13 @@ import (
 )
 
 type repo struct {
-	repository.BaseRepository[*entity.MovieGenres]
+	repository.BaseRepository[*entity.MovieImages]
 	db *gorm.DB
 }
 
 func New(db *gorm.DB) Repository {
 	return &repo{
-		BaseRepository: repository.NewBaseRepository[*entity.MovieGenres](db),
+		BaseRepository: repository.NewBaseRepository[*entity.MovieImages](db),
 		db:             db,
 	}
 }
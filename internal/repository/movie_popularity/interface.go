@@ -0,0 +1,18 @@
+package movie_popularity
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.MoviePopularity]
+	// Rebuild replaces the popularity of every movie for each period with scores
+	// computed from the recorded events, and returns how many were stored
+	Rebuild(ctx context.Context, periods []entity.ChartPeriod, weights entity.PopularityWeights) (int64, error)
+	// Top ranks the movies matching movieFilter that had activity over period,
+	// by the score of chart, and returns the total number of ranked movies
+	Top(ctx context.Context, chart entity.Chart, period entity.ChartPeriod, limit, page uint64, movieFilter repository.Filter) (int64, []*entity.MoviePopularity, error)
+}
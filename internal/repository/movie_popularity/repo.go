@@ -0,0 +1,121 @@
+package movie_popularity
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.MoviePopularity]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.MoviePopularity](db),
+		db:             db,
+	}
+}
+
+// rebuildSQL scores the events of the last two periods, a user counting once per
+// movie, kind and day. Events of the period decay exponentially with their age
+// into score and, faster, into recent; the trending score is recent damped by
+// the plain weight of the period before it.
+const rebuildSQL = `
+INSERT INTO movie_popularity (movie_id, period, score, trending_score, events, computed_at)
+WITH distinct_events AS (
+    SELECT movie_id, kind, max(created_at) AS created_at
+    FROM movie_events
+    WHERE created_at >= now() - make_interval(secs => 2 * CAST(@horizon AS float8))
+    GROUP BY movie_id, user_id, kind, date_trunc('day', created_at)
+), weighted AS (
+    SELECT movie_id,
+        CASE kind WHEN 'open' THEN CAST(@open AS float8) ELSE CAST(@view AS float8) END AS weight,
+        extract(epoch FROM now() - created_at)::float8 AS age
+    FROM distinct_events
+), scored AS (
+    SELECT movie_id,
+        sum(weight * exp(-ln(2) * age / CAST(@half_life AS float8))) FILTER (WHERE age < @horizon) AS score,
+        sum(weight * exp(-ln(2) * age / CAST(@trending_half_life AS float8))) FILTER (WHERE age < @horizon) AS recent,
+        coalesce(sum(weight) FILTER (WHERE age >= @horizon), 0) AS previous,
+        count(*) FILTER (WHERE age < @horizon) AS events
+    FROM weighted
+    GROUP BY movie_id
+)
+SELECT movie_id, @period, score, recent * recent / (recent + previous + 1), events, now()
+FROM scored
+WHERE events > 0`
+
+func (r *repo) Rebuild(ctx context.Context, periods []entity.ChartPeriod, weights entity.PopularityWeights) (int64, error) {
+	var stored int64
+
+	err := r.WithTransaction(ctx, func(ctx context.Context) error {
+		db := repository.FromContext(ctx, r.db)
+
+		// readers keep seeing the previous scores until the transaction commits
+		if err := db.Exec("DELETE FROM movie_popularity").Error; err != nil {
+			return err
+		}
+
+		for _, period := range periods {
+			horizon := period.Duration().Seconds()
+
+			result := db.Exec(rebuildSQL, map[string]any{
+				"period":             string(period),
+				"horizon":            horizon,
+				"half_life":          horizon * weights.HalfLife,
+				"trending_half_life": horizon * weights.TrendingHalfLife,
+				"view":               weights.View,
+				"open":               weights.Open,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+
+			stored += result.RowsAffected
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, postgres.Error(err, "Rebuild", &entity.MoviePopularity{})
+	}
+
+	return stored, nil
+}
+
+func (r *repo) Top(ctx context.Context, chart entity.Chart, period entity.ChartPeriod, limit, page uint64, movieFilter repository.Filter) (int64, []*entity.MoviePopularity, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	column := "movie_popularity.score"
+	if chart == entity.ChartTrending {
+		column = "movie_popularity.trending_score"
+	}
+
+	query := db.Model(&entity.MoviePopularity{}).
+		Joins("JOIN movies ON movies.id = movie_popularity.movie_id AND movies.deleted_at IS NULL").
+		Where("movie_popularity.period = ?", period).
+		Scopes(movieFilter.Scope)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, nil, postgres.Error(err, "Top", &entity.MoviePopularity{})
+	}
+
+	var ranked []*entity.MoviePopularity
+	err := query.Select("movie_popularity.*").
+		Order(column + " DESC").
+		Order("movie_popularity.movie_id").
+		Offset(int((page - 1) * limit)).
+		Limit(int(limit)).
+		Find(&ranked).Error
+	if err != nil {
+		return 0, nil, postgres.Error(err, "Top", &entity.MoviePopularity{})
+	}
+
+	return total, ranked, nil
+}
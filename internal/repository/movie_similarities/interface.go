@@ -0,0 +1,21 @@
+package movie_similarities
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.MovieSimilarities]
+	// Rebuild replaces every similarity with ones computed from the current
+	// ratings, watch history and genres, and returns how many were stored
+	Rebuild(ctx context.Context, weights entity.SimilarityWeights) (int64, error)
+	// CountLiked returns how many of the movies matching movieFilter userID liked
+	CountLiked(ctx context.Context, userID string, movieFilter repository.Filter) (int64, error)
+	// Recommend ranks the movies matching movieFilter that userID has neither
+	// started nor rated. personal in [0, 1] weighs the neighbours of the movies
+	// they liked against the movies liked by the most users.
+	Recommend(ctx context.Context, userID string, limit uint64, personal float64, movieFilter repository.Filter) ([]*entity.Recommendation, error)
+}
@@ -0,0 +1,178 @@
+package movie_similarities
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.MovieSimilarities]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.MovieSimilarities](db),
+		db:             db,
+	}
+}
+
+// rebuildSQL scores the pairs of movies liked by the same users by the cosine of
+// their interaction weights, and pairs of a liked movie with any movie sharing a
+// genre by the Jaccard index of their genres. Only liked movies get neighbours
+// since only they are the source of recommendations.
+const rebuildSQL = `
+INSERT INTO movie_similarities (movie_id, similar_movie_id, score, computed_at)
+WITH norms AS (
+    SELECT movie_id, sqrt(sum(weight * weight)) AS norm
+    FROM movie_interactions
+    GROUP BY movie_id
+), co_liked AS (
+    SELECT a.movie_id, b.movie_id AS similar_movie_id, sum(a.weight * b.weight) / (na.norm * nb.norm) AS score
+    FROM movie_interactions a
+    JOIN movie_interactions b ON b.user_id = a.user_id AND b.movie_id <> a.movie_id
+    JOIN norms na ON na.movie_id = a.movie_id
+    JOIN norms nb ON nb.movie_id = b.movie_id
+    GROUP BY a.movie_id, b.movie_id, na.norm, nb.norm
+), genre_counts AS (
+    SELECT title_id AS movie_id, count(*) AS genres
+    FROM title_genres
+    WHERE title_type = 'movie'
+    GROUP BY title_id
+), shared_genres AS (
+    SELECT a.title_id AS movie_id, b.title_id AS similar_movie_id,
+        count(*)::float8 / (ga.genres + gb.genres - count(*)) AS score
+    FROM title_genres a
+    JOIN norms liked ON liked.movie_id = a.title_id
+    JOIN title_genres b ON b.genre_id = a.genre_id AND b.title_type = 'movie' AND b.title_id <> a.title_id
+    JOIN genre_counts ga ON ga.movie_id = a.title_id
+    JOIN genre_counts gb ON gb.movie_id = b.title_id
+    WHERE a.title_type = 'movie'
+    GROUP BY a.title_id, b.title_id, ga.genres, gb.genres
+), blended AS (
+    SELECT coalesce(c.movie_id, g.movie_id) AS movie_id,
+        coalesce(c.similar_movie_id, g.similar_movie_id) AS similar_movie_id,
+        @co_liked * coalesce(c.score, 0) + @genres * coalesce(g.score, 0) AS score
+    FROM co_liked c
+    FULL JOIN shared_genres g ON g.movie_id = c.movie_id AND g.similar_movie_id = c.similar_movie_id
+), ranked AS (
+    SELECT blended.*, row_number() OVER (PARTITION BY blended.movie_id ORDER BY blended.score DESC, blended.similar_movie_id) AS rank
+    FROM blended
+    JOIN movies ON movies.id = blended.similar_movie_id AND movies.deleted_at IS NULL
+    WHERE blended.score > 0
+)
+SELECT movie_id, similar_movie_id, score, now()
+FROM ranked
+WHERE rank <= @neighbours`
+
+func (r *repo) Rebuild(ctx context.Context, weights entity.SimilarityWeights) (int64, error) {
+	var stored int64
+
+	err := r.WithTransaction(ctx, func(ctx context.Context) error {
+		db := repository.FromContext(ctx, r.db)
+
+		// readers keep seeing the previous similarities until the transaction commits
+		if err := db.Exec("DELETE FROM movie_similarities").Error; err != nil {
+			return err
+		}
+
+		result := db.Exec(rebuildSQL, map[string]any{
+			"co_liked":   weights.CoLiked,
+			"genres":     weights.Genres,
+			"neighbours": weights.Neighbours,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		stored = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, postgres.Error(err, "Rebuild", &entity.MovieSimilarities{})
+	}
+
+	return stored, nil
+}
+
+func (r *repo) CountLiked(ctx context.Context, userID string, movieFilter repository.Filter) (int64, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	var count int64
+	err := db.Table("movie_interactions").
+		Where("movie_interactions.user_id = ? AND movie_interactions.movie_id IN (?)", userID, r.visible(db, movieFilter)).
+		Count(&count).Error
+	if err != nil {
+		return 0, postgres.Error(err, "CountLiked", &entity.MovieSimilarities{})
+	}
+
+	return count, nil
+}
+
+// recommendSQL sums, for each neighbour of the movies the user liked, its
+// similarity weighted by how much they liked the source, and remembers the
+// source that contributed most. Both that and popularity are scaled to [0, 1]
+// before they are blended.
+const recommendSQL = `
+WITH visible AS (?), sources AS (
+    SELECT movie_id, weight
+    FROM movie_interactions
+    WHERE user_id = ? AND movie_id IN (SELECT id FROM visible)
+), personal AS (
+    SELECT s.similar_movie_id AS movie_id, sum(src.weight * s.score) AS score,
+        (array_agg(s.movie_id ORDER BY src.weight * s.score DESC, s.movie_id))[1] AS because_movie_id
+    FROM sources src
+    JOIN movie_similarities s ON s.movie_id = src.movie_id
+    GROUP BY s.similar_movie_id
+), popular AS (
+    SELECT movie_id, count(*)::float8 AS likes
+    FROM movie_interactions
+    GROUP BY movie_id
+), scored AS (
+    SELECT coalesce(p.movie_id, q.movie_id) AS movie_id,
+        ? * coalesce(p.score / nullif(max(p.score) OVER (), 0), 0)
+            + (1 - ?) * coalesce(q.likes / nullif(max(q.likes) OVER (), 0), 0) AS score,
+        p.because_movie_id
+    FROM personal p
+    FULL JOIN popular q ON q.movie_id = p.movie_id
+)
+SELECT scored.movie_id, scored.score, scored.because_movie_id
+FROM scored
+WHERE scored.score > 0
+  AND scored.movie_id IN (SELECT id FROM visible)
+  AND NOT EXISTS (
+    SELECT 1 FROM watch_progress
+    WHERE watch_progress.user_id = ? AND watch_progress.watchable_type = 'movie' AND watch_progress.watchable_id = scored.movie_id
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM movie_ratings
+    WHERE movie_ratings.user_id = ? AND movie_ratings.movie_id = scored.movie_id
+  )
+ORDER BY scored.score DESC, scored.movie_id
+LIMIT ?`
+
+func (r *repo) Recommend(ctx context.Context, userID string, limit uint64, personal float64, movieFilter repository.Filter) ([]*entity.Recommendation, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	var recommendations []*entity.Recommendation
+	err := db.Raw(recommendSQL,
+		r.visible(db, movieFilter), userID, personal, personal, userID, userID, limit,
+	).Scan(&recommendations).Error
+	if err != nil {
+		return nil, postgres.Error(err, "Recommend", &entity.MovieSimilarities{})
+	}
+
+	return recommendations, nil
+}
+
+// visible selects the ids of the movies that are not deleted and match filter
+func (r *repo) visible(db *gorm.DB, filter repository.Filter) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Model(&entity.Movies{}).
+		Select("movies.id").
+		Scopes(filter.Scope)
+}
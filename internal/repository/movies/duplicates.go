@@ -0,0 +1,113 @@
+package movies
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+)
+
+// duplicatesSQL pairs movies whose titles are equal once lowercased and stripped
+// of anything but letters and digits, released at most a year apart. Half of the
+// score is the release year, half how close the durations are; an unknown
+// duration counts as half a match. Dismissed pairs are left out.
+const duplicatesSQL = `
+WITH normalized AS (
+    SELECT id,
+        regexp_replace(lower(title), '[^[:alnum:]]+', '', 'g') AS title_key,
+        extract(year FROM release)::int AS year,
+        duration_minutes
+    FROM movies
+    WHERE deleted_at IS NULL
+), pairs AS (
+    SELECT a.id AS movie_id, b.id AS duplicate_id,
+        abs(a.year - b.year) AS year_diff,
+        abs(a.duration_minutes - b.duration_minutes) AS duration_diff,
+        CASE WHEN a.year = b.year THEN 0.5 ELSE 0.25 END +
+        CASE WHEN a.duration_minutes = 0 OR b.duration_minutes = 0 THEN 0.25
+            ELSE 0.5 * (1 - least(abs(a.duration_minutes - b.duration_minutes)::float8 / greatest(a.duration_minutes, b.duration_minutes), 1))
+        END AS score
+    FROM normalized a
+    JOIN normalized b ON b.title_key = a.title_key AND b.id > a.id AND abs(a.year - b.year) <= 1
+    WHERE a.title_key <> ''
+      AND NOT EXISTS (
+        SELECT 1 FROM movie_duplicate_dismissals
+        WHERE movie_duplicate_dismissals.movie_id = a.id AND movie_duplicate_dismissals.duplicate_id = b.id
+      )
+)
+SELECT movie_id, duplicate_id, score, year_diff, duration_diff, count(*) OVER () AS total
+FROM pairs
+WHERE score >= CAST(@min_score AS float8)
+ORDER BY score DESC, movie_id, duplicate_id
+LIMIT @limit OFFSET @offset`
+
+func (r *repo) DuplicateCandidates(ctx context.Context, limit, page uint64, minScore float64) (int64, []*entity.DuplicateCandidate, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	var rows []struct {
+		entity.DuplicateCandidate
+		Total int64
+	}
+	err := db.Raw(duplicatesSQL, map[string]any{
+		"min_score": minScore,
+		"limit":     limit,
+		"offset":    (page - 1) * limit,
+	}).Scan(&rows).Error
+	if err != nil {
+		return 0, nil, postgres.Error(err, "DuplicateCandidates", &entity.Movies{})
+	}
+
+	var total int64
+	candidates := make([]*entity.DuplicateCandidate, 0, len(rows))
+	for i := range rows {
+		total = rows[i].Total
+		candidates = append(candidates, &rows[i].DuplicateCandidate)
+	}
+
+	return total, candidates, nil
+}
+
+// mergeSQL moves what hangs off the duplicate onto the survivor. Where both
+// have an entry, a user's rating, a list's item or an external id of a source,
+// the survivor's is kept and the duplicate's dropped. Comment threads move whole.
+var mergeSQL = []string{
+	`INSERT INTO title_genres (title_type, title_id, genre_id, created_at)
+    SELECT title_type, CAST(@survivor AS bigint), genre_id, created_at FROM title_genres
+    WHERE title_type = 'movie' AND title_id = @duplicate
+    ON CONFLICT DO NOTHING`,
+	`DELETE FROM title_genres WHERE title_type = 'movie' AND title_id = @duplicate`,
+
+	`UPDATE movie_ratings SET movie_id = @survivor
+    WHERE movie_id = @duplicate AND NOT EXISTS (
+        SELECT 1 FROM movie_ratings kept WHERE kept.movie_id = @survivor AND kept.user_id = movie_ratings.user_id
+    )`,
+	`DELETE FROM movie_ratings WHERE movie_id = @duplicate`,
+
+	`UPDATE movie_comments SET movie_id = @survivor WHERE movie_id = @duplicate`,
+
+	`UPDATE user_list_items SET movie_id = @survivor, updated_at = now()
+    WHERE movie_id = @duplicate AND NOT EXISTS (
+        SELECT 1 FROM user_list_items kept WHERE kept.list_id = user_list_items.list_id AND kept.movie_id = @survivor
+    )`,
+	`DELETE FROM user_list_items WHERE movie_id = @duplicate`,
+
+	`UPDATE movie_external_ids SET movie_id = @survivor
+    WHERE movie_id = @duplicate AND source NOT IN (
+        SELECT source FROM movie_external_ids WHERE movie_id = @survivor
+    )`,
+	`DELETE FROM movie_external_ids WHERE movie_id = @duplicate`,
+}
+
+func (r *repo) MergeInto(ctx context.Context, survivorID, duplicateID int64) error {
+	db := repository.FromContext(ctx, r.db)
+
+	params := map[string]any{"survivor": survivorID, "duplicate": duplicateID}
+	for _, statement := range mergeSQL {
+		if err := db.Exec(statement, params).Error; err != nil {
+			return postgres.Error(err, "MergeInto", &entity.Movies{})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package movies
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type facetRow struct {
+	Value string
+	Label string
+	Count int64
+}
+
+// Facets runs one grouped aggregate per requested facet. Each facet is computed
+// under the current filters except its own, so selecting "Drama" still shows counts for other genres.
+func (r *repo) Facets(ctx context.Context, filters entity.MovieFilters, facets []entity.MovieFacet) (*entity.MovieFacets, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	result := &entity.MovieFacets{}
+
+	for _, facet := range facets {
+		query := db.Model(&entity.Movies{}).Scopes(movieFilter(filters, facet).Scope)
+
+		var rows []facetRow
+		var err error
+
+		switch facet {
+		case entity.MovieFacetGenres:
+			err = query.
+				Select("genres.id::text AS value, genres.name AS label, COUNT(DISTINCT movies.id) AS count").
+				Joins("JOIN title_genres ON title_genres.title_type = 'movie' AND title_genres.title_id = movies.id").
+				Joins("JOIN genres ON genres.id = title_genres.genre_id").
+				Group("genres.id, genres.name").
+				Order("count DESC, genres.name ASC").
+				Scan(&rows).Error
+			result.Genres = toBuckets(rows)
+
+		case entity.MovieFacetDecades:
+			err = releaseFacet(query, "(EXTRACT(YEAR FROM movies.release)::int / 10) * 10").Scan(&rows).Error
+			for i := range rows {
+				rows[i].Label = rows[i].Value + "s"
+			}
+			result.Decades = toBuckets(rows)
+
+		case entity.MovieFacetYears:
+			err = releaseFacet(query, "EXTRACT(YEAR FROM movies.release)::int").Scan(&rows).Error
+			result.Years = toBuckets(rows)
+
+		case entity.MovieFacetDurations:
+			err = durationFacet(query).Scan(&rows).Error
+			result.Durations = durationBuckets(rows)
+		}
+
+		if err != nil {
+			return nil, postgres.Error(err, "Facets", &entity.Movies{})
+		}
+	}
+
+	return result, nil
+}
+
+func releaseFacet(query *gorm.DB, expr string) *gorm.DB {
+	return query.
+		Select(expr + "::text AS value, COUNT(*) AS count").
+		Where("movies.release IS NOT NULL").
+		Group(expr).
+		Order(expr + " DESC")
+}
+
+func durationFacet(query *gorm.DB) *gorm.DB {
+	caseExpr := "CASE"
+	vars := make([]any, 0, len(entity.MovieDurationBuckets)*3)
+
+	for _, bucket := range entity.MovieDurationBuckets {
+		if bucket.Max > 0 {
+			caseExpr += " WHEN movies.duration_minutes >= ? AND movies.duration_minutes < ? THEN ?"
+			vars = append(vars, bucket.Min, bucket.Max, bucket.Key)
+		} else {
+			caseExpr += " WHEN movies.duration_minutes >= ? THEN ?"
+			vars = append(vars, bucket.Min, bucket.Key)
+		}
+	}
+	caseExpr += " END"
+
+	return query.
+		Select(caseExpr+" AS value, COUNT(*) AS count", vars...).
+		Where("movies.duration_minutes IS NOT NULL").
+		Group("value")
+}
+
+func toBuckets(rows []facetRow) []entity.FacetBucket {
+	buckets := make([]entity.FacetBucket, 0, len(rows))
+	for _, row := range rows {
+		label := row.Label
+		if label == "" {
+			label = row.Value
+		}
+		buckets = append(buckets, entity.FacetBucket{
+			Value: row.Value,
+			Label: label,
+			Count: row.Count,
+		})
+	}
+	return buckets
+}
+
+// durationBuckets keeps the configured bucket order and reports empty buckets with zero count
+func durationBuckets(rows []facetRow) []entity.FacetBucket {
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Value] = row.Count
+	}
+
+	buckets := make([]entity.FacetBucket, 0, len(entity.MovieDurationBuckets))
+	for _, bucket := range entity.MovieDurationBuckets {
+		buckets = append(buckets, entity.FacetBucket{
+			Value: bucket.Key,
+			Label: bucket.Label,
+			Count: counts[bucket.Key],
+		})
+	}
+	return buckets
+}
@@ -2,6 +2,7 @@ package movies
 
 import (
 	"context"
+	"time"
 
 	"github.com/AsaHero/movie-app-server/internal/entity"
 	"github.com/AsaHero/movie-app-server/internal/repository"
@@ -10,4 +11,23 @@ import (
 type Repository interface {
 	repository.BaseRepository[*entity.Movies]
 	ListWithFilters(ctx context.Context, limit, page uint64, orderBy, orderDir string, filters entity.MovieFilters) (int64, []entity.Movies, error)
+	Patch(ctx context.Context, movie *entity.Movies, columns []string, withGenres bool) error
+	ReplaceGenres(ctx context.Context, movie *entity.Movies) error
+	ListTrashed(ctx context.Context, limit, page uint64) (int64, []entity.Movies, error)
+	Restore(ctx context.Context, id int64) error
+	Touch(ctx context.Context, id int64) error
+	TouchGenre(ctx context.Context, genreID int64) error
+	HardDelete(ctx context.Context, id, version int64) error
+	FindWithTrashed(ctx context.Context, filter repository.Filter) (*entity.Movies, error)
+	PurgeDeleted(ctx context.Context, before time.Time) ([]entity.Movies, error)
+	StreamWithFilters(ctx context.Context, filters entity.MovieFilters, batchSize int, fn func(movies []entity.Movies) error) error
+	Facets(ctx context.Context, filters entity.MovieFilters, facets []entity.MovieFacet) (*entity.MovieFacets, error)
+	SimilarCandidates(ctx context.Context, movie *entity.Movies, limit int) ([]entity.Movies, error)
+	GenreFrequencies(ctx context.Context) (map[int64]int64, int64, error)
+	// DuplicateCandidates lists the likely duplicates scoring at least minScore,
+	// best first, and returns how many there are
+	DuplicateCandidates(ctx context.Context, limit, page uint64, minScore float64) (int64, []*entity.DuplicateCandidate, error)
+	// MergeInto moves the genres, ratings, comments, list items and external ids
+	// of the duplicate onto the survivor. It must run inside a transaction.
+	MergeInto(ctx context.Context, survivorID, duplicateID int64) error
 }
@@ -2,10 +2,15 @@ package movies
 
 import (
 	"context"
+	"time"
 
 	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
 	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"github.com/AsaHero/movie-app-server/pkg/utility"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type repo struct {
@@ -26,35 +31,20 @@ func (r *repo) ListWithFilters(ctx context.Context, limit, page uint64, orderBy,
 	var movies []entity.Movies
 	var total int64
 
-	query := db.Model(&entity.Movies{})
-
-	// Apply search filter
-	if filters.Search != nil && *filters.Search != "" {
-		searchTerm := "%" + *filters.Search + "%"
-		query = query.Where("title ILIKE ?", searchTerm)
-	}
-
-	// Apply genres filter
-	if len(filters.Genres) > 0 {
-		query = query.Joins("JOIN movie_genres ON movies.id = movie_genres.movie_id").
-			Where("movie_genres.genre_id IN ?", filters.Genres).
-			Group("movies.id")
-	}
+	query := db.Model(&entity.Movies{}).Scopes(movieFilter(filters, "").Scope)
 
 	// Preload related data
-	query = query.Preload("MovieGenres").Preload("MovieGenres.Genre")
+	query = query.Preload("MovieGenres").Preload("MovieGenres.Genre").Preload("MovieGenres.Genre.Translations").
+		Preload("ExternalIDs").Preload("Images").Preload("Translations").Preload("Releases")
 
 	// Get total count for pagination
 	if err := query.Count(&total).Error; err != nil {
 		return 0, nil, err
 	}
 
-	// Apply ordering
-	if orderBy != "" {
-		if orderDir == "" {
-			orderDir = "asc"
-		}
-		query = query.Order(orderBy + " " + orderDir)
+	// Apply ordering, unknown columns fall back to the default
+	if column, ok := movieOrders[orderBy]; ok {
+		query = query.Order(movieOrder(orderBy, column, orderDir))
 	} else {
 		// Default ordering
 		query = query.Order("created_at desc")
@@ -72,25 +62,398 @@ func (r *repo) ListWithFilters(ctx context.Context, limit, page uint64, orderBy,
 	return total, movies, nil
 }
 
+// movieOrders are the expressions movie listings can be ordered by, popularity
+// is the score of the month and movies without activity have none
+var movieOrders = map[string]string{
+	"title":      "movies.title",
+	"release":    "movies.release",
+	"created_at": "movies.created_at",
+	"popularity": "(SELECT movie_popularity.score FROM movie_popularity WHERE movie_popularity.movie_id = movies.id AND movie_popularity.period = 'month')",
+}
+
+// movieOrder builds the ORDER BY clause for one of movieOrders. Popularity
+// defaults to the most popular first and keeps movies without a score last,
+// ties are broken by id so pages stay stable.
+func movieOrder(orderBy, column, orderDir string) string {
+	if orderBy != "popularity" {
+		if orderDir != "desc" {
+			orderDir = "asc"
+		}
+		return column + " " + orderDir
+	}
+
+	if orderDir == "asc" {
+		return column + " ASC NULLS FIRST, movies.id"
+	}
+	return column + " DESC NULLS LAST, movies.id"
+}
+
+// StreamWithFilters walks every movie matching filters in id order, batchSize rows at a time,
+// so large exports never hold the whole result set in memory. Returning an error from fn stops the walk.
+func (r *repo) StreamWithFilters(ctx context.Context, filters entity.MovieFilters, batchSize int, fn func(movies []entity.Movies) error) error {
+	db := repository.FromContext(ctx, r.db)
+
+	var batch []entity.Movies
+
+	return db.Model(&entity.Movies{}).
+		Scopes(movieFilter(filters, "").Scope).
+		Preload("MovieGenres").
+		Preload("MovieGenres.Genre").
+		Preload("ExternalIDs").
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+// movieFilter translates MovieFilters into a repository filter. The facet passed
+// as exclude is left out so its own counts are not narrowed by its selection.
+func movieFilter(filters entity.MovieFilters, exclude entity.MovieFacet) repository.Filter {
+	var filter repository.Filter
+
+	// Apply search filter
+	if filters.Search != nil && *filters.Search != "" {
+		searchTerm := "%" + *filters.Search + "%"
+		filter = filter.And(repository.Or(
+			repository.ILike("movies.title", searchTerm),
+			repository.Expr(
+				"EXISTS (SELECT 1 FROM movie_translations WHERE movie_translations.movie_id = movies.id AND movie_translations.title ILIKE ?)",
+				searchTerm,
+			),
+		))
+	}
+
+	// Apply genres filter
+	if len(filters.Genres) > 0 && exclude != entity.MovieFacetGenres {
+		filter = filter.And(repository.Expr(
+			"EXISTS (SELECT 1 FROM title_genres WHERE title_genres.title_type = 'movie' AND title_genres.title_id = movies.id AND title_genres.genre_id IN ?)",
+			filters.Genres,
+		))
+	}
+
+	// Apply release decade filter
+	if len(filters.Decades) > 0 && exclude != entity.MovieFacetDecades {
+		ranges := make([]repository.Filter, 0, len(filters.Decades))
+		for _, decade := range filters.Decades {
+			ranges = append(ranges, releaseRange(decade, 10))
+		}
+		filter = filter.And(repository.Or(ranges...))
+	}
+
+	// Apply release year filter
+	if len(filters.Years) > 0 && exclude != entity.MovieFacetYears {
+		ranges := make([]repository.Filter, 0, len(filters.Years))
+		for _, year := range filters.Years {
+			ranges = append(ranges, releaseRange(year, 1))
+		}
+		filter = filter.And(repository.Or(ranges...))
+	}
+
+	// Apply duration buckets filter
+	if len(filters.Durations) > 0 && exclude != entity.MovieFacetDurations {
+		ranges := make([]repository.Filter, 0, len(filters.Durations))
+		for _, key := range filters.Durations {
+			bucket, ok := entity.FindDurationBucket(key)
+			if !ok {
+				continue
+			}
+
+			bucketFilter := repository.Gte("movies.duration_minutes", bucket.Min)
+			if bucket.Max > 0 {
+				bucketFilter = bucketFilter.And(repository.Lt("movies.duration_minutes", bucket.Max))
+			}
+			ranges = append(ranges, bucketFilter)
+		}
+		filter = filter.And(repository.Or(ranges...))
+	}
+
+	// Apply release country filter
+	if filters.Country != "" {
+		filter = filter.And(repository.Expr(
+			"EXISTS (SELECT 1 FROM movie_releases WHERE movie_releases.movie_id = movies.id AND movie_releases.country = ?)",
+			filters.Country,
+		))
+	}
+
+	// Apply certification filters
+	for _, limit := range filters.AgeLimits {
+		filter = filter.And(AgeLimitFilter(limit))
+	}
+
+	// Apply workflow status filter, listings are public unless asked otherwise
+	if len(filters.Statuses) > 0 {
+		filter = filter.And(repository.In("movies.status", filters.Statuses))
+	} else {
+		filter = filter.And(PublishedFilter())
+	}
+
+	return filter
+}
+
+// AgeLimitFilter matches movies whose strictest certification in the limit's
+// country admits its age. Movies without a rating there are left out, and so
+// are movies with any unrated release there, an unknown age counts as the strictest.
+func AgeLimitFilter(limit entity.AgeLimit) repository.Filter {
+	return repository.Expr(
+		"(SELECT max(coalesce(movie_releases.min_age, 99)) FROM movie_releases WHERE movie_releases.movie_id = movies.id AND movie_releases.country = ?) <= ?",
+		limit.Country, limit.MaxAge,
+	)
+}
+
+// VisibleFilter matches the published movies a viewer within limit may see, nil
+// meaning no age limit. Readers serving movies to viewers go through it, so a new
+// visibility rule belongs here.
+func VisibleFilter(limit *entity.AgeLimit) repository.Filter {
+	filter := PublishedFilter()
+	if limit != nil {
+		filter = filter.And(AgeLimitFilter(*limit))
+	}
+	return filter
+}
+
+// PublishedFilter matches the movies that went through the editorial workflow and are public
+func PublishedFilter() repository.Filter {
+	return repository.Eq("movies.status", entity.MovieStatusPublished)
+}
+
+// releaseRange matches releases in [year, year+span) and stays index friendly
+func releaseRange(year, span int) repository.Filter {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(span, 0, 0)
+
+	return repository.And(
+		repository.Gte("movies.release", from),
+		repository.Lt("movies.release", to),
+	)
+}
+
 func (r *repo) Update(ctx context.Context, movie *entity.Movies) error {
 	db := repository.FromContext(ctx, r.db)
 
-	// Update the movie itself (without associations)
-	if err := db.Model(movie).Omit("MovieGenres").Updates(map[string]interface{}{
+	// Update the movie itself (without associations), a non-zero Version is the
+	// version the caller last saw and the update only applies if it is still current
+	query := db.Model(movie).Omit("MovieGenres").
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "version"}}})
+	if movie.Version > 0 {
+		query = query.Where("version = ?", movie.Version)
+	}
+
+	result := query.Updates(map[string]interface{}{
 		"title":            movie.Title,
 		"release":          movie.Release,
 		"plot":             movie.Plot,
 		"duration_minutes": movie.DurationMinutes,
 		"poster_url":       movie.PosterURL,
 		"trailer_url":      movie.TrailerURL,
-	}).Error; err != nil {
-		return err
+		"trailer_provider": movie.TrailerProvider,
+		"trailer_video_id": movie.TrailerVideoID,
+		"version":          gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return postgres.Error(result.Error, "Update", movie)
+	}
+
+	if result.RowsAffected == 0 {
+		if movie.Version > 0 {
+			return inerr.NewErrPreconditionFailed(utility.GetTypeName(movie))
+		}
+		return postgres.Error(gorm.ErrRecordNotFound, "Update", movie)
+	}
+
+	return replaceGenres(db, movie)
+}
+
+// Patch writes only the given columns and bumps the version. The update applies only
+// if movie.Version is still current. Genres are replaced only when asked to.
+func (r *repo) Patch(ctx context.Context, movie *entity.Movies, columns []string, withGenres bool) error {
+	db := repository.FromContext(ctx, r.db)
+
+	expected := movie.Version
+	movie.Version = expected + 1
+
+	result := db.Model(movie).
+		Select(append(columns, "version", "updated_at")).
+		Where("version = ?", expected).
+		Updates(movie)
+	if result.Error != nil {
+		return postgres.Error(result.Error, "Patch", movie)
+	}
+
+	if result.RowsAffected == 0 {
+		movie.Version = expected
+		return inerr.NewErrPreconditionFailed(utility.GetTypeName(movie))
 	}
 
-	// Replace associations
-	if err := db.Model(movie).Association("MovieGenres").Replace(movie.MovieGenres); err != nil {
-		return err
+	if !withGenres {
+		return nil
+	}
+
+	return replaceGenres(db, movie)
+}
+
+// ReplaceGenres syncs title_genres with movie.MovieGenres
+func (r *repo) ReplaceGenres(ctx context.Context, movie *entity.Movies) error {
+	return replaceGenres(repository.FromContext(ctx, r.db), movie)
+}
+
+// replaceGenres syncs title_genres with movie.MovieGenres, dropped rows are deleted
+// since title_genres.title_id is part of the primary key
+func replaceGenres(db *gorm.DB, movie *entity.Movies) error {
+	if err := db.Model(movie).Association("MovieGenres").Unscoped().Replace(movie.MovieGenres); err != nil {
+		return postgres.Error(err, "ReplaceGenres", movie)
+	}
+	return nil
+}
+
+// ListTrashed returns soft-deleted movies, most recently deleted first
+func (r *repo) ListTrashed(ctx context.Context, limit, page uint64) (int64, []entity.Movies, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	var movies []entity.Movies
+	var total int64
+
+	query := db.Unscoped().Model(&entity.Movies{}).Where("movies.deleted_at IS NOT NULL")
+
+	if err := query.Count(&total).Error; err != nil {
+		return 0, nil, postgres.Error(err, "ListTrashed", &entity.Movies{})
+	}
+
+	offset := (page - 1) * limit
+	err := query.
+		Preload("MovieGenres").Preload("MovieGenres.Genre").
+		Order("movies.deleted_at desc").
+		Offset(int(offset)).Limit(int(limit)).
+		Find(&movies).Error
+	if err != nil {
+		return 0, nil, postgres.Error(err, "ListTrashed", &entity.Movies{})
+	}
+
+	return total, movies, nil
+}
+
+func (r *repo) Restore(ctx context.Context, id int64) error {
+	db := repository.FromContext(ctx, r.db)
+
+	result := db.Unscoped().Model(&entity.Movies{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]any{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return postgres.Error(result.Error, "Restore", &entity.Movies{})
+	}
+
+	if result.RowsAffected == 0 {
+		return postgres.Error(gorm.ErrRecordNotFound, "Restore", &entity.Movies{})
+	}
+
+	return nil
+}
+
+// Touch bumps the version of a movie whose related data changed, so cached
+// representations keyed by the ETag are invalidated
+func (r *repo) Touch(ctx context.Context, id int64) error {
+	db := repository.FromContext(ctx, r.db)
+
+	result := db.Model(&entity.Movies{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return postgres.Error(result.Error, "Touch", &entity.Movies{})
+	}
+
+	if result.RowsAffected == 0 {
+		return postgres.Error(gorm.ErrRecordNotFound, "Touch", &entity.Movies{})
 	}
 
 	return nil
 }
+
+// TouchGenre bumps the version of every movie in the genre, e.g. when its
+// translated name changes
+func (r *repo) TouchGenre(ctx context.Context, genreID int64) error {
+	db := repository.FromContext(ctx, r.db)
+
+	err := db.Model(&entity.Movies{}).
+		Where("EXISTS (SELECT 1 FROM title_genres WHERE title_genres.title_type = ? AND title_genres.title_id = movies.id AND title_genres.genre_id = ?)", entity.TitleTypeMovie, genreID).
+		Updates(map[string]any{
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		return postgres.Error(err, "TouchGenre", &entity.Movies{})
+	}
+
+	return nil
+}
+
+// HardDelete removes the row permanently, title_genres rows go with it through the delete_title_genres trigger.
+// A non-zero version must still be current.
+func (r *repo) HardDelete(ctx context.Context, id, version int64) error {
+	db := repository.FromContext(ctx, r.db)
+
+	query := db.Unscoped().Where("id = ?", id)
+	if version > 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	result := query.Delete(&entity.Movies{})
+	if result.Error != nil {
+		return postgres.Error(result.Error, "HardDelete", &entity.Movies{})
+	}
+
+	if result.RowsAffected == 0 {
+		if version > 0 {
+			return inerr.NewErrPreconditionFailed(utility.GetTypeName(&entity.Movies{}))
+		}
+		return postgres.Error(gorm.ErrRecordNotFound, "HardDelete", &entity.Movies{})
+	}
+
+	return nil
+}
+
+// FindWithTrashed looks a movie up whether or not it is soft-deleted
+func (r *repo) FindWithTrashed(ctx context.Context, filter repository.Filter) (*entity.Movies, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	var movie entity.Movies
+	if err := db.Unscoped().Preload("MovieGenres").Scopes(filter.Scope).First(&movie).Error; err != nil {
+		return nil, postgres.Error(err, "FindWithTrashed", &movie)
+	}
+
+	return &movie, nil
+}
+
+// PurgeDeleted permanently removes movies that were soft-deleted before the given time
+// and returns them as they were right before removal
+func (r *repo) PurgeDeleted(ctx context.Context, before time.Time) ([]entity.Movies, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	var movies []entity.Movies
+	err := db.Unscoped().
+		Preload("MovieGenres").
+		Preload("Images").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Find(&movies).Error
+	if err != nil {
+		return nil, postgres.Error(err, "PurgeDeleted", &entity.Movies{})
+	}
+
+	if len(movies) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(movies))
+	for _, movie := range movies {
+		ids = append(ids, movie.ID)
+	}
+
+	if err := db.Unscoped().Where("id IN ?", ids).Delete(&entity.Movies{}).Error; err != nil {
+		return nil, postgres.Error(err, "PurgeDeleted", &entity.Movies{})
+	}
+
+	return movies, nil
+}
@@ -0,0 +1,97 @@
+package movies
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm/clause"
+)
+
+// similarReleaseYears is how far apart in time movies sharing no genre may be
+// to still be candidates
+const similarReleaseYears = 3
+
+// SimilarCandidates returns up to limit other movies sharing a genre with movie
+// or released around the same time, the most genres in common first
+func (r *repo) SimilarCandidates(ctx context.Context, movie *entity.Movies, limit int) ([]entity.Movies, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	genreIDs := make([]int64, 0, len(movie.MovieGenres))
+	for _, genre := range movie.MovieGenres {
+		genreIDs = append(genreIDs, genre.GenreID)
+	}
+
+	near := repository.Expr(
+		"movies.release BETWEEN ?::timestamptz - make_interval(years => ?) AND ?::timestamptz + make_interval(years => ?)",
+		movie.Release, similarReleaseYears, movie.Release, similarReleaseYears,
+	)
+
+	filter := near
+	order := clause.Expr{
+		SQL:                "abs(extract(epoch FROM movies.release - ?::timestamptz)), movies.id",
+		Vars:               []any{movie.Release},
+		WithoutParentheses: true,
+	}
+	if len(genreIDs) > 0 {
+		order.SQL = "(SELECT count(*) FROM title_genres WHERE title_genres.title_type = 'movie' AND title_genres.title_id = movies.id AND title_genres.genre_id IN (?)) DESC, " + order.SQL
+		order.Vars = append([]any{genreIDs}, order.Vars...)
+		filter = filter.Or(repository.Expr(
+			"EXISTS (SELECT 1 FROM title_genres WHERE title_genres.title_type = 'movie' AND title_genres.title_id = movies.id AND title_genres.genre_id IN ?)",
+			genreIDs,
+		))
+	}
+
+	var movies []entity.Movies
+	err := db.Model(&entity.Movies{}).
+		Preload("MovieGenres").
+		Preload("Translations").
+		Where("movies.id <> ?", movie.ID).
+		Scopes(PublishedFilter().Scope, filter.Scope).
+		Order(clause.OrderBy{Expression: order}).
+		Limit(limit).
+		Find(&movies).Error
+	if err != nil {
+		return nil, postgres.Error(err, "SimilarCandidates", &entity.Movies{})
+	}
+
+	return movies, nil
+}
+
+// GenreFrequencies returns how many movies each genre has and how many movies
+// have a genre at all
+func (r *repo) GenreFrequencies(ctx context.Context) (map[int64]int64, int64, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	var rows []struct {
+		GenreID int64
+		Movies  int64
+	}
+	err := db.Table("title_genres").
+		Select("title_genres.genre_id, count(*) AS movies").
+		Joins("JOIN movies ON movies.id = title_genres.title_id AND movies.deleted_at IS NULL").
+		Where("title_genres.title_type = ?", entity.TitleTypeMovie).
+		Group("title_genres.genre_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, postgres.Error(err, "GenreFrequencies", &entity.Movies{})
+	}
+
+	var total int64
+	err = db.Table("title_genres").
+		Joins("JOIN movies ON movies.id = title_genres.title_id AND movies.deleted_at IS NULL").
+		Where("title_genres.title_type = ?", entity.TitleTypeMovie).
+		Distinct("title_genres.title_id").
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, postgres.Error(err, "GenreFrequencies", &entity.Movies{})
+	}
+
+	frequencies := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		frequencies[row.GenreID] = row.Movies
+	}
+
+	return frequencies, total, nil
+}
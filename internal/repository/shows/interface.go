@@ -0,0 +1,21 @@
+package shows
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.Shows]
+	ReplaceGenres(ctx context.Context, show *entity.Shows) error
+}
+
+// GenresFilter matches shows linked to any of genreIDs
+func GenresFilter(genreIDs []int64) repository.Filter {
+	return repository.Expr(
+		"EXISTS (SELECT 1 FROM title_genres WHERE title_genres.title_type = 'show' AND title_genres.title_id = shows.id AND title_genres.genre_id IN ?)",
+		genreIDs,
+	)
+}
@@ -0,0 +1,28 @@
+package shows
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.Shows]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.Shows](db),
+		db:             db,
+	}
+}
+
+// ReplaceGenres syncs title_genres with show.ShowGenres, dropped rows are deleted
+// since title_genres.title_id is part of the primary key
+func (r *repo) ReplaceGenres(ctx context.Context, show *entity.Shows) error {
+	db := repository.FromContext(ctx, r.db)
+	return db.Model(show).Association("ShowGenres").Unscoped().Replace(show.ShowGenres)
+}
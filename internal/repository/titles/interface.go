@@ -0,0 +1,12 @@
+package titles
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// Repository reads movies and shows together
+type Repository interface {
+	Search(ctx context.Context, limit, page uint64, search entity.TitleSearch) (int64, []entity.TitleSearchResult, error)
+}
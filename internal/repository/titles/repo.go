@@ -0,0 +1,81 @@
+package titles
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		db: db,
+	}
+}
+
+// Search matches movie titles, translated ones included, and show titles in one
+// UNION ALL. Exact and prefix matches rank first, then titles alphabetically.
+func (r *repo) Search(ctx context.Context, limit, page uint64, search entity.TitleSearch) (int64, []entity.TitleSearchResult, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	pattern := "%" + search.Query + "%"
+
+	var parts []any
+	if search.Includes(entity.TitleTypeMovie) {
+		filter := repository.Or(
+			repository.ILike("movies.title", pattern),
+			repository.Expr(
+				"EXISTS (SELECT 1 FROM movie_translations WHERE movie_translations.movie_id = movies.id AND movie_translations.title ILIKE ?)",
+				pattern,
+			),
+		).And(movies.VisibleFilter(search.AgeLimit))
+
+		parts = append(parts, db.Model(&entity.Movies{}).
+			Select("'movie' AS type, movies.id, movies.title, movies.release AS released, movies.poster_url").
+			Scopes(filter.Scope))
+	}
+	if search.Includes(entity.TitleTypeShow) {
+		parts = append(parts, db.Model(&entity.Shows{}).
+			Select("'show' AS type, shows.id, shows.title, shows.first_air_date AS released, shows.poster_url").
+			Scopes(repository.ILike("shows.title", pattern).Scope))
+	}
+
+	if len(parts) == 0 {
+		return 0, nil, nil
+	}
+
+	sql := "?"
+	for range parts[1:] {
+		sql += " UNION ALL ?"
+	}
+	union := db.Raw(sql, parts...)
+
+	var total int64
+	if err := db.Table("(?) AS titles", union).Count(&total).Error; err != nil {
+		return 0, nil, postgres.Error(err, "Search", &entity.TitleSearchResult{})
+	}
+
+	var results []entity.TitleSearchResult
+	err := db.Table("(?) AS titles", union).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "lower(titles.title) = lower(?) DESC, titles.title ILIKE ? DESC, titles.title, titles.type, titles.id",
+			Vars:               []any{search.Query, search.Query + "%"},
+			WithoutParentheses: true,
+		}}).
+		Offset(int((page - 1) * limit)).
+		Limit(int(limit)).
+		Scan(&results).Error
+	if err != nil {
+		return 0, nil, postgres.Error(err, "Search", &entity.TitleSearchResult{})
+	}
+
+	return total, results, nil
+}
@@ -0,0 +1,16 @@
+package user_list_items
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.UserListItems]
+	// ListItems returns a page of the list's items in order, keeping those whose movie matches filter
+	ListItems(ctx context.Context, listID int64, limit, page uint64, filter repository.Filter) (int64, []*entity.UserListItems, error)
+	// MaxPosition returns the last position used in the list, 0 when it is empty
+	MaxPosition(ctx context.Context, listID int64) (int, error)
+}
@@ -0,0 +1,65 @@
+package user_list_items
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.UserListItems]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.UserListItems](db),
+		db:             db,
+	}
+}
+
+func (r *repo) ListItems(ctx context.Context, listID int64, limit, page uint64, filter repository.Filter) (int64, []*entity.UserListItems, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	query := db.Model(&entity.UserListItems{}).
+		Joins("JOIN movies ON movies.id = user_list_items.movie_id AND movies.deleted_at IS NULL").
+		Where("user_list_items.list_id = ?", listID).
+		Scopes(filter.Scope)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, nil, postgres.Error(err, "ListItems", &entity.UserListItems{})
+	}
+
+	var items []*entity.UserListItems
+	err := query.
+		Preload("Movie").
+		Preload("Movie.Translations").
+		Order("user_list_items.position, user_list_items.created_at").
+		Offset(int((page - 1) * limit)).
+		Limit(int(limit)).
+		Find(&items).Error
+	if err != nil {
+		return 0, nil, postgres.Error(err, "ListItems", &entity.UserListItems{})
+	}
+
+	return total, items, nil
+}
+
+func (r *repo) MaxPosition(ctx context.Context, listID int64) (int, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	var position int
+	err := db.Model(&entity.UserListItems{}).
+		Where("list_id = ?", listID).
+		Select("COALESCE(max(position), 0)").
+		Scan(&position).Error
+	if err != nil {
+		return 0, postgres.Error(err, "MaxPosition", &entity.UserListItems{})
+	}
+
+	return position, nil
+}
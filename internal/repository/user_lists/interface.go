@@ -0,0 +1,22 @@
+package user_lists
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.UserLists]
+	// RefreshFollowers recounts the list's followers into followers_count
+	RefreshFollowers(ctx context.Context, listID int64) error
+}
+
+// FollowedByFilter matches the lists userID follows
+func FollowedByFilter(userID string) repository.Filter {
+	return repository.Expr(
+		"EXISTS (SELECT 1 FROM user_list_follows WHERE user_list_follows.list_id = user_lists.id AND user_list_follows.user_id = ?)",
+		userID,
+	)
+}
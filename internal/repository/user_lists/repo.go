@@ -0,0 +1,36 @@
+package user_lists
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.UserLists]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.UserLists](db),
+		db:             db,
+	}
+}
+
+func (r *repo) RefreshFollowers(ctx context.Context, listID int64) error {
+	db := repository.FromContext(ctx, r.db)
+
+	err := db.Model(&entity.UserLists{}).
+		Where("id = ?", listID).
+		UpdateColumn("followers_count", gorm.Expr("(SELECT count(*) FROM user_list_follows WHERE user_list_follows.list_id = user_lists.id)")).
+		Error
+	if err != nil {
+		return postgres.Error(err, "RefreshFollowers", &entity.UserLists{})
+	}
+
+	return nil
+}
@@ -17,7 +17,12 @@ func (r *repo) FindByLogin(ctx context.Context, login string) (*entity.Users, er
 	db := repository.FromContext(ctx, r.db)
 	var user *entity.Users
 
-	if err := db.Where("username = ?", login).Or("email = ?", login).First(&user).Error; err != nil {
+	filter := repository.Or(
+		repository.Eq("username", login),
+		repository.Eq("email", login),
+	)
+
+	if err := db.Scopes(filter.Scope).First(&user).Error; err != nil {
 		return nil, postgres.Error(err, "FindByLogin", &entity.Users{})
 	}
 
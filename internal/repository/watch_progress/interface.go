@@ -0,0 +1,16 @@
+package watch_progress
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.WatchProgress]
+	// ContinueWatching pages through the unfinished videos of userID, most recently
+	// watched first. Movies must still exist and match movieFilter, episodes are
+	// only included when withEpisodes is set.
+	ContinueWatching(ctx context.Context, userID string, limit, page uint64, movieFilter repository.Filter, withEpisodes bool) (int64, []*entity.WatchProgress, error)
+}
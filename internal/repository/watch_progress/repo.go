@@ -0,0 +1,59 @@
+package watch_progress
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.WatchProgress]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.WatchProgress](db),
+		db:             db,
+	}
+}
+
+func (r *repo) ContinueWatching(ctx context.Context, userID string, limit, page uint64, movieFilter repository.Filter, withEpisodes bool) (int64, []*entity.WatchProgress, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	movies := db.Session(&gorm.Session{NewDB: true}).
+		Model(&entity.Movies{}).
+		Select("1").
+		Where("movies.id = watch_progress.watchable_id").
+		Scopes(movieFilter.Scope)
+
+	watchable := db.Session(&gorm.Session{NewDB: true}).
+		Where("watch_progress.watchable_type = ? AND EXISTS (?)", entity.WatchableMovie, movies)
+	if withEpisodes {
+		watchable = watchable.Or("watch_progress.watchable_type = ?", entity.WatchableEpisode)
+	}
+
+	query := db.Model(&entity.WatchProgress{}).
+		Where("watch_progress.user_id = ? AND NOT watch_progress.completed AND watch_progress.position_seconds > 0", userID).
+		Where(watchable)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, nil, postgres.Error(err, "ContinueWatching", &entity.WatchProgress{})
+	}
+
+	var progress []*entity.WatchProgress
+	err := query.
+		Order("watch_progress.updated_at DESC").
+		Offset(int((page - 1) * limit)).
+		Limit(int(limit)).
+		Find(&progress).Error
+	if err != nil {
+		return 0, nil, postgres.Error(err, "ContinueWatching", &entity.WatchProgress{})
+	}
+
+	return total, progress, nil
+}
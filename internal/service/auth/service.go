@@ -8,6 +8,7 @@ import (
 
 	"github.com/AsaHero/movie-app-server/internal/entity"
 	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
 	"github.com/AsaHero/movie-app-server/internal/repository/users"
 	"github.com/AsaHero/movie-app-server/pkg/security"
 	"github.com/google/uuid"
@@ -31,9 +32,7 @@ func (s *service) LoginByUsername(ctx context.Context, username, password string
 
 	user, err := s.userRepo.FindOne(
 		ctx,
-		map[string]any{
-			"username": username,
-		},
+		repository.Eq("username", username),
 	)
 	if err != nil {
 		return nil, inerr.Err(err)
@@ -0,0 +1,23 @@
+package charts
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// Service records what users look at and ranks movies by that activity
+type Service interface {
+	// Record queues an event of the user in ctx for a movie and returns at once,
+	// events are written in batches in the background and dropped when the
+	// queue is full
+	Record(ctx context.Context, movieID int64, kind entity.MovieEventKind)
+	// Start begins writing queued events, Stop writes the ones left and returns
+	Start()
+	Stop()
+	// Top returns the movies of chart over period, with their movie loaded
+	Top(ctx context.Context, chart entity.Chart, period entity.ChartPeriod, limit, page uint64) (int64, []*entity.MoviePopularity, error)
+	// RebuildPopularity recomputes the popularity of every movie, purges events
+	// too old to count and returns how many scores were stored
+	RebuildPopularity(ctx context.Context) (int64, error)
+}
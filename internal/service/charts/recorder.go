@@ -0,0 +1,131 @@
+package charts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_events"
+	"github.com/AsaHero/movie-app-server/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// recorderQueueSize is how many events may wait to be written before new ones are dropped
+	recorderQueueSize = 1024
+	// recorderBatchSize and recorderFlushInterval bound how long an event waits
+	recorderBatchSize     = 100
+	recorderFlushInterval = time.Second
+)
+
+// recorder writes movie events from a single goroutine, in batches, so the
+// requests they are recorded from never wait on the database
+type recorder struct {
+	contextTimeout time.Duration
+	eventsRepo     movie_events.Repository
+	events         chan *entity.MovieEvents
+
+	mu   sync.Mutex
+	quit chan struct{}
+	done chan struct{}
+}
+
+func newRecorder(contextTimeout time.Duration, eventsRepo movie_events.Repository) *recorder {
+	return &recorder{
+		contextTimeout: contextTimeout,
+		eventsRepo:     eventsRepo,
+		events:         make(chan *entity.MovieEvents, recorderQueueSize),
+	}
+}
+
+func (r *recorder) record(event *entity.MovieEvents) {
+	select {
+	case r.events <- event:
+	default:
+		logger.Warn("movie events queue is full, dropping event", logrus.Fields{
+			"movie_id": event.MovieID,
+			"kind":     event.Kind,
+		})
+	}
+}
+
+func (r *recorder) start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.quit != nil {
+		return
+	}
+
+	r.quit = make(chan struct{})
+	r.done = make(chan struct{})
+	go r.run(r.quit, r.done)
+}
+
+func (r *recorder) stop() {
+	r.mu.Lock()
+	quit, done := r.quit, r.done
+	r.quit, r.done = nil, nil
+	r.mu.Unlock()
+
+	if quit == nil {
+		return
+	}
+
+	close(quit)
+	<-done
+}
+
+func (r *recorder) run(quit, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(recorderFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*entity.MovieEvents, 0, recorderBatchSize)
+	for {
+		select {
+		case event := <-r.events:
+			batch = append(batch, event)
+			if len(batch) >= recorderBatchSize {
+				batch = r.flush(batch)
+			}
+		case <-ticker.C:
+			batch = r.flush(batch)
+		case <-quit:
+			// write what is already queued, events recorded from now on wait for the next start
+			for {
+				select {
+				case event := <-r.events:
+					batch = append(batch, event)
+					if len(batch) >= recorderBatchSize {
+						batch = r.flush(batch)
+					}
+				default:
+					r.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush writes batch and returns it emptied, events that fail to be written are dropped
+func (r *recorder) flush(batch []*entity.MovieEvents) []*entity.MovieEvents {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.contextTimeout)
+	defer cancel()
+
+	if err := r.eventsRepo.BatchCreate(ctx, batch); err != nil {
+		logger.Error("failed to write movie events", logrus.Fields{
+			"count": len(batch),
+			"error": err.Error(),
+		})
+	}
+
+	return batch[:0]
+}
@@ -0,0 +1,148 @@
+package charts
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_events"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_popularity"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+)
+
+// popularityWeights count opening the player as a stronger signal than looking
+// at the details. Popular scores halve over half a period, trending ones over
+// an eighth so the end of the period dominates.
+var popularityWeights = entity.PopularityWeights{
+	View:             1,
+	Open:             3,
+	HalfLife:         0.5,
+	TrendingHalfLife: 0.125,
+}
+
+type service struct {
+	*recorder
+	contextTimeout  time.Duration
+	eventsRepo      movie_events.Repository
+	popularityRepo  movie_popularity.Repository
+	movieRepo       movies.Repository
+	profilesService profiles.Service
+}
+
+func New(
+	contextTimeout time.Duration,
+	eventsRepo movie_events.Repository,
+	popularityRepo movie_popularity.Repository,
+	movieRepo movies.Repository,
+	profilesService profiles.Service,
+) Service {
+	return &service{
+		recorder:        newRecorder(contextTimeout, eventsRepo),
+		contextTimeout:  contextTimeout,
+		eventsRepo:      eventsRepo,
+		popularityRepo:  popularityRepo,
+		movieRepo:       movieRepo,
+		profilesService: profilesService,
+	}
+}
+
+func (s *service) Record(ctx context.Context, movieID int64, kind entity.MovieEventKind) {
+	userID := security.UserIDFromContext(ctx)
+	if userID == "" {
+		return
+	}
+
+	s.record(&entity.MovieEvents{
+		MovieID:   movieID,
+		UserID:    userID,
+		Kind:      kind,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *service) Start() {
+	s.start()
+}
+
+func (s *service) Stop() {
+	s.stop()
+}
+
+func (s *service) Top(ctx context.Context, chart entity.Chart, period entity.ChartPeriod, limit, page uint64) (int64, []*entity.MoviePopularity, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	filter, err := s.profilesService.VisibleMovies(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	total, ranked, err := s.popularityRepo.Top(ctx, chart, period, limit, page, filter)
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	if err := s.loadMovies(ctx, ranked); err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return total, ranked, nil
+}
+
+func (s *service) RebuildPopularity(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	// the longest period is compared with the one before it, older events never count again
+	longest := entity.ChartPeriods[len(entity.ChartPeriods)-1].Duration()
+	if _, err := s.eventsRepo.PurgeBefore(ctx, time.Now().Add(-2*longest)); err != nil {
+		return 0, inerr.Err(err)
+	}
+
+	stored, err := s.popularityRepo.Rebuild(ctx, entity.ChartPeriods, popularityWeights)
+	if err != nil {
+		return 0, inerr.Err(err)
+	}
+
+	return stored, nil
+}
+
+// loadMovies sets the movie of each ranked popularity
+func (s *service) loadMovies(ctx context.Context, ranked []*entity.MoviePopularity) error {
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(ranked))
+	for _, popularity := range ranked {
+		ids = append(ids, popularity.MovieID)
+	}
+
+	_, found, err := s.movieRepo.FindAll(ctx, 0, 1, "", repository.In("id", ids), "Translations")
+	if err != nil {
+		return err
+	}
+
+	moviesByID := make(map[int64]*entity.Movies, len(found))
+	for _, movie := range found {
+		moviesByID[movie.ID] = movie
+	}
+
+	for _, popularity := range ranked {
+		popularity.Movie = moviesByID[popularity.MovieID]
+	}
+
+	return nil
+}
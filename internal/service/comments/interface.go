@@ -0,0 +1,43 @@
+package comments
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// Service runs the discussion threads of movies for the user in ctx. Only
+// authors change their comments; the moderation methods expect the caller to
+// have checked the user in ctx is an admin.
+type Service interface {
+	// Threads returns a page of a movie's top-level comments, newest first, each
+	// with its first replies
+	Threads(ctx context.Context, movieID int64, cursor string, limit uint64) (*entity.CommentPage, error)
+	// Replies returns a page of the replies of the thread commentID belongs to, oldest first
+	Replies(ctx context.Context, commentID int64, cursor string, limit uint64) (*entity.CommentPage, error)
+	// Create posts a comment, a reply when ParentID is set
+	Create(ctx context.Context, comment *entity.MovieComments) error
+	Edit(ctx context.Context, id int64, body string) (*entity.MovieComments, error)
+	// Delete removes the body of a comment, its replies stay
+	Delete(ctx context.Context, id int64) error
+	Like(ctx context.Context, id int64) error
+	Unlike(ctx context.Context, id int64) error
+	// Report flags a comment to the moderators, reporting again replaces the reason
+	Report(ctx context.Context, id int64, reason string) error
+
+	// Reports returns the open reports, oldest first, with their comment
+	Reports(ctx context.Context, limit, page uint64) (int64, []*entity.MovieCommentReports, error)
+	// Hide and Unhide take a comment out of the discussion or put it back, both
+	// resolve its open reports
+	Hide(ctx context.Context, id int64) error
+	Unhide(ctx context.Context, id int64) error
+	// DismissReports resolves the open reports of a comment and leaves it as it is
+	DismissReports(ctx context.Context, id int64) error
+	// Lock and Unlock stop or allow replies and edits in the thread of a comment
+	Lock(ctx context.Context, id int64) error
+	Unlock(ctx context.Context, id int64) error
+	// Ban keeps a user from commenting until expiresAt, forever when it is nil
+	Ban(ctx context.Context, userID string, reason *string, expiresAt *time.Time) error
+	Unban(ctx context.Context, userID string) error
+}
@@ -0,0 +1,664 @@
+package comments
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/comment_bans"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_comment_likes"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_comment_reports"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_comments"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+)
+
+// previewReplies is how many replies come with each thread of a movie's listing,
+// the rest are paged through Replies
+const previewReplies = 3
+
+type service struct {
+	contextTimeout  time.Duration
+	commentsRepo    movie_comments.Repository
+	likesRepo       movie_comment_likes.Repository
+	reportsRepo     movie_comment_reports.Repository
+	bansRepo        comment_bans.Repository
+	movieRepo       movies.Repository
+	profilesService profiles.Service
+}
+
+func New(
+	contextTimeout time.Duration,
+	commentsRepo movie_comments.Repository,
+	likesRepo movie_comment_likes.Repository,
+	reportsRepo movie_comment_reports.Repository,
+	bansRepo comment_bans.Repository,
+	movieRepo movies.Repository,
+	profilesService profiles.Service,
+) Service {
+	return &service{
+		contextTimeout:  contextTimeout,
+		commentsRepo:    commentsRepo,
+		likesRepo:       likesRepo,
+		reportsRepo:     reportsRepo,
+		bansRepo:        bansRepo,
+		movieRepo:       movieRepo,
+		profilesService: profilesService,
+	}
+}
+
+func (s *service) Threads(ctx context.Context, movieID int64, cursor string, limit uint64) (*entity.CommentPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.visibleMovie(ctx, movieID); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	limit = max(1, min(limit, 100))
+
+	threads, err := s.commentsRepo.Threads(ctx, movieID, after, int(limit)+1)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	page := newPage(threads, int(limit))
+
+	rootIDs := make([]int64, 0, len(page.Comments))
+	byID := make(map[int64]*entity.MovieComments, len(page.Comments))
+	for _, thread := range page.Comments {
+		rootIDs = append(rootIDs, thread.ID)
+		byID[thread.ID] = thread
+	}
+
+	replies, err := s.commentsRepo.FirstReplies(ctx, rootIDs, previewReplies)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	for _, reply := range replies {
+		if thread, ok := byID[reply.ThreadID()]; ok {
+			thread.Replies = append(thread.Replies, reply)
+		}
+	}
+
+	if err := s.markLiked(ctx, slices.Concat(page.Comments, replies)); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return page, nil
+}
+
+func (s *service) Replies(ctx context.Context, commentID int64, cursor string, limit uint64) (*entity.CommentPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentsRepo.FindOne(ctx, repository.Eq("id", commentID))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	if err := s.visibleMovie(ctx, comment.MovieID); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	limit = max(1, min(limit, 100))
+
+	replies, err := s.commentsRepo.Replies(ctx, comment.ThreadID(), after, int(limit)+1)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	page := newPage(replies, int(limit))
+
+	if err := s.markLiked(ctx, page.Comments); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return page, nil
+}
+
+// Create posts a top-level comment or, with ParentID set, a reply to a comment
+// of the same movie that was not removed and whose thread is not locked
+func (s *service) Create(ctx context.Context, comment *entity.MovieComments) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	userID := security.UserIDFromContext(ctx)
+
+	err := s.commentsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkBan(ctx, userID); err != nil {
+			return err
+		}
+
+		if err := s.visibleMovie(ctx, comment.MovieID); err != nil {
+			return err
+		}
+
+		comment.RootID = nil
+		if comment.ParentID != nil {
+			parent, err := s.commentsRepo.FindOne(ctx, repository.Eq("id", *comment.ParentID))
+			if err != nil {
+				return err
+			}
+
+			if parent.MovieID != comment.MovieID {
+				return inerr.ErrorReplyToOtherMovie
+			}
+
+			if parent.IsRemoved() {
+				return inerr.ErrorCommentRemoved
+			}
+
+			if err := s.checkUnlocked(ctx, parent); err != nil {
+				return err
+			}
+
+			rootID := parent.ThreadID()
+			comment.RootID = &rootID
+		}
+
+		now := time.Now()
+		comment.UserID = userID
+		comment.LikesCount = 0
+		comment.RepliesCount = 0
+		comment.CreatedAt = now
+		comment.UpdatedAt = now
+
+		if err := s.commentsRepo.Create(ctx, comment); err != nil {
+			return err
+		}
+
+		if comment.RootID != nil {
+			return s.commentsRepo.RefreshReplies(ctx, *comment.RootID)
+		}
+		return nil
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	created, err := s.commentsRepo.FindOne(ctx, repository.Eq("id", comment.ID), "User")
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	*comment = *created
+	return nil
+}
+
+// Edit replaces the body of a comment of the user in ctx, unless it was removed or its thread is locked
+func (s *service) Edit(ctx context.Context, id int64, body string) (*entity.MovieComments, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	userID := security.UserIDFromContext(ctx)
+
+	err := s.commentsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkBan(ctx, userID); err != nil {
+			return err
+		}
+
+		comment, err := s.authored(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if comment.IsRemoved() {
+			return inerr.ErrorCommentRemoved
+		}
+
+		if err := s.checkUnlocked(ctx, comment); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return s.commentsRepo.UpdateDataWhere(ctx, map[string]any{
+			"body":       body,
+			"edited_at":  now,
+			"updated_at": now,
+		}, repository.Eq("id", id))
+	})
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	comment, err := s.commentsRepo.FindOne(ctx, repository.Eq("id", id), "User")
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return comment, nil
+}
+
+func (s *service) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.commentsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		comment, err := s.authored(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if comment.DeletedAt != nil {
+			return nil
+		}
+
+		now := time.Now()
+		err = s.commentsRepo.UpdateDataWhere(ctx, map[string]any{
+			"body":       "",
+			"deleted_at": now,
+			"updated_at": now,
+		}, repository.Eq("id", id))
+		if err != nil {
+			return err
+		}
+
+		return s.refreshThread(ctx, comment)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// Like marks a comment as liked by the user in ctx, liking twice is a no-op
+func (s *service) Like(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	userID := security.UserIDFromContext(ctx)
+
+	err := s.commentsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		comment, err := s.reachable(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if comment.IsRemoved() {
+			return inerr.ErrorCommentRemoved
+		}
+
+		_, err = s.likesRepo.FindOne(ctx, likeFilter(id, userID))
+		if err == nil {
+			return nil
+		}
+		if !inerr.IsErrNotFound(err) {
+			return err
+		}
+
+		err = s.likesRepo.Create(ctx, &entity.MovieCommentLikes{
+			CommentID: id,
+			UserID:    userID,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.commentsRepo.RefreshLikes(ctx, id)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Unlike(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.commentsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.likesRepo.Delete(ctx, likeFilter(id, security.UserIDFromContext(ctx))); err != nil {
+			return err
+		}
+
+		return s.commentsRepo.RefreshLikes(ctx, id)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Report(ctx context.Context, id int64, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	userID := security.UserIDFromContext(ctx)
+
+	if _, err := s.reachable(ctx, id, userID); err != nil {
+		return inerr.Err(err)
+	}
+
+	// reporting again reopens a resolved report
+	err := s.reportsRepo.Upsert(ctx, []string{"reason", "created_at", "resolved_at", "resolved_by"}, &entity.MovieCommentReports{
+		CommentID: id,
+		UserID:    userID,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}, "comment_id", "user_id")
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Reports(ctx context.Context, limit, page uint64) (int64, []*entity.MovieCommentReports, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	total, reports, err := s.reportsRepo.FindAll(ctx, limit, page, "created_at, comment_id, user_id",
+		repository.IsNull("resolved_at"), "Comment", "Comment.User")
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return int64(total), reports, nil
+}
+
+func (s *service) Hide(ctx context.Context, id int64) error {
+	moderatorID := security.UserIDFromContext(ctx)
+
+	return s.moderate(ctx, id, func(ctx context.Context, comment *entity.MovieComments) error {
+		if comment.HiddenAt != nil {
+			return nil
+		}
+
+		return s.setHidden(ctx, comment, map[string]any{
+			"hidden_at": time.Now(),
+			"hidden_by": moderatorID,
+		})
+	})
+}
+
+func (s *service) Unhide(ctx context.Context, id int64) error {
+	return s.moderate(ctx, id, func(ctx context.Context, comment *entity.MovieComments) error {
+		if comment.HiddenAt == nil {
+			return nil
+		}
+
+		return s.setHidden(ctx, comment, map[string]any{
+			"hidden_at": nil,
+			"hidden_by": nil,
+		})
+	})
+}
+
+func (s *service) DismissReports(ctx context.Context, id int64) error {
+	return s.moderate(ctx, id, func(ctx context.Context, comment *entity.MovieComments) error {
+		return nil
+	})
+}
+
+func (s *service) Lock(ctx context.Context, id int64) error {
+	moderatorID := security.UserIDFromContext(ctx)
+
+	return s.setLocked(ctx, id, map[string]any{
+		"locked_at": time.Now(),
+		"locked_by": moderatorID,
+	})
+}
+
+func (s *service) Unlock(ctx context.Context, id int64) error {
+	return s.setLocked(ctx, id, map[string]any{
+		"locked_at": nil,
+		"locked_by": nil,
+	})
+}
+
+// Ban keeps userID from posting and editing comments, banning again replaces the ban
+func (s *service) Ban(ctx context.Context, userID string, reason *string, expiresAt *time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.bansRepo.Upsert(ctx, []string{"banned_by", "reason", "expires_at", "created_at"}, &entity.CommentBans{
+		UserID:    userID,
+		BannedBy:  security.UserIDFromContext(ctx),
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}, "user_id")
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Unban(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.bansRepo.Delete(ctx, repository.Eq("user_id", userID)); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// moderate runs fn on a comment and resolves its open reports in one transaction
+func (s *service) moderate(ctx context.Context, id int64, fn func(ctx context.Context, comment *entity.MovieComments) error) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	moderatorID := security.UserIDFromContext(ctx)
+
+	err := s.commentsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		comment, err := s.commentsRepo.FindOne(ctx, repository.Eq("id", id))
+		if err != nil {
+			return err
+		}
+
+		if err := fn(ctx, comment); err != nil {
+			return err
+		}
+
+		return s.reportsRepo.UpdateDataWhere(ctx, map[string]any{
+			"resolved_at": time.Now(),
+			"resolved_by": moderatorID,
+		}, repository.And(
+			repository.Eq("comment_id", id),
+			repository.IsNull("resolved_at"),
+		))
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) setHidden(ctx context.Context, comment *entity.MovieComments, data map[string]any) error {
+	data["updated_at"] = time.Now()
+	if err := s.commentsRepo.UpdateDataWhere(ctx, data, repository.Eq("id", comment.ID)); err != nil {
+		return err
+	}
+
+	return s.refreshThread(ctx, comment)
+}
+
+// setLocked updates the root of the thread the comment belongs to
+func (s *service) setLocked(ctx context.Context, id int64, data map[string]any) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.commentsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		comment, err := s.commentsRepo.FindOne(ctx, repository.Eq("id", id))
+		if err != nil {
+			return err
+		}
+
+		data["updated_at"] = time.Now()
+		return s.commentsRepo.UpdateDataWhere(ctx, data, repository.Eq("id", comment.ThreadID()))
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// refreshThread recounts the replies of the thread a reply belongs to
+func (s *service) refreshThread(ctx context.Context, comment *entity.MovieComments) error {
+	if comment.RootID == nil {
+		return nil
+	}
+	return s.commentsRepo.RefreshReplies(ctx, *comment.RootID)
+}
+
+// authored finds a comment and checks the user in ctx wrote it
+func (s *service) authored(ctx context.Context, id int64) (*entity.MovieComments, error) {
+	comment, err := s.commentsRepo.FindOne(ctx, repository.Eq("id", id))
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != security.UserIDFromContext(ctx) {
+		return nil, inerr.ErrorNotCommentAuthor
+	}
+
+	return comment, nil
+}
+
+func (s *service) checkBan(ctx context.Context, userID string) error {
+	ban, err := s.bansRepo.FindOne(ctx, repository.Eq("user_id", userID))
+	if inerr.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if ban.IsActive(time.Now()) {
+		return inerr.ErrorCommentBanned
+	}
+	return nil
+}
+
+// checkUnlocked fails when the thread of comment is locked
+func (s *service) checkUnlocked(ctx context.Context, comment *entity.MovieComments) error {
+	root := comment
+	if comment.RootID != nil {
+		var err error
+		if root, err = s.commentsRepo.FindOne(ctx, repository.Eq("id", *comment.RootID)); err != nil {
+			return err
+		}
+	}
+
+	if root.LockedAt != nil {
+		return inerr.ErrorThreadLocked
+	}
+	return nil
+}
+
+// markLiked sets Liked on the comments the user in ctx liked
+func (s *service) markLiked(ctx context.Context, comments []*entity.MovieComments) error {
+	ids := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+
+	liked, err := s.likesRepo.Liked(ctx, security.UserIDFromContext(ctx), ids)
+	if err != nil {
+		return err
+	}
+
+	likedIDs := make(map[int64]bool, len(liked))
+	for _, id := range liked {
+		likedIDs[id] = true
+	}
+
+	for _, comment := range comments {
+		comment.Liked = likedIDs[comment.ID]
+	}
+
+	return nil
+}
+
+// reachable finds a comment the user may react to, with the same checks as
+// posting one: the user is not banned and the viewing profile may see the movie
+func (s *service) reachable(ctx context.Context, id int64, userID string) (*entity.MovieComments, error) {
+	if err := s.checkBan(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentsRepo.FindOne(ctx, repository.Eq("id", id))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.visibleMovie(ctx, comment.MovieID); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// visibleMovie checks the movie exists and the viewing profile may see it
+func (s *service) visibleMovie(ctx context.Context, movieID int64) error {
+	visible, err := s.profilesService.VisibleMovies(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.movieRepo.FindOne(ctx, repository.Eq("id", movieID).And(visible))
+	return err
+}
+
+func likeFilter(commentID int64, userID string) repository.Filter {
+	return repository.And(
+		repository.Eq("comment_id", commentID),
+		repository.Eq("user_id", userID),
+	)
+}
+
+func parseCursor(cursor string) (*entity.CommentCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	after, ok := entity.ParseCommentCursor(cursor)
+	if !ok {
+		return nil, inerr.ErrorInvalidCursor
+	}
+	return after, nil
+}
+
+// newPage keeps the first limit comments, fetched with one more to know whether a next page exists
+func newPage(comments []*entity.MovieComments, limit int) *entity.CommentPage {
+	page := &entity.CommentPage{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		last := page.Comments[limit-1]
+		page.NextCursor = entity.CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return page
+}
@@ -8,4 +8,7 @@ import (
 
 type Service interface {
 	GetAll(ctx context.Context) ([]*entity.Genres, error)
+	ListTranslations(ctx context.Context, genreID int64) ([]*entity.GenreTranslations, error)
+	SetTranslation(ctx context.Context, translation *entity.GenreTranslations) error
+	RemoveTranslation(ctx context.Context, genreID int64, locale string) error
 }
@@ -6,18 +6,25 @@ import (
 
 	"github.com/AsaHero/movie-app-server/internal/entity"
 	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/genre_translations"
 	"github.com/AsaHero/movie-app-server/internal/repository/genres"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
 )
 
 type service struct {
-	contextTimeout time.Duration
-	genresRepo     genres.Repository
+	contextTimeout   time.Duration
+	genresRepo       genres.Repository
+	translationsRepo genre_translations.Repository
+	movieRepo        movies.Repository
 }
 
-func New(contextTimeout time.Duration, genresRepo genres.Repository) Service {
+func New(contextTimeout time.Duration, genresRepo genres.Repository, translationsRepo genre_translations.Repository, movieRepo movies.Repository) Service {
 	return &service{
-		contextTimeout: contextTimeout,
-		genresRepo:     genresRepo,
+		contextTimeout:   contextTimeout,
+		genresRepo:       genresRepo,
+		translationsRepo: translationsRepo,
+		movieRepo:        movieRepo,
 	}
 }
 
@@ -25,10 +32,77 @@ func (s *service) GetAll(ctx context.Context) ([]*entity.Genres, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
 
-	_, genres, err := s.genresRepo.FindAll(ctx, 0, 0, "", map[string]any{})
+	_, genres, err := s.genresRepo.FindAll(ctx, 0, 0, "", repository.Filter{}, "Translations")
 	if err != nil {
 		return nil, inerr.Err(err)
 	}
 
 	return genres, err
 }
+
+func (s *service) ListTranslations(ctx context.Context, genreID int64) ([]*entity.GenreTranslations, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if _, err := s.genresRepo.FindOne(ctx, repository.Eq("id", genreID)); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	_, translations, err := s.translationsRepo.FindAll(ctx, 0, 0, "locale", repository.Eq("genre_id", genreID))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return translations, nil
+}
+
+// SetTranslation creates or replaces the genre's name for translation.Locale.
+// The movies of the genre get a new version since their localized responses change.
+func (s *service) SetTranslation(ctx context.Context, translation *entity.GenreTranslations) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	translation.CreatedAt = now
+	translation.UpdatedAt = now
+
+	err := s.genresRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.genresRepo.FindOne(ctx, repository.Eq("id", translation.GenreID)); err != nil {
+			return err
+		}
+
+		if err := s.translationsRepo.Upsert(ctx, []string{"name", "updated_at"}, translation, "genre_id", "locale"); err != nil {
+			return err
+		}
+
+		return s.movieRepo.TouchGenre(ctx, translation.GenreID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// RemoveTranslation deletes the genre's name for locale and bumps the versions of its movies
+func (s *service) RemoveTranslation(ctx context.Context, genreID int64, locale string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.genresRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := s.translationsRepo.Delete(ctx, repository.And(
+			repository.Eq("genre_id", genreID),
+			repository.Eq("locale", locale),
+		))
+		if err != nil {
+			return err
+		}
+
+		return s.movieRepo.TouchGenre(ctx, genreID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
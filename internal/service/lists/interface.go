@@ -0,0 +1,27 @@
+package lists
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// Service manages the movie lists of the user in ctx and reads the lists of
+// others. Lists of other users are only managed by their owner; private ones
+// are reported as not found to everyone else.
+type Service interface {
+	Browse(ctx context.Context, limit, page uint64, order entity.ListOrder, search string) (int64, []*entity.UserLists, error)
+	Mine(ctx context.Context, limit, page uint64) (int64, []*entity.UserLists, error)
+	Following(ctx context.Context, limit, page uint64) (int64, []*entity.UserLists, error)
+	Get(ctx context.Context, id int64) (*entity.UserLists, error)
+	GetBySlug(ctx context.Context, slug string) (*entity.UserLists, error)
+	Items(ctx context.Context, listID int64, limit, page uint64) (int64, []*entity.UserListItems, error)
+	Create(ctx context.Context, list *entity.UserLists) error
+	Update(ctx context.Context, list *entity.UserLists) error
+	Delete(ctx context.Context, id int64) error
+	SetItem(ctx context.Context, item *entity.UserListItems) error
+	RemoveItem(ctx context.Context, listID, movieID int64) error
+	Reorder(ctx context.Context, listID int64, movieIDs []int64) error
+	Follow(ctx context.Context, listID int64) error
+	Unfollow(ctx context.Context, listID int64) error
+}
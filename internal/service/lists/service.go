@@ -0,0 +1,456 @@
+package lists
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/internal/repository/user_list_follows"
+	"github.com/AsaHero/movie-app-server/internal/repository/user_list_items"
+	"github.com/AsaHero/movie-app-server/internal/repository/user_lists"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+	"github.com/google/uuid"
+)
+
+// maxSlugBase keeps slugs, with their random suffix, within the column size
+const maxSlugBase = 80
+
+type service struct {
+	contextTimeout  time.Duration
+	listsRepo       user_lists.Repository
+	itemsRepo       user_list_items.Repository
+	followsRepo     user_list_follows.Repository
+	movieRepo       movies.Repository
+	profilesService profiles.Service
+}
+
+func New(
+	contextTimeout time.Duration,
+	listsRepo user_lists.Repository,
+	itemsRepo user_list_items.Repository,
+	followsRepo user_list_follows.Repository,
+	movieRepo movies.Repository,
+	profilesService profiles.Service,
+) Service {
+	return &service{
+		contextTimeout:  contextTimeout,
+		listsRepo:       listsRepo,
+		itemsRepo:       itemsRepo,
+		followsRepo:     followsRepo,
+		movieRepo:       movieRepo,
+		profilesService: profilesService,
+	}
+}
+
+// Browse returns public lists, the most followed or the most recently updated first
+func (s *service) Browse(ctx context.Context, limit, page uint64, order entity.ListOrder, search string) (int64, []*entity.UserLists, error) {
+	filter := repository.Eq("visibility", entity.ListVisibilityPublic)
+	if search != "" {
+		filter = filter.And(repository.ILike("name", "%"+search+"%"))
+	}
+
+	orderBy := "followers_count DESC, updated_at DESC, id"
+	if order == entity.ListOrderRecent {
+		orderBy = "updated_at DESC, id"
+	}
+
+	return s.find(ctx, limit, page, orderBy, filter)
+}
+
+// Mine returns every list of the user in ctx, whatever its visibility
+func (s *service) Mine(ctx context.Context, limit, page uint64) (int64, []*entity.UserLists, error) {
+	return s.find(ctx, limit, page, "updated_at DESC, id", repository.Eq("user_id", security.UserIDFromContext(ctx)))
+}
+
+// Following returns the lists the user in ctx follows that are still open to them
+func (s *service) Following(ctx context.Context, limit, page uint64) (int64, []*entity.UserLists, error) {
+	userID := security.UserIDFromContext(ctx)
+
+	return s.find(ctx, limit, page, "updated_at DESC, id", repository.And(
+		user_lists.FollowedByFilter(userID),
+		repository.Neq("visibility", entity.ListVisibilityPrivate),
+	))
+}
+
+func (s *service) find(ctx context.Context, limit, page uint64, orderBy string, filter repository.Filter) (int64, []*entity.UserLists, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	total, lists, err := s.listsRepo.FindAll(ctx, limit, page, orderBy, filter, "User")
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return int64(total), lists, nil
+}
+
+func (s *service) Get(ctx context.Context, id int64) (*entity.UserLists, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	return s.open(ctx, repository.Eq("id", id))
+}
+
+// GetBySlug returns a public or unlisted list, or a private one of the user in ctx
+func (s *service) GetBySlug(ctx context.Context, slug string) (*entity.UserLists, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	return s.open(ctx, repository.Eq("slug", slug))
+}
+
+// Items returns a page of the list's movies that the viewing profile may see
+func (s *service) Items(ctx context.Context, listID int64, limit, page uint64) (int64, []*entity.UserListItems, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if _, err := s.open(ctx, repository.Eq("id", listID)); err != nil {
+		return 0, nil, err
+	}
+
+	filter, err := s.profilesService.VisibleMovies(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	total, items, err := s.itemsRepo.ListItems(ctx, listID, limit, page, filter)
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return total, items, nil
+}
+
+func (s *service) Create(ctx context.Context, list *entity.UserLists) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	list.UserID = security.UserIDFromContext(ctx)
+	list.Slug = newSlug(list.Name)
+	list.FollowersCount = 0
+	list.CreatedAt = now
+	list.UpdatedAt = now
+
+	if err := s.listsRepo.Create(ctx, list); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// Update replaces the list's name, description and visibility. The slug is kept
+// so shared links stay valid after a rename.
+func (s *service) Update(ctx context.Context, list *entity.UserLists) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.listsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.owned(ctx, list.ID); err != nil {
+			return err
+		}
+
+		return s.listsRepo.UpdateDataWhere(ctx, map[string]any{
+			"name":        list.Name,
+			"description": list.Description,
+			"visibility":  list.Visibility,
+			"updated_at":  time.Now(),
+		}, repository.Eq("id", list.ID))
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.listsRepo.Delete(ctx, repository.And(
+		repository.Eq("id", id),
+		repository.Eq("user_id", security.UserIDFromContext(ctx)),
+	))
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// SetItem adds a movie at the end of the list or, when it is already there, replaces its note
+func (s *service) SetItem(ctx context.Context, item *entity.UserListItems) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	movieFilter, err := s.profilesService.VisibleMovies(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	err = s.listsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.owned(ctx, item.ListID); err != nil {
+			return err
+		}
+
+		if _, err := s.movieRepo.FindOne(ctx, repository.Eq("id", item.MovieID).And(movieFilter)); err != nil {
+			return err
+		}
+
+		filter := itemFilter(item.ListID, item.MovieID)
+
+		_, err := s.itemsRepo.FindOne(ctx, filter)
+		switch {
+		case err == nil:
+			err = s.itemsRepo.UpdateDataWhere(ctx, map[string]any{
+				"note":       item.Note,
+				"updated_at": now,
+			}, filter)
+		case inerr.IsErrNotFound(err):
+			var position int
+			position, err = s.itemsRepo.MaxPosition(ctx, item.ListID)
+			if err != nil {
+				return err
+			}
+
+			item.Position = position + 1
+			item.CreatedAt = now
+			item.UpdatedAt = now
+			err = s.itemsRepo.Create(ctx, item)
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.touch(ctx, item.ListID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) RemoveItem(ctx context.Context, listID, movieID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.listsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.owned(ctx, listID); err != nil {
+			return err
+		}
+
+		if err := s.itemsRepo.Delete(ctx, itemFilter(listID, movieID)); err != nil {
+			return err
+		}
+
+		return s.touch(ctx, listID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// Reorder sets the order of the list's movies, movieIDs must name each of them once
+func (s *service) Reorder(ctx context.Context, listID int64, movieIDs []int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.listsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.owned(ctx, listID); err != nil {
+			return err
+		}
+
+		_, items, err := s.itemsRepo.FindAll(ctx, 0, 0, "", repository.Eq("list_id", listID))
+		if err != nil {
+			return err
+		}
+
+		current := make([]int64, 0, len(items))
+		for _, item := range items {
+			current = append(current, item.MovieID)
+		}
+
+		requested := slices.Clone(movieIDs)
+		slices.Sort(current)
+		slices.Sort(requested)
+		requested = slices.Compact(requested)
+		if len(requested) != len(movieIDs) || !slices.Equal(current, requested) {
+			return inerr.ErrorListOrderMismatch
+		}
+
+		now := time.Now()
+		for i, movieID := range movieIDs {
+			err := s.itemsRepo.UpdateDataWhere(ctx, map[string]any{
+				"position":   i + 1,
+				"updated_at": now,
+			}, itemFilter(listID, movieID))
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.touch(ctx, listID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// Follow subscribes the user in ctx to a list open to them, following twice is a no-op
+func (s *service) Follow(ctx context.Context, listID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	userID := security.UserIDFromContext(ctx)
+
+	err := s.listsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		list, err := s.open(ctx, repository.Eq("id", listID))
+		if err != nil {
+			return err
+		}
+
+		if list.UserID == userID {
+			return inerr.ErrorFollowOwnList
+		}
+
+		_, err = s.followsRepo.FindOne(ctx, followFilter(listID, userID))
+		if err == nil {
+			return nil
+		}
+		if !inerr.IsErrNotFound(err) {
+			return err
+		}
+
+		err = s.followsRepo.Create(ctx, &entity.UserListFollows{
+			ListID:    listID,
+			UserID:    userID,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.listsRepo.RefreshFollowers(ctx, listID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Unfollow(ctx context.Context, listID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.listsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.followsRepo.Delete(ctx, followFilter(listID, security.UserIDFromContext(ctx))); err != nil {
+			return err
+		}
+
+		return s.listsRepo.RefreshFollowers(ctx, listID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// open finds a list the user in ctx may read, private lists of others are not found
+func (s *service) open(ctx context.Context, filter repository.Filter) (*entity.UserLists, error) {
+	list, err := s.listsRepo.FindOne(ctx, filter, "User")
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	if !list.OpenTo(security.UserIDFromContext(ctx)) {
+		return nil, inerr.NewErrNotFound("UserLists")
+	}
+
+	return list, nil
+}
+
+// owned finds a list of the user in ctx
+func (s *service) owned(ctx context.Context, id int64) (*entity.UserLists, error) {
+	return s.listsRepo.FindOne(ctx, repository.And(
+		repository.Eq("id", id),
+		repository.Eq("user_id", security.UserIDFromContext(ctx)),
+	))
+}
+
+func (s *service) touch(ctx context.Context, listID int64) error {
+	return s.listsRepo.UpdateDataWhere(ctx, map[string]any{"updated_at": time.Now()}, repository.Eq("id", listID))
+}
+
+func itemFilter(listID, movieID int64) repository.Filter {
+	return repository.And(
+		repository.Eq("list_id", listID),
+		repository.Eq("movie_id", movieID),
+	)
+}
+
+func followFilter(listID int64, userID string) repository.Filter {
+	return repository.And(
+		repository.Eq("list_id", listID),
+		repository.Eq("user_id", userID),
+	)
+}
+
+// newSlug builds a URL friendly slug from name with a random suffix, e.g.
+// best-90s-thrillers-3f9a1c2e, so lists with the same name get distinct links
+func newSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= maxSlugBase {
+			break
+		}
+	}
+
+	base := strings.Trim(b.String(), "-")
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:8]
+	if base == "" {
+		return suffix
+	}
+	return base + "-" + suffix
+}
@@ -0,0 +1,221 @@
+package movies
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+func (s *service) ListCollections(ctx context.Context, limit, page uint64) (int64, []*entity.Collections, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	total, collections, err := s.collectionsRepo.FindAll(ctx, limit, page, "name, id", repository.Filter{})
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return int64(total), collections, nil
+}
+
+// GetCollection returns the collection with the member movies the viewing profile may see, in order
+func (s *service) GetCollection(ctx context.Context, id int64) (*entity.Collections, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	collection, err := s.collectionsRepo.FindOne(ctx, repository.Eq("id", id))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	filter, err := s.visible(ctx, repository.Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.collectionMoviesRepo.ListMembers(ctx, id, filter)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	collection.Members = make([]entity.CollectionMovies, 0, len(members))
+	for _, member := range members {
+		collection.Members = append(collection.Members, *member)
+	}
+
+	return collection, nil
+}
+
+func (s *service) CreateCollection(ctx context.Context, collection *entity.Collections) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	collection.CreatedAt = now
+	collection.UpdatedAt = now
+
+	if err := s.collectionsRepo.Create(ctx, collection); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// UpdateCollection replaces the collection's fields, its members are left untouched
+func (s *service) UpdateCollection(ctx context.Context, collection *entity.Collections) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.collectionsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.collectionsRepo.FindOne(ctx, repository.Eq("id", collection.ID), "Members")
+		if err != nil {
+			return err
+		}
+
+		collection.CreatedAt = existing.CreatedAt
+		collection.UpdatedAt = time.Now()
+
+		if err := s.collectionsRepo.Update(ctx, collection); err != nil {
+			return err
+		}
+
+		// member movies show the collection name, so their cached copies are stale
+		return s.touchMembers(ctx, memberIDs(existing.Members), nil)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) DeleteCollection(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.collectionsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.collectionsRepo.FindOne(ctx, repository.Eq("id", id), "Members")
+		if err != nil {
+			return err
+		}
+
+		if err := s.collectionsRepo.Delete(ctx, repository.Eq("id", id)); err != nil {
+			return err
+		}
+
+		return s.touchMembers(ctx, memberIDs(existing.Members), nil)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// SetCollectionMovies replaces the collection's members with movieIDs, in that
+// order. A movie already in another collection is reported as a conflict.
+func (s *service) SetCollectionMovies(ctx context.Context, id int64, movieIDs []int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.collectionsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.collectionsRepo.FindOne(ctx, repository.Eq("id", id), "Members")
+		if err != nil {
+			return err
+		}
+
+		if len(existing.Members) > 0 {
+			if err := s.collectionMoviesRepo.Delete(ctx, repository.Eq("collection_id", id)); err != nil {
+				return err
+			}
+		}
+
+		if err := s.touchMembers(ctx, memberIDs(existing.Members), movieIDs); err != nil {
+			return err
+		}
+
+		if len(movieIDs) == 0 {
+			return nil
+		}
+
+		members := make([]*entity.CollectionMovies, 0, len(movieIDs))
+		for i, movieID := range movieIDs {
+			members = append(members, &entity.CollectionMovies{
+				CollectionID: id,
+				MovieID:      movieID,
+				Position:     i + 1,
+			})
+		}
+
+		return s.collectionMoviesRepo.BatchCreate(ctx, members)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// touchMembers bumps the version of every movie in added, which must exist, and
+// of the ones in removed that still do
+func (s *service) touchMembers(ctx context.Context, removed, added []int64) error {
+	for _, movieID := range added {
+		if err := s.movieRepo.Touch(ctx, movieID); err != nil {
+			return err
+		}
+	}
+
+	for _, movieID := range removed {
+		if slices.Contains(added, movieID) {
+			continue
+		}
+		if err := s.movieRepo.Touch(ctx, movieID); err != nil && !inerr.IsErrNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadNeighbours sets the previous and next entries of the movie's collection
+// among the movies the viewing profile may see
+func (s *service) loadNeighbours(ctx context.Context, movie *entity.Movies) error {
+	if movie.Collection == nil {
+		return nil
+	}
+
+	filter, err := s.visible(ctx, repository.Filter{})
+	if err != nil {
+		return err
+	}
+
+	prev, next, err := s.collectionMoviesRepo.Neighbours(ctx, movie.Collection.CollectionID, movie.Collection.Position, filter)
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	movie.Collection.Previous = prev
+	movie.Collection.Next = next
+
+	return nil
+}
+
+func memberIDs(members []entity.CollectionMovies) []int64 {
+	ids := make([]int64, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.MovieID)
+	}
+	return ids
+}
@@ -0,0 +1,191 @@
+package movies
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+)
+
+// minDuplicateScore keeps the candidates whose release year or duration
+// differ a little, e.g. a year apart with the same runtime scores 0.75
+const minDuplicateScore = 0.6
+
+func (s *service) Duplicates(ctx context.Context, limit, page uint64) (int64, []*entity.DuplicateCandidate, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	total, candidates, err := s.movieRepo.DuplicateCandidates(ctx, limit, page, minDuplicateScore)
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	if len(candidates) == 0 {
+		return total, candidates, nil
+	}
+
+	ids := make([]int64, 0, 2*len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.MovieID, candidate.DuplicateID)
+	}
+
+	_, movies, err := s.movieRepo.FindAll(ctx, 0, 1, "", repository.In("id", ids), "ExternalIDs")
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	moviesByID := make(map[int64]*entity.Movies, len(movies))
+	for _, movie := range movies {
+		s.resolveImageURLs(movie)
+		moviesByID[movie.ID] = movie
+	}
+
+	for _, candidate := range candidates {
+		candidate.Movie = moviesByID[candidate.MovieID]
+		candidate.Duplicate = moviesByID[candidate.DuplicateID]
+	}
+
+	return total, candidates, nil
+}
+
+// DismissDuplicate records that the two movies are different, so the pair is
+// no longer offered as a candidate
+func (s *service) DismissDuplicate(ctx context.Context, movieID, duplicateID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if movieID == duplicateID {
+		return inerr.ErrorMergeSameMovie
+	}
+
+	dismissal := &entity.MovieDuplicateDismissals{
+		MovieID:     min(movieID, duplicateID),
+		DuplicateID: max(movieID, duplicateID),
+		CreatedAt:   time.Now(),
+	}
+	if userID := security.UserIDFromContext(ctx); userID != "" {
+		dismissal.DismissedBy = &userID
+	}
+
+	err := s.dismissalsRepo.Upsert(ctx, []string{"dismissed_by", "created_at"}, dismissal, "movie_id", "duplicate_id")
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// Merge folds the duplicate into the survivor: its genres, ratings, comments,
+// list entries and external ids move over, the duplicate goes to the trash and
+// its id redirects to the survivor, as do the ids merged into it before.
+func (s *service) Merge(ctx context.Context, survivorID, duplicateID int64) (*entity.Movies, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if survivorID == duplicateID {
+		return nil, inerr.ErrorMergeSameMovie
+	}
+
+	var movie *entity.Movies
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.movieRepo.FindOne(ctx, repository.Eq("id", survivorID), "MovieGenres")
+		if err != nil {
+			return err
+		}
+
+		duplicate, err := s.movieRepo.FindOne(ctx, repository.Eq("id", duplicateID), "MovieGenres")
+		if err != nil {
+			return err
+		}
+
+		if err := s.movieRepo.MergeInto(ctx, survivorID, duplicateID); err != nil {
+			return err
+		}
+
+		if err := s.movieRepo.Delete(ctx, repository.Eq("id", duplicateID)); err != nil {
+			return err
+		}
+
+		if err := s.redirectsRepo.UpdateDataWhere(ctx, map[string]any{"target_id": survivorID}, repository.Eq("target_id", duplicateID)); err != nil {
+			return err
+		}
+
+		// the survivor may itself have been merged away once and restored since,
+		// its old redirect would now point back at the duplicate
+		if err := s.redirectsRepo.Delete(ctx, repository.Eq("movie_id", survivorID)); err != nil && !inerr.IsErrNotFound(err) {
+			return err
+		}
+
+		redirect := &entity.MovieRedirects{
+			MovieID:   duplicateID,
+			TargetID:  survivorID,
+			CreatedAt: time.Now(),
+		}
+		if userID := security.UserIDFromContext(ctx); userID != "" {
+			redirect.MergedBy = &userID
+		}
+
+		// a movie restored from the trash after a merge may be merged again
+		if err := s.redirectsRepo.Upsert(ctx, []string{"target_id", "merged_by", "created_at"}, redirect, "movie_id"); err != nil {
+			return err
+		}
+
+		if err := s.movieRepo.Touch(ctx, survivorID); err != nil {
+			return err
+		}
+
+		after, err := s.movieRepo.FindOne(ctx, repository.Eq("id", survivorID), "MovieGenres")
+		if err != nil {
+			return err
+		}
+
+		if err := s.audit(ctx, entity.AuditActionDelete, duplicateID, duplicate, nil); err != nil {
+			return err
+		}
+
+		if err := s.audit(ctx, entity.AuditActionMerge, survivorID, before, after); err != nil {
+			return err
+		}
+
+		movie, err = s.movieRepo.FindOne(ctx, repository.Eq("id", survivorID), movieDetails...)
+		if err != nil {
+			return err
+		}
+
+		return s.loadNeighbours(ctx, movie)
+	})
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+	s.resolveImageURLs(movie)
+
+	return movie, nil
+}
+
+// RedirectTarget returns the movie the given id was merged into, not found when
+// it was never merged
+func (s *service) RedirectTarget(ctx context.Context, id int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	redirect, err := s.redirectsRepo.FindOne(ctx, repository.Eq("movie_id", id))
+	if inerr.IsErrNotFound(err) {
+		return 0, err
+	}
+	if err != nil {
+		return 0, inerr.Err(err)
+	}
+
+	return redirect.TargetID, nil
+}
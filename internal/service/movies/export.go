@@ -0,0 +1,26 @@
+package movies
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+)
+
+// exportBatchSize is the number of movies loaded per round trip during an export
+const exportBatchSize = 500
+
+// Export passes every movie matching filters to fn in batches. It is bound by the
+// caller's context rather than contextTimeout since a full catalogue can take longer.
+func (s *service) Export(ctx context.Context, filters entity.MovieFilters, fn func(movies []entity.Movies) error) error {
+	filters, err := s.restrict(ctx, filters)
+	if err != nil {
+		return err
+	}
+
+	if err := s.movieRepo.StreamWithFilters(ctx, filters, exportBatchSize, fn); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package movies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+func (s *service) GetByExternalID(ctx context.Context, source entity.ExternalSource, value string) (*entity.Movies, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	externalID, err := s.externalIDRepo.FindOne(ctx, repository.And(
+		repository.Eq("source", source),
+		repository.Eq("value", value),
+	))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	filter, err := s.visible(ctx, repository.Eq("id", externalID.MovieID))
+	if err != nil {
+		return nil, err
+	}
+
+	movie, err := s.movieRepo.FindOne(ctx, filter, movieDetails...)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+	s.resolveImageURLs(movie)
+
+	if err := s.loadNeighbours(ctx, movie); err != nil {
+		return nil, err
+	}
+
+	return movie, nil
+}
+
+// SetExternalID attaches or replaces the movie's id for source. An id already
+// attached to another movie is reported as a conflict. A change bumps the movie's version.
+func (s *service) SetExternalID(ctx context.Context, id int64, source entity.ExternalSource, value string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.movieRepo.FindOne(ctx, repository.Eq("id", id)); err != nil {
+			return err
+		}
+
+		changed, err := s.attachExternalIDs(ctx, id, []entity.MovieExternalIDs{{Source: source, Value: value}})
+		if err != nil || !changed {
+			return err
+		}
+
+		return s.movieRepo.Touch(ctx, id)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) RemoveExternalID(ctx context.Context, id int64, source entity.ExternalSource) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := s.externalIDRepo.Delete(ctx, repository.And(
+			repository.Eq("movie_id", id),
+			repository.Eq("source", source),
+		))
+		if err != nil {
+			return err
+		}
+
+		return s.movieRepo.Touch(ctx, id)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// attachExternalIDs upserts one id per source for the movie and reports whether any
+// was written, callers bump the movie's version then. The unique (source, value)
+// index still catches concurrent writers, this check gives a readable conflict first.
+func (s *service) attachExternalIDs(ctx context.Context, movieID int64, externalIDs []entity.MovieExternalIDs) (bool, error) {
+	var changed bool
+	for _, externalID := range externalIDs {
+		existing, err := s.externalIDRepo.FindOne(ctx, repository.And(
+			repository.Eq("source", externalID.Source),
+			repository.Eq("value", externalID.Value),
+		))
+		switch {
+		case err == nil && existing.MovieID == movieID:
+			continue
+		case err == nil:
+			return false, inerr.NewErrConflict(fmt.Sprintf("external id %s:%s", externalID.Source, externalID.Value))
+		case !inerr.IsErrNotFound(err):
+			return false, err
+		}
+
+		err = s.externalIDRepo.Upsert(ctx, []string{"value"}, &entity.MovieExternalIDs{
+			MovieID:   movieID,
+			Source:    externalID.Source,
+			Value:     externalID.Value,
+			CreatedAt: time.Now(),
+		}, "movie_id", "source")
+		if err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,144 @@
+package movies
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+)
+
+func (s *service) History(ctx context.Context, id int64, limit, page uint64) (int64, []*entity.AuditLogs, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	total, revisions, err := s.auditRepo.FindAll(ctx, limit, page, "id desc", movieAuditFilter(id))
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return int64(total), revisions, nil
+}
+
+// Revert restores the movie to the state recorded by the given revision. For deletions
+// the state before the change is used since there is no state after it, and a
+// movie still in the trash is restored first.
+func (s *service) Revert(ctx context.Context, id, revisionID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		revision, err := s.auditRepo.FindOne(ctx, movieAuditFilter(id).And(repository.Eq("id", revisionID)))
+		if err != nil {
+			return err
+		}
+
+		state := revision.After
+		if len(state) == 0 {
+			state = revision.Before
+		}
+
+		var snapshot entity.MovieSnapshot
+		if err := json.Unmarshal(state, &snapshot); err != nil {
+			return err
+		}
+
+		before, err := s.movieRepo.FindWithTrashed(ctx, repository.Eq("id", id))
+		if err != nil {
+			return err
+		}
+
+		if before.DeletedAt.Valid {
+			if err := s.movieRepo.Restore(ctx, id); err != nil {
+				return err
+			}
+
+			if err := s.audit(ctx, entity.AuditActionRestore, id, nil, before); err != nil {
+				return err
+			}
+
+			if before, err = s.movieRepo.FindOne(ctx, repository.Eq("id", id), "MovieGenres"); err != nil {
+				return err
+			}
+		}
+
+		after := *before
+		snapshot.Apply(&after)
+		s.beforeUpdate(&after)
+
+		if err := s.movieRepo.Update(ctx, &after); err != nil {
+			return err
+		}
+
+		return s.audit(ctx, entity.AuditActionRevert, id, before, &after)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// audit records a movie change in the audit log. It must be called with the
+// transactional context so the record is committed or rolled back with the change.
+func (s *service) audit(ctx context.Context, action entity.AuditAction, id int64, before, after *entity.Movies) error {
+	var beforeState, afterState any
+	if before != nil {
+		beforeState = entity.NewMovieSnapshot(before)
+	}
+	if after != nil {
+		afterState = entity.NewMovieSnapshot(after)
+	}
+
+	return s.record(ctx, entity.AuditEntityMovie, id, action, beforeState, afterState)
+}
+
+// record writes an audit entry for any entity type, nil states are stored as NULL
+func (s *service) record(ctx context.Context, entityType entity.AuditEntityType, id int64, action entity.AuditAction, before, after any) error {
+	record := &entity.AuditLogs{
+		EntityType: entityType,
+		EntityID:   id,
+		Action:     action,
+		CreatedAt:  time.Now(),
+	}
+
+	if actorID := security.UserIDFromContext(ctx); actorID != "" {
+		record.ActorID = &actorID
+	}
+
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err != nil {
+			return err
+		}
+		record.Before = data
+	}
+
+	if after != nil {
+		data, err := json.Marshal(after)
+		if err != nil {
+			return err
+		}
+		record.After = data
+	}
+
+	return s.auditRepo.Create(ctx, record)
+}
+
+func movieAuditFilter(id int64) repository.Filter {
+	return repository.And(
+		repository.Eq("entity_type", entity.AuditEntityMovie),
+		repository.Eq("entity_id", id),
+	)
+}
@@ -0,0 +1,199 @@
+package movies
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/imaging"
+	"github.com/AsaHero/movie-app-server/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// imageExtensions are the upload formats we can decode and re-encode
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+}
+
+const jpegQuality = 85
+
+// UploadImage stores the upload as the original variant plus resized copies and
+// replaces the movie's previous image of the same kind. The movie's version is bumped.
+func (s *service) UploadImage(ctx context.Context, movieID int64, kind entity.ImageKind, upload entity.ImageUpload) ([]entity.MovieImages, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	ext, ok := imageExtensions[upload.ContentType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported image type %s", upload.ContentType)
+	}
+
+	if _, err := s.movieRepo.FindOne(ctx, repository.Eq("id", movieID)); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	uploadID := uuid.New().String()
+	images := make([]*entity.MovieImages, 0, len(kind.Variants()))
+	saved := make([]string, 0, len(kind.Variants()))
+
+	for _, variant := range kind.Variants() {
+		data, bounds, err := renderVariant(upload, variant)
+		if err != nil {
+			s.deleteFiles(ctx, saved)
+			return nil, inerr.Err(err)
+		}
+
+		key := fmt.Sprintf("movies/%d/%s/%s-%s.%s", movieID, kind, uploadID, variant.Name, ext)
+		if err := s.storage.Save(ctx, key, bytes.NewReader(data), upload.ContentType); err != nil {
+			s.deleteFiles(ctx, saved)
+			return nil, inerr.Err(err)
+		}
+		saved = append(saved, key)
+
+		images = append(images, &entity.MovieImages{
+			MovieID:     movieID,
+			Kind:        kind,
+			Variant:     variant.Name,
+			StorageKey:  key,
+			ContentType: upload.ContentType,
+			Width:       bounds.Dx(),
+			Height:      bounds.Dy(),
+			Size:        int64(len(data)),
+			CreatedAt:   time.Now(),
+		})
+	}
+
+	var replaced []string
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if replaced, err = s.imageKeys(ctx, movieID, kind); err != nil {
+			return err
+		}
+
+		if len(replaced) > 0 {
+			if err := s.imagesRepo.Delete(ctx, imageFilter(movieID, kind)); err != nil {
+				return err
+			}
+		}
+
+		if err := s.imagesRepo.BatchCreate(ctx, images); err != nil {
+			return err
+		}
+
+		return s.movieRepo.Touch(ctx, movieID)
+	})
+	if err != nil {
+		s.deleteFiles(ctx, saved)
+		return nil, inerr.Err(err)
+	}
+
+	s.deleteFiles(ctx, replaced)
+
+	result := make([]entity.MovieImages, 0, len(images))
+	for _, image := range images {
+		image.URL = s.storage.URL(image.StorageKey)
+		result = append(result, *image)
+	}
+
+	return result, nil
+}
+
+// DeleteImage removes the movie's image of kind with its variants and bumps the movie's version
+func (s *service) DeleteImage(ctx context.Context, movieID int64, kind entity.ImageKind) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	var keys []string
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if keys, err = s.imageKeys(ctx, movieID, kind); err != nil {
+			return err
+		}
+
+		if err := s.imagesRepo.Delete(ctx, imageFilter(movieID, kind)); err != nil {
+			return err
+		}
+
+		return s.movieRepo.Touch(ctx, movieID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	s.deleteFiles(ctx, keys)
+	return nil
+}
+
+// renderVariant returns the encoded bytes of a variant, the original is stored as uploaded
+func renderVariant(upload entity.ImageUpload, variant entity.ImageVariant) ([]byte, image.Rectangle, error) {
+	if variant.Width == 0 {
+		return upload.Data, upload.Image.Bounds(), nil
+	}
+
+	resized := imaging.Fit(upload.Image, variant.Width)
+
+	var buf bytes.Buffer
+	var err error
+	if upload.ContentType == "image/png" {
+		err = png.Encode(&buf, resized)
+	} else {
+		err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, image.Rectangle{}, err
+	}
+
+	return buf.Bytes(), resized.Bounds(), nil
+}
+
+// imageKeys lists the stored files of a movie, optionally limited to some kinds
+func (s *service) imageKeys(ctx context.Context, movieID int64, kinds ...entity.ImageKind) ([]string, error) {
+	_, images, err := s.imagesRepo.FindAll(ctx, 0, 0, "", imageFilter(movieID, kinds...))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(images))
+	for _, image := range images {
+		keys = append(keys, image.StorageKey)
+	}
+	return keys, nil
+}
+
+// deleteFiles removes stored files once the rows pointing at them are gone.
+// Failures only leave orphaned files behind, so they are logged and not returned.
+func (s *service) deleteFiles(ctx context.Context, keys []string) {
+	ctx = context.WithoutCancel(ctx)
+
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			logger.Error("failed to delete stored file", logrus.Fields{
+				"key":   key,
+				"error": err.Error(),
+			})
+		}
+	}
+}
+
+func (s *service) resolveImageURLs(movie *entity.Movies) {
+	for i := range movie.Images {
+		movie.Images[i].URL = s.storage.URL(movie.Images[i].StorageKey)
+	}
+}
+
+func imageFilter(movieID int64, kinds ...entity.ImageKind) repository.Filter {
+	filter := repository.Eq("movie_id", movieID)
+	if len(kinds) > 0 {
+		filter = filter.And(repository.In("kind", kinds))
+	}
+	return filter
+}
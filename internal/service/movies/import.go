@@ -0,0 +1,519 @@
+package movies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/logger"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+	"github.com/google/uuid"
+	"github.com/shogo82148/pointer"
+	"github.com/sirupsen/logrus"
+)
+
+// importProgressEvery is the number of rows processed between progress writes
+const importProgressEvery = 50
+
+// importColumns are overwritten when a row matches an existing movie by external id
+var importColumns = []string{
+	"title",
+	"release",
+	"plot",
+	"duration_minutes",
+	"poster_url",
+	"trailer_url",
+	"trailer_provider",
+	"trailer_video_id",
+}
+
+// importMaxErrors caps the stored error report, the failed counter stays exact
+const importMaxErrors = 1000
+
+type importOutcome int
+
+const (
+	importCreated importOutcome = iota
+	importUpdated
+	importSkipped
+)
+
+var (
+	// errDryRun rolls back a row transaction once everything has been checked
+	errDryRun = errors.New("dry run")
+	// errImportKeyClaimed rolls back a row whose key a concurrent import claimed first
+	errImportKeyClaimed = errors.New("import key claimed by another movie")
+)
+
+// Import writes the rows of an upload, creating movies or updating the ones matching
+// by external id. Rows that failed validation show up in the report. The rows are
+// read one at a time and closed once the import is done.
+func (s *service) Import(ctx context.Context, total int, rows entity.ImportRows, opts entity.ImportOptions) (*entity.ImportJobs, error) {
+	job, err := s.createImportJob(ctx, opts)
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	job.Total = total
+
+	if opts.Async {
+		// Detach from the request so the job outlives it, the actor is kept for the audit log
+		jobCtx := s.importsCtx
+		if job.CreatedBy != nil {
+			jobCtx = security.WithUserID(jobCtx, *job.CreatedBy)
+		}
+
+		pending := *job
+		s.imports.Add(1)
+		go func() {
+			defer rows.Close()
+			s.runBackgroundImport(jobCtx, job, rows.Next, opts)
+		}()
+		return &pending, nil
+	}
+
+	defer rows.Close()
+	s.runImport(ctx, job, rows.Next, opts)
+	return job, nil
+}
+
+// ImportStream imports rows returned by next until it returns io.EOF. The total is
+// not known upfront and grows as rows are read, rows are never held in memory together.
+func (s *service) ImportStream(ctx context.Context, next func() (*entity.MovieImportRow, error), opts entity.ImportOptions) (*entity.ImportJobs, error) {
+	job, err := s.createImportJob(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	counted := func() (*entity.MovieImportRow, error) {
+		row, err := next()
+		if err == nil {
+			job.Total++
+		}
+		return row, err
+	}
+
+	s.runImport(ctx, job, counted, opts)
+	return job, nil
+}
+
+func (s *service) StopImports() {
+	s.stopImports()
+	s.imports.Wait()
+}
+
+// runBackgroundImport runs an async import, a panic fails the job instead of the process
+func (s *service) runBackgroundImport(ctx context.Context, job *entity.ImportJobs, next func() (*entity.MovieImportRow, error), opts entity.ImportOptions) {
+	defer s.imports.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("movie import panicked", logrus.Fields{
+				"job":   job.ID,
+				"panic": r,
+			})
+			s.failImportJob(context.WithoutCancel(ctx), job, fmt.Errorf("import stopped unexpectedly: %v", r))
+		}
+	}()
+
+	s.runImport(ctx, job, next, opts)
+}
+
+func (s *service) GetImportJob(ctx context.Context, id string) (*entity.ImportJobs, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	job, err := s.importJobsRepo.FindOne(ctx, repository.Eq("id", id))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return job, nil
+}
+
+func (s *service) createImportJob(ctx context.Context, opts entity.ImportOptions) (*entity.ImportJobs, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	job := &entity.ImportJobs{
+		ID:           uuid.New().String(),
+		Status:       entity.ImportJobStatusPending,
+		DryRun:       opts.DryRun,
+		CreateGenres: opts.CreateGenres,
+		Errors:       entity.ImportRowErrors{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if actorID := security.UserIDFromContext(ctx); actorID != "" {
+		job.CreatedBy = &actorID
+	}
+
+	if err := s.importJobsRepo.Create(ctx, job); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return job, nil
+}
+
+func (s *service) runImport(ctx context.Context, job *entity.ImportJobs, next func() (*entity.MovieImportRow, error), opts entity.ImportOptions) {
+	job.Status = entity.ImportJobStatusRunning
+	s.saveImportJob(ctx, job)
+
+	known, err := s.loadGenres(ctx)
+	if err != nil {
+		s.failImportJob(ctx, job, err)
+		return
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			// The job row is still marked as failed once the caller gives up
+			s.failImportJob(context.WithoutCancel(ctx), job, err)
+			return
+		}
+
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			s.failImportJob(ctx, job, err)
+			return
+		}
+
+		outcome, err := importSkipped, row.Err
+		if err == nil {
+			outcome, err = s.importRow(ctx, row, opts, known)
+		}
+
+		job.Processed++
+		switch {
+		case err != nil:
+			job.Failed++
+			if len(job.Errors) < importMaxErrors {
+				job.Errors = append(job.Errors, entity.ImportRowError{
+					Row:        row.Row,
+					ExternalID: pointer.StringValue(row.ExternalID),
+					Message:    err.Error(),
+				})
+			}
+		case outcome == importCreated:
+			job.Created++
+		case outcome == importUpdated:
+			job.Updated++
+		default:
+			job.Skipped++
+		}
+
+		if job.Processed%importProgressEvery == 0 {
+			s.saveImportJob(ctx, job)
+		}
+	}
+
+	job.Status = entity.ImportJobStatusCompleted
+	s.finishImportJob(ctx, job)
+}
+
+// importRow writes a single row in its own transaction so one bad row doesn't
+// abort the others. Rows identical to the stored movie are skipped without a write.
+// A row that loses the race for its key to a concurrent import is retried once,
+// it then updates the movie the other import created.
+func (s *service) importRow(ctx context.Context, row *entity.MovieImportRow, opts entity.ImportOptions, known map[string]int64) (importOutcome, error) {
+	outcome, err := s.writeImportRow(ctx, row, opts, known)
+	if errors.Is(err, errImportKeyClaimed) {
+		return s.writeImportRow(ctx, row, opts, known)
+	}
+
+	return outcome, err
+}
+
+func (s *service) writeImportRow(ctx context.Context, row *entity.MovieImportRow, opts entity.ImportOptions, known map[string]int64) (importOutcome, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	// genres are only remembered once the row is committed
+	created := make(map[string]int64)
+
+	outcome := importUpdated
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		movieGenres, err := s.resolveGenres(ctx, row.GenreNames, opts, known, created)
+		if err != nil {
+			return err
+		}
+
+		movie := row.Movie
+		movie.MovieGenres = movieGenres
+
+		externalIDs := row.ExternalIDs
+		var key *entity.MovieExternalIDs
+		var before *entity.Movies
+		if row.ExternalID != nil {
+			parsed := entity.ParseImportKey(*row.ExternalID)
+			key = &parsed
+			externalIDs = append([]entity.MovieExternalIDs{parsed}, externalIDs...)
+
+			if before, err = s.findByImportKey(ctx, parsed); err != nil {
+				return err
+			}
+		}
+
+		normalizeTrailer(&movie)
+
+		if before != nil && entity.NewMovieSnapshot(before).Equal(entity.NewMovieSnapshot(&movie)) {
+			outcome = importSkipped
+			changed, err := s.attachExternalIDs(ctx, before.ID, externalIDs)
+			if err != nil {
+				return err
+			}
+
+			if changed {
+				if err := s.movieRepo.Touch(ctx, before.ID); err != nil {
+					return err
+				}
+			}
+
+			if opts.DryRun {
+				return errDryRun
+			}
+			return nil
+		}
+
+		// imported movies start as drafts like created ones, updates leave the
+		// workflow status of existing movies alone
+		if before == nil {
+			outcome = importCreated
+			s.beforeCreate(&movie)
+			movie.Status = entity.MovieStatusDraft
+			movie.Version = 1
+		} else {
+			movie.ID = before.ID
+			movie.CreatedAt = before.CreatedAt
+			movie.Version = before.Version
+			movie.Status = before.Status
+			movie.PublishAt = before.PublishAt
+			movie.ScheduledBy = before.ScheduledBy
+			movie.PublishedAt = before.PublishedAt
+			movie.PublishedBy = before.PublishedBy
+			s.beforeUpdate(&movie)
+		}
+
+		movie.MovieGenres = nil
+		if before == nil {
+			err = s.movieRepo.Create(ctx, &movie)
+		} else {
+			err = s.movieRepo.Patch(ctx, &movie, importColumns, false)
+		}
+		if err != nil {
+			return err
+		}
+
+		if before == nil && key != nil {
+			if err := s.claimImportKey(ctx, movie.ID, *key); err != nil {
+				return err
+			}
+		}
+
+		for i := range movieGenres {
+			movieGenres[i].TitleType = entity.TitleTypeMovie
+			movieGenres[i].TitleID = movie.ID
+		}
+		movie.MovieGenres = movieGenres
+
+		if err := s.movieRepo.ReplaceGenres(ctx, &movie); err != nil {
+			return err
+		}
+
+		// the version was bumped by the write above
+		if _, err := s.attachExternalIDs(ctx, movie.ID, externalIDs); err != nil {
+			return err
+		}
+
+		action := entity.AuditActionUpdate
+		if outcome == importCreated {
+			action = entity.AuditActionCreate
+		}
+
+		if err := s.audit(ctx, action, movie.ID, before, &movie); err != nil {
+			return err
+		}
+
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return outcome, nil
+	}
+	if err != nil {
+		return outcome, err
+	}
+
+	for name, id := range created {
+		known[name] = id
+	}
+
+	return outcome, nil
+}
+
+// claimImportKey attaches the key to the movie just created for it. The upsert
+// on (source, value) waits for a concurrent import of the same key, so only one
+// of them keeps its movie and the other gets errImportKeyClaimed.
+func (s *service) claimImportKey(ctx context.Context, movieID int64, key entity.MovieExternalIDs) error {
+	err := s.externalIDRepo.Upsert(ctx, []string{"source"}, &entity.MovieExternalIDs{
+		MovieID:   movieID,
+		Source:    key.Source,
+		Value:     key.Value,
+		CreatedAt: time.Now(),
+	}, "source", "value")
+	if err != nil {
+		return err
+	}
+
+	holder, err := s.externalIDRepo.FindOne(ctx, repository.And(
+		repository.Eq("source", key.Source),
+		repository.Eq("value", key.Value),
+	))
+	if err != nil {
+		return err
+	}
+
+	if holder.MovieID != movieID {
+		return errImportKeyClaimed
+	}
+
+	return nil
+}
+
+// findByImportKey returns the movie the key is attached to, nil when there is none.
+// A trashed movie keeps its external ids, it has to be restored rather than revived by an import.
+func (s *service) findByImportKey(ctx context.Context, key entity.MovieExternalIDs) (*entity.Movies, error) {
+	externalID, err := s.externalIDRepo.FindOne(ctx, repository.And(
+		repository.Eq("source", key.Source),
+		repository.Eq("value", key.Value),
+	))
+	if inerr.IsErrNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	movie, err := s.movieRepo.FindWithTrashed(ctx, repository.Eq("id", externalID.MovieID))
+	if err != nil {
+		return nil, err
+	}
+
+	if movie.DeletedAt.Valid {
+		return nil, inerr.ErrorImportTrashedMovie
+	}
+
+	return movie, nil
+}
+
+// resolveGenres maps genre names to ids case-insensitively, creating missing genres when allowed.
+// New genres go to created, the caller remembers them once their transaction commits.
+func (s *service) resolveGenres(ctx context.Context, names []string, opts entity.ImportOptions, known, created map[string]int64) ([]entity.TitleGenres, error) {
+	movieGenres := make([]entity.TitleGenres, 0, len(names))
+	seen := make(map[int64]bool, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if key == "" {
+			continue
+		}
+
+		id, ok := known[key]
+		if !ok {
+			id, ok = created[key]
+		}
+		if !ok {
+			if !opts.CreateGenres {
+				return nil, inerr.NewErrNotFound(fmt.Sprintf("genre %q", name))
+			}
+
+			genre := &entity.Genres{Name: name}
+			if err := s.genresRepo.Create(ctx, genre); err != nil {
+				return nil, err
+			}
+
+			if err := s.record(ctx, entity.AuditEntityGenre, genre.ID, entity.AuditActionCreate, nil, entity.NewGenreSnapshot(genre)); err != nil {
+				return nil, err
+			}
+
+			id = genre.ID
+			created[key] = id
+		}
+
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		movieGenres = append(movieGenres, entity.TitleGenres{GenreID: id})
+	}
+
+	return movieGenres, nil
+}
+
+func (s *service) loadGenres(ctx context.Context) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	_, genres, err := s.genresRepo.FindAll(ctx, 0, 0, "", repository.Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]int64, len(genres))
+	for _, genre := range genres {
+		known[strings.ToLower(genre.Name)] = genre.ID
+	}
+
+	return known, nil
+}
+
+func (s *service) failImportJob(ctx context.Context, job *entity.ImportJobs, err error) {
+	job.Status = entity.ImportJobStatusFailed
+	job.Errors = append(job.Errors, entity.ImportRowError{Message: err.Error()})
+	s.finishImportJob(ctx, job)
+}
+
+func (s *service) finishImportJob(ctx context.Context, job *entity.ImportJobs) {
+	now := time.Now()
+	job.FinishedAt = &now
+	s.saveImportJob(ctx, job)
+}
+
+// saveImportJob persists progress, failures are only logged so the import keeps going
+func (s *service) saveImportJob(ctx context.Context, job *entity.ImportJobs) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	job.UpdatedAt = time.Now()
+
+	err := s.importJobsRepo.UpdateDataWhere(ctx, map[string]any{
+		"status":      job.Status,
+		"total":       job.Total,
+		"processed":   job.Processed,
+		"created":     job.Created,
+		"updated":     job.Updated,
+		"skipped":     job.Skipped,
+		"failed":      job.Failed,
+		"errors":      job.Errors,
+		"updated_at":  job.UpdatedAt,
+		"finished_at": job.FinishedAt,
+	}, repository.Eq("id", job.ID))
+	if err != nil {
+		inerr.Err(err)
+	}
+}
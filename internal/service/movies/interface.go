@@ -2,14 +2,55 @@ package movies
 
 import (
 	"context"
+	"time"
 
 	"github.com/AsaHero/movie-app-server/internal/entity"
 )
 
 type Service interface {
-	Create(ctx context.Context, movie *entity.Movies, movieGenres []*entity.MovieGenres) error
+	Create(ctx context.Context, movie *entity.Movies, movieGenres []*entity.TitleGenres) error
 	Update(ctx context.Context, movie *entity.Movies) error
+	Patch(ctx context.Context, id, version int64, patch entity.MoviePatch) (*entity.Movies, error)
 	List(ctx context.Context, limit, page uint64, orderBy, orderDir string, filters entity.MovieFilters) (int64, []entity.Movies, error)
+	Export(ctx context.Context, filters entity.MovieFilters, fn func(movies []entity.Movies) error) error
+	Facets(ctx context.Context, filters entity.MovieFilters, facets []entity.MovieFacet) (*entity.MovieFacets, error)
 	GetByID(ctx context.Context, id int64) (*entity.Movies, error)
-	Delete(ctx context.Context, id int64) error
+	Preview(ctx context.Context, id int64) (*entity.Movies, error)
+	SetStatus(ctx context.Context, id, version int64, status entity.MovieStatus, publishAt *time.Time, admin bool) (*entity.Movies, error)
+	PublishScheduled(ctx context.Context) (int64, error)
+	GetByExternalID(ctx context.Context, source entity.ExternalSource, value string) (*entity.Movies, error)
+	Similar(ctx context.Context, id int64, limit uint64) ([]entity.SimilarMovie, error)
+	SetExternalID(ctx context.Context, id int64, source entity.ExternalSource, value string) error
+	ListTranslations(ctx context.Context, movieID int64) ([]*entity.MovieTranslations, error)
+	SetTranslation(ctx context.Context, translation *entity.MovieTranslations) error
+	RemoveTranslation(ctx context.Context, movieID int64, locale string) error
+	ListReleases(ctx context.Context, movieID int64) ([]*entity.MovieReleases, error)
+	SetRelease(ctx context.Context, release *entity.MovieReleases) error
+	RemoveRelease(ctx context.Context, movieID int64, country string, releaseType entity.ReleaseType) error
+	UploadImage(ctx context.Context, movieID int64, kind entity.ImageKind, upload entity.ImageUpload) ([]entity.MovieImages, error)
+	DeleteImage(ctx context.Context, movieID int64, kind entity.ImageKind) error
+	RemoveExternalID(ctx context.Context, id int64, source entity.ExternalSource) error
+	ListCollections(ctx context.Context, limit, page uint64) (int64, []*entity.Collections, error)
+	GetCollection(ctx context.Context, id int64) (*entity.Collections, error)
+	CreateCollection(ctx context.Context, collection *entity.Collections) error
+	UpdateCollection(ctx context.Context, collection *entity.Collections) error
+	DeleteCollection(ctx context.Context, id int64) error
+	SetCollectionMovies(ctx context.Context, id int64, movieIDs []int64) error
+	Delete(ctx context.Context, id, version int64) error
+	HardDelete(ctx context.Context, id, version int64) error
+	ListTrash(ctx context.Context, limit, page uint64) (int64, []entity.Movies, error)
+	Restore(ctx context.Context, id int64) error
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	History(ctx context.Context, id int64, limit, page uint64) (int64, []*entity.AuditLogs, error)
+	Revert(ctx context.Context, id, revisionID int64) error
+	Import(ctx context.Context, total int, rows entity.ImportRows, opts entity.ImportOptions) (*entity.ImportJobs, error)
+	ImportStream(ctx context.Context, next func() (*entity.MovieImportRow, error), opts entity.ImportOptions) (*entity.ImportJobs, error)
+	GetImportJob(ctx context.Context, id string) (*entity.ImportJobs, error)
+	// StopImports cancels the imports running in the background and waits until
+	// their jobs are marked as failed
+	StopImports()
+	Duplicates(ctx context.Context, limit, page uint64) (int64, []*entity.DuplicateCandidate, error)
+	DismissDuplicate(ctx context.Context, movieID, duplicateID int64) error
+	Merge(ctx context.Context, survivorID, duplicateID int64) (*entity.Movies, error)
+	RedirectTarget(ctx context.Context, id int64) (int64, error)
 }
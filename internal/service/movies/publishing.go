@@ -0,0 +1,166 @@
+package movies
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+	"github.com/AsaHero/movie-app-server/pkg/utility"
+)
+
+// publishBatchSize is how many due movies PublishScheduled publishes per run
+const publishBatchSize = 100
+
+// Preview returns the movie whatever its workflow status, for editors
+func (s *service) Preview(ctx context.Context, id int64) (*entity.Movies, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	movie, err := s.movieRepo.FindOne(ctx, repository.Eq("id", id), movieDetails...)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+	s.resolveImageURLs(movie)
+
+	if err := s.loadNeighbours(ctx, movie); err != nil {
+		return nil, err
+	}
+
+	return movie, nil
+}
+
+// SetStatus moves the movie through the editorial workflow. Scheduling needs a
+// publish_at in the future, publishing records the caller as the publisher.
+// A non-zero version must match the current one, and every move but sending a
+// draft to review needs an admin.
+func (s *service) SetStatus(ctx context.Context, id, version int64, status entity.MovieStatus, publishAt *time.Time, admin bool) (*entity.Movies, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	if status == entity.MovieStatusScheduled && (publishAt == nil || !publishAt.After(now)) {
+		return nil, inerr.ErrorPublishAtInPast
+	}
+
+	var movie *entity.Movies
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.movieRepo.FindOne(ctx, repository.Eq("id", id), "MovieGenres")
+		if err != nil {
+			return err
+		}
+
+		if version > 0 && before.Version != version {
+			return inerr.NewErrPreconditionFailed(utility.GetTypeName(before))
+		}
+
+		if !before.Status.CanMoveTo(status) {
+			return inerr.ErrorStatusTransition
+		}
+
+		if before.Status.NeedsAdmin(status) && !admin {
+			return inerr.ErrorStatusNeedsAdmin
+		}
+
+		var actorID *string
+		if userID := security.UserIDFromContext(ctx); userID != "" {
+			actorID = &userID
+		}
+
+		after := *before
+		switch status {
+		case entity.MovieStatusPublished:
+			after.Publish(now, actorID)
+		case entity.MovieStatusScheduled:
+			after.Status = status
+			after.PublishAt = publishAt
+			after.ScheduledBy = actorID
+		default:
+			after.Status = status
+			after.PublishAt = nil
+			after.ScheduledBy = nil
+			after.PublishedAt = nil
+			after.PublishedBy = nil
+		}
+
+		if err := s.transition(ctx, before, &after); err != nil {
+			return err
+		}
+
+		movie, err = s.movieRepo.FindOne(ctx, repository.Eq("id", id), movieDetails...)
+		if err != nil {
+			return err
+		}
+
+		return s.loadNeighbours(ctx, movie)
+	})
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+	s.resolveImageURLs(movie)
+
+	return movie, nil
+}
+
+// PublishScheduled publishes the scheduled movies whose publish_at has passed,
+// on behalf of whoever scheduled them, and returns how many went live
+func (s *service) PublishScheduled(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	due := func(now time.Time) repository.Filter {
+		return repository.And(
+			repository.Eq("status", entity.MovieStatusScheduled),
+			repository.Lte("publish_at", now),
+		)
+	}
+
+	_, scheduled, err := s.movieRepo.FindAll(ctx, publishBatchSize, 1, "publish_at", due(time.Now()))
+	if err != nil {
+		return 0, inerr.Err(err)
+	}
+
+	var published int64
+	for _, movie := range scheduled {
+		err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+			// the schedule may have been moved or cancelled since it was listed
+			before, err := s.movieRepo.FindOne(ctx, repository.Eq("id", movie.ID).And(due(time.Now())), "MovieGenres")
+			if err != nil {
+				return err
+			}
+
+			if before.ScheduledBy != nil {
+				ctx = security.WithUserID(ctx, *before.ScheduledBy)
+			}
+
+			after := *before
+			after.Publish(time.Now(), before.ScheduledBy)
+
+			return s.transition(ctx, before, &after)
+		})
+		if inerr.IsErrNotFound(err) || inerr.IsErrPreconditionFailed(err) {
+			continue
+		}
+		if err != nil {
+			return published, inerr.Err(err)
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+// transition writes the workflow columns of after and records the change
+func (s *service) transition(ctx context.Context, before, after *entity.Movies) error {
+	after.UpdatedAt = time.Now()
+
+	columns := []string{"status", "publish_at", "scheduled_by", "published_at", "published_by"}
+	if err := s.movieRepo.Patch(ctx, after, columns, false); err != nil {
+		return err
+	}
+
+	return s.audit(ctx, entity.AuditActionStatus, after.ID, before, after)
+}
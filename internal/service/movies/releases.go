@@ -0,0 +1,84 @@
+package movies
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+func (s *service) ListReleases(ctx context.Context, movieID int64) ([]*entity.MovieReleases, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if _, err := s.movieRepo.FindOne(ctx, repository.Eq("id", movieID)); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	_, releases, err := s.releasesRepo.FindAll(ctx, 0, 0, "country, release_date", repository.Eq("movie_id", movieID))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return releases, nil
+}
+
+// SetRelease creates or replaces the movie's release for release.Country and release.Type.
+// Certifications unknown for the country are stored without an age and never pass max_certification.
+func (s *service) SetRelease(ctx context.Context, release *entity.MovieReleases) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	release.MinAge = nil
+	if release.Certification != nil {
+		if age, ok := entity.CertificationAge(release.Country, *release.Certification); ok {
+			release.MinAge = &age
+		}
+	}
+
+	now := time.Now()
+	release.CreatedAt = now
+	release.UpdatedAt = now
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.movieRepo.Touch(ctx, release.MovieID); err != nil {
+			return err
+		}
+
+		return s.releasesRepo.Upsert(ctx,
+			[]string{"release_date", "certification", "min_age", "updated_at"},
+			release,
+			"movie_id", "country", "type",
+		)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) RemoveRelease(ctx context.Context, movieID int64, country string, releaseType entity.ReleaseType) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := s.releasesRepo.Delete(ctx, repository.And(
+			repository.Eq("movie_id", movieID),
+			repository.Eq("country", country),
+			repository.Eq("type", releaseType),
+		))
+		if err != nil {
+			return err
+		}
+
+		return s.movieRepo.Touch(ctx, movieID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
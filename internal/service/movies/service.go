@@ -2,33 +2,110 @@ package movies
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/AsaHero/movie-app-server/internal/entity"
 	"github.com/AsaHero/movie-app-server/internal/inerr"
-	"github.com/AsaHero/movie-app-server/internal/repository/movie_genres"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/audit_logs"
+	"github.com/AsaHero/movie-app-server/internal/repository/collection_movies"
+	"github.com/AsaHero/movie-app-server/internal/repository/collections"
+	"github.com/AsaHero/movie-app-server/internal/repository/genres"
+	"github.com/AsaHero/movie-app-server/internal/repository/import_jobs"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_duplicate_dismissals"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_external_ids"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_images"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_redirects"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_releases"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_translations"
 	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/internal/repository/title_genres"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/pkg/cache"
+	"github.com/AsaHero/movie-app-server/pkg/storage"
+	"github.com/AsaHero/movie-app-server/pkg/utility"
+	"github.com/AsaHero/movie-app-server/pkg/video"
 )
 
+// movieDetails are the relations loaded for a single movie response
+var movieDetails = []string{"MovieGenres", "MovieGenres.Genre", "MovieGenres.Genre.Translations", "ExternalIDs", "Images", "Translations", "Releases", "Collection", "Collection.Collection"}
+
 type service struct {
-	contextTimeout time.Duration
-	movieRepo      movies.Repository
-	movieGeresRepo movie_genres.Repository
+	contextTimeout       time.Duration
+	movieRepo            movies.Repository
+	titleGenresRepo      title_genres.Repository
+	genresRepo           genres.Repository
+	auditRepo            audit_logs.Repository
+	importJobsRepo       import_jobs.Repository
+	externalIDRepo       movie_external_ids.Repository
+	imagesRepo           movie_images.Repository
+	storage              storage.Storage
+	translationsRepo     movie_translations.Repository
+	releasesRepo         movie_releases.Repository
+	profilesService      profiles.Service
+	collectionsRepo      collections.Repository
+	collectionMoviesRepo collection_movies.Repository
+	redirectsRepo        movie_redirects.Repository
+	dismissalsRepo       movie_duplicate_dismissals.Repository
+	similarCache         *cache.Cache[int64, similarRanking]
+
+	// background imports run under importsCtx, StopImports cancels it and waits
+	importsCtx  context.Context
+	stopImports context.CancelFunc
+	imports     sync.WaitGroup
 }
 
-func New(contextTimeout time.Duration, movieRepo movies.Repository, movieGenresRepo movie_genres.Repository) Service {
+func New(
+	contextTimeout time.Duration,
+	movieRepo movies.Repository,
+	titleGenresRepo title_genres.Repository,
+	genresRepo genres.Repository,
+	auditRepo audit_logs.Repository,
+	importJobsRepo import_jobs.Repository,
+	externalIDRepo movie_external_ids.Repository,
+	imagesRepo movie_images.Repository,
+	storage storage.Storage,
+	translationsRepo movie_translations.Repository,
+	releasesRepo movie_releases.Repository,
+	profilesService profiles.Service,
+	collectionsRepo collections.Repository,
+	collectionMoviesRepo collection_movies.Repository,
+	redirectsRepo movie_redirects.Repository,
+	dismissalsRepo movie_duplicate_dismissals.Repository,
+) Service {
+	importsCtx, stopImports := context.WithCancel(context.Background())
+
 	return &service{
-		contextTimeout: contextTimeout,
-		movieRepo:      movieRepo,
-		movieGeresRepo: movieGenresRepo,
+		contextTimeout:       contextTimeout,
+		movieRepo:            movieRepo,
+		titleGenresRepo:      titleGenresRepo,
+		genresRepo:           genresRepo,
+		auditRepo:            auditRepo,
+		importJobsRepo:       importJobsRepo,
+		externalIDRepo:       externalIDRepo,
+		imagesRepo:           imagesRepo,
+		storage:              storage,
+		translationsRepo:     translationsRepo,
+		releasesRepo:         releasesRepo,
+		profilesService:      profilesService,
+		collectionsRepo:      collectionsRepo,
+		collectionMoviesRepo: collectionMoviesRepo,
+		redirectsRepo:        redirectsRepo,
+		dismissalsRepo:       dismissalsRepo,
+		similarCache:         cache.New[int64, similarRanking](similarCacheTTL, similarCacheEntries),
+		importsCtx:           importsCtx,
+		stopImports:          stopImports,
 	}
 }
 
-func (s *service) Create(ctx context.Context, movie *entity.Movies, movieGenres []*entity.MovieGenres) error {
+func (s *service) Create(ctx context.Context, movie *entity.Movies, movieGenres []*entity.TitleGenres) error {
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
 
 	s.beforeCreate(movie)
+	movie.Status = entity.MovieStatusDraft
 
 	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
 		// First create the movie
@@ -37,17 +114,23 @@ func (s *service) Create(ctx context.Context, movie *entity.Movies, movieGenres
 		}
 
 		for i := range movieGenres {
-			movieGenres[i].MovieID = movie.ID
+			movieGenres[i].TitleType = entity.TitleTypeMovie
+			movieGenres[i].TitleID = movie.ID
 		}
 
 		if len(movieGenres) > 0 {
 
-			if err := s.movieGeresRepo.BatchCreate(ctx, movieGenres); err != nil {
+			if err := s.titleGenresRepo.BatchCreate(ctx, movieGenres); err != nil {
 				return err
 			}
 		}
 
-		return nil
+		after := *movie
+		for _, movieGenre := range movieGenres {
+			after.MovieGenres = append(after.MovieGenres, *movieGenre)
+		}
+
+		return s.audit(ctx, entity.AuditActionCreate, movie.ID, nil, &after)
 	})
 
 	if err != nil {
@@ -60,13 +143,73 @@ func (s *service) Update(ctx context.Context, movie *entity.Movies) error {
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
 
-	if err := s.movieRepo.Update(ctx, movie); err != nil {
+	s.beforeUpdate(movie)
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.movieRepo.FindOne(ctx, repository.Eq("id", movie.ID), "MovieGenres")
+		if err != nil {
+			return err
+		}
+
+		if err := s.movieRepo.Update(ctx, movie); err != nil {
+			return err
+		}
+
+		return s.audit(ctx, entity.AuditActionUpdate, movie.ID, before, movie)
+	})
+	if err != nil {
 		return inerr.Err(err)
 	}
 
 	return nil
 }
 
+// Patch applies a partial update. A non-zero version must match the current one,
+// otherwise the version read inside the transaction guards against concurrent writes.
+func (s *service) Patch(ctx context.Context, id, version int64, patch entity.MoviePatch) (*entity.Movies, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	var movie *entity.Movies
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.movieRepo.FindOne(ctx, repository.Eq("id", id), "MovieGenres")
+		if err != nil {
+			return err
+		}
+
+		if version > 0 && before.Version != version {
+			return inerr.NewErrPreconditionFailed(utility.GetTypeName(before))
+		}
+
+		after := *before
+		after.MovieGenres = append([]entity.TitleGenres(nil), before.MovieGenres...)
+
+		columns, withGenres := patch.Apply(&after)
+		s.beforeUpdate(&after)
+
+		if err := s.movieRepo.Patch(ctx, &after, columns, withGenres); err != nil {
+			return err
+		}
+
+		if err := s.audit(ctx, entity.AuditActionUpdate, id, before, &after); err != nil {
+			return err
+		}
+
+		movie, err = s.movieRepo.FindOne(ctx, repository.Eq("id", id), movieDetails...)
+		if err != nil {
+			return err
+		}
+
+		return s.loadNeighbours(ctx, movie)
+	})
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+	s.resolveImageURLs(movie)
+
+	return movie, nil
+}
+
 func (s *service) List(ctx context.Context, limit, page uint64, orderBy, orderDir string, filters entity.MovieFilters) (int64, []entity.Movies, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
@@ -79,31 +222,189 @@ func (s *service) List(ctx context.Context, limit, page uint64, orderBy, orderDi
 		page = 1
 	}
 
+	filters, err := s.restrict(ctx, filters)
+	if err != nil {
+		return 0, nil, err
+	}
+
 	total, movies, err := s.movieRepo.ListWithFilters(ctx, limit, page, orderBy, orderDir, filters)
 	if err != nil {
 		return 0, nil, inerr.Err(err)
 	}
 
+	for i := range movies {
+		s.resolveImageURLs(&movies[i])
+	}
+
 	return total, movies, nil
 }
 
+func (s *service) Facets(ctx context.Context, filters entity.MovieFilters, facets []entity.MovieFacet) (*entity.MovieFacets, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	filters, err := s.restrict(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.movieRepo.Facets(ctx, filters, facets)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return result, nil
+}
+
 func (s *service) GetByID(ctx context.Context, id int64) (*entity.Movies, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
 
-	movie, err := s.movieRepo.FindOne(ctx, map[string]any{"id": id}, "MovieGenres", "MovieGenres.Genre")
+	filter, err := s.visible(ctx, repository.Eq("id", id))
+	if err != nil {
+		return nil, err
+	}
+
+	movie, err := s.movieRepo.FindOne(ctx, filter, movieDetails...)
 	if err != nil {
 		return nil, inerr.Err(err)
 	}
+	s.resolveImageURLs(movie)
+
+	if err := s.loadNeighbours(ctx, movie); err != nil {
+		return nil, err
+	}
 
 	return movie, nil
 }
 
-func (s *service) Delete(ctx context.Context, id int64) error {
+// restrict adds the age limit of the viewing profile in ctx to filters
+func (s *service) restrict(ctx context.Context, filters entity.MovieFilters) (entity.MovieFilters, error) {
+	limit, err := s.profilesService.AgeLimit(ctx)
+	if err != nil || limit == nil {
+		return filters, err
+	}
+
+	filters.AgeLimits = append(slices.Clone(filters.AgeLimits), *limit)
+	return filters, nil
+}
+
+// visible narrows filter to the published movies the viewing profile in ctx may
+// see, hidden and unpublished movies are reported as not found
+func (s *service) visible(ctx context.Context, filter repository.Filter) (repository.Filter, error) {
+	visible, err := s.profilesService.VisibleMovies(ctx)
+	if err != nil {
+		return repository.Filter{}, err
+	}
+
+	return filter.And(visible), nil
+}
+
+// Delete moves the movie to the trash. A non-zero version must match the current one.
+func (s *service) Delete(ctx context.Context, id, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.movieRepo.FindOne(ctx, repository.Eq("id", id), "MovieGenres")
+		if err != nil {
+			return err
+		}
+
+		filter := repository.Eq("id", id)
+		if version > 0 {
+			if before.Version != version {
+				return inerr.NewErrPreconditionFailed(utility.GetTypeName(before))
+			}
+			filter = filter.And(repository.Eq("version", version))
+		}
+
+		if err := s.movieRepo.Delete(ctx, filter); err != nil {
+			if inerr.IsErrNotFound(err) && version > 0 {
+				return inerr.NewErrPreconditionFailed(utility.GetTypeName(before))
+			}
+			return err
+		}
+
+		return s.audit(ctx, entity.AuditActionDelete, id, before, nil)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// HardDelete removes the movie for good. A non-zero version must match the current one.
+func (s *service) HardDelete(ctx context.Context, id, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	var imageKeys []string
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.movieRepo.FindWithTrashed(ctx, repository.Eq("id", id))
+		if err != nil {
+			return err
+		}
+
+		if version > 0 && before.Version != version {
+			return inerr.NewErrPreconditionFailed(utility.GetTypeName(before))
+		}
+
+		if imageKeys, err = s.imageKeys(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.movieRepo.HardDelete(ctx, id, version); err != nil {
+			return err
+		}
+
+		return s.audit(ctx, entity.AuditActionHardDelete, id, before, nil)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	s.deleteFiles(ctx, imageKeys)
+	return nil
+}
+
+func (s *service) ListTrash(ctx context.Context, limit, page uint64) (int64, []entity.Movies, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	total, movies, err := s.movieRepo.ListTrashed(ctx, limit, page)
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return total, movies, nil
+}
+
+func (s *service) Restore(ctx context.Context, id int64) error {
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
 
-	err := s.movieRepo.Delete(ctx, map[string]any{"id": id})
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.movieRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		after, err := s.movieRepo.FindOne(ctx, repository.Eq("id", id), "MovieGenres")
+		if err != nil {
+			return err
+		}
+
+		return s.audit(ctx, entity.AuditActionRestore, id, nil, after)
+	})
 	if err != nil {
 		return inerr.Err(err)
 	}
@@ -111,7 +412,43 @@ func (s *service) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// PurgeTrash permanently deletes movies that have been in the trash longer than retention
+func (s *service) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	var purged int64
+	var imageKeys []string
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		movies, err := s.movieRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		for i := range movies {
+			for _, image := range movies[i].Images {
+				imageKeys = append(imageKeys, image.StorageKey)
+			}
+
+			if err := s.audit(ctx, entity.AuditActionHardDelete, movies[i].ID, &movies[i], nil); err != nil {
+				return err
+			}
+		}
+
+		purged = int64(len(movies))
+		return nil
+	})
+	if err != nil {
+		return 0, inerr.Err(err)
+	}
+
+	s.deleteFiles(ctx, imageKeys)
+	return purged, nil
+}
+
 func (s *service) beforeCreate(m *entity.Movies) {
+	normalizeTrailer(m)
+
 	if m.CreatedAt.IsZero() {
 		m.CreatedAt = time.Now()
 	}
@@ -122,5 +459,23 @@ func (s *service) beforeCreate(m *entity.Movies) {
 }
 
 func (s *service) beforeUpdate(m *entity.Movies) {
+	normalizeTrailer(m)
+
 	m.UpdatedAt = time.Now()
 }
+
+// normalizeTrailer rewrites recognized YouTube/Vimeo links to their canonical
+// watch URL and records the provider and video id. Other links are kept as is.
+func normalizeTrailer(m *entity.Movies) {
+	v, ok := video.Parse(m.TrailerURL)
+	if !ok {
+		m.TrailerProvider = nil
+		m.TrailerVideoID = nil
+		return
+	}
+
+	provider := string(v.Provider)
+	m.TrailerURL = v.WatchURL()
+	m.TrailerProvider = &provider
+	m.TrailerVideoID = &v.ID
+}
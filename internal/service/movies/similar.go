@@ -0,0 +1,211 @@
+package movies
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/pkg/tfidf"
+	"github.com/shogo82148/pointer"
+)
+
+const (
+	// similarCandidates is how many movies are scored for each movie
+	similarCandidates = 300
+	// similarKept is how many of the best scored are cached, and the most a caller gets
+	similarKept = 50
+	// similarCacheTTL bounds how long a ranking misses changes of its candidates
+	similarCacheTTL     = time.Hour
+	similarCacheEntries = 10000
+	// similarReleaseScale is the gap in years at which release proximity drops to 1/e
+	similarReleaseScale = 5.0
+)
+
+// similarWeights blend the signals we have. The catalogue keeps no cast or crew,
+// so genres weigh the most.
+var similarWeights = struct {
+	Genres, Plot, Release float64
+}{
+	Genres:  0.5,
+	Plot:    0.3,
+	Release: 0.2,
+}
+
+// similarRanking is the cached outcome of scoring the candidates of a movie at
+// Version. Any update of the movie, its genres included, bumps the version and
+// so makes the ranking stale.
+type similarRanking struct {
+	Version int64
+	IDs     []int64
+	Scores  []float64
+}
+
+// Similar returns up to limit movies related to the movie, best first, among
+// the ones the viewing profile may see
+func (s *service) Similar(ctx context.Context, id int64, limit uint64) ([]entity.SimilarMovie, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > similarKept {
+		limit = similarKept
+	}
+
+	filter, err := s.visible(ctx, repository.Eq("id", id))
+	if err != nil {
+		return nil, err
+	}
+
+	movie, err := s.movieRepo.FindOne(ctx, filter, "MovieGenres")
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	ranking, err := s.similarRanking(ctx, movie)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	if len(ranking.IDs) == 0 {
+		return []entity.SimilarMovie{}, nil
+	}
+
+	// the ranking is shared by every profile, movies hidden from this one are
+	// dropped here, as are the ones deleted since it was computed
+	filter, err = s.visible(ctx, repository.In("id", ranking.IDs))
+	if err != nil {
+		return nil, err
+	}
+
+	_, found, err := s.movieRepo.FindAll(ctx, 0, 1, "", filter, "Translations")
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	moviesByID := make(map[int64]*entity.Movies, len(found))
+	for _, m := range found {
+		moviesByID[m.ID] = m
+	}
+
+	similar := make([]entity.SimilarMovie, 0, limit)
+	for i, movieID := range ranking.IDs {
+		if uint64(len(similar)) == limit {
+			break
+		}
+		if m, ok := moviesByID[movieID]; ok {
+			similar = append(similar, entity.SimilarMovie{Movie: m, Score: ranking.Scores[i]})
+		}
+	}
+
+	return similar, nil
+}
+
+// similarRanking returns the cached ranking for the movie's current version or
+// scores its candidates again
+func (s *service) similarRanking(ctx context.Context, movie *entity.Movies) (similarRanking, error) {
+	if ranking, ok := s.similarCache.Get(movie.ID); ok && ranking.Version == movie.Version {
+		return ranking, nil
+	}
+
+	candidates, err := s.movieRepo.SimilarCandidates(ctx, movie, similarCandidates)
+	if err != nil {
+		return similarRanking{}, err
+	}
+
+	frequencies, total, err := s.movieRepo.GenreFrequencies(ctx)
+	if err != nil {
+		return similarRanking{}, err
+	}
+
+	// plots are compared against the candidates only, that is enough to tell
+	// words common among related movies from distinctive ones
+	plots := make([]string, 0, len(candidates)+1)
+	plots = append(plots, pointer.StringValue(movie.Plot))
+	for _, candidate := range candidates {
+		plots = append(plots, pointer.StringValue(candidate.Plot))
+	}
+	vectors := tfidf.Vectorize(plots)
+
+	type scored struct {
+		id    int64
+		score float64
+	}
+
+	ranked := make([]scored, 0, len(candidates))
+	for i := range candidates {
+		candidate := &candidates[i]
+
+		score := similarWeights.Genres*genreOverlap(movie, candidate, frequencies, total) +
+			similarWeights.Plot*tfidf.Cosine(vectors[0], vectors[i+1]) +
+			similarWeights.Release*releaseProximity(movie.Release, candidate.Release)
+		if score > 0 {
+			ranked = append(ranked, scored{id: candidate.ID, score: score})
+		}
+	}
+
+	slices.SortFunc(ranked, func(a, b scored) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.id, b.id)
+	})
+	if len(ranked) > similarKept {
+		ranked = ranked[:similarKept]
+	}
+
+	ranking := similarRanking{
+		Version: movie.Version,
+		IDs:     make([]int64, 0, len(ranked)),
+		Scores:  make([]float64, 0, len(ranked)),
+	}
+	for _, r := range ranked {
+		ranking.IDs = append(ranking.IDs, r.id)
+		ranking.Scores = append(ranking.Scores, math.Round(r.score*1000)/1000)
+	}
+
+	s.similarCache.Set(movie.ID, ranking)
+
+	return ranking, nil
+}
+
+// genreOverlap is the weighted Jaccard index of the genres of two movies, each
+// genre weighted by its rarity so sharing a niche genre counts more than
+// sharing Drama
+func genreOverlap(a, b *entity.Movies, frequencies map[int64]int64, total int64) float64 {
+	weight := func(genreID int64) float64 {
+		return math.Log(1 + float64(total)/float64(max(frequencies[genreID], 1)))
+	}
+
+	genres := make(map[int64]int, len(a.MovieGenres)+len(b.MovieGenres))
+	for _, genre := range a.MovieGenres {
+		genres[genre.GenreID] |= 1
+	}
+	for _, genre := range b.MovieGenres {
+		genres[genre.GenreID] |= 2
+	}
+
+	var shared, union float64
+	for genreID, in := range genres {
+		w := weight(genreID)
+		union += w
+		if in == 3 {
+			shared += w
+		}
+	}
+
+	if union == 0 {
+		return 0
+	}
+	return shared / union
+}
+
+// releaseProximity decays from 1 for movies released the same day as the gap
+// between them grows
+func releaseProximity(a, b time.Time) float64 {
+	years := math.Abs(a.Sub(b).Hours()) / (24 * 365.25)
+	return math.Exp(-years / similarReleaseScale)
+}
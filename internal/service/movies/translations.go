@@ -0,0 +1,76 @@
+package movies
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+func (s *service) ListTranslations(ctx context.Context, movieID int64) ([]*entity.MovieTranslations, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if _, err := s.movieRepo.FindOne(ctx, repository.Eq("id", movieID)); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	_, translations, err := s.translationsRepo.FindAll(ctx, 0, 0, "locale", repository.Eq("movie_id", movieID))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return translations, nil
+}
+
+// SetTranslation creates or replaces the movie's translation for translation.Locale
+// and bumps the movie version so responses cached by ETag are refreshed.
+func (s *service) SetTranslation(ctx context.Context, translation *entity.MovieTranslations) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	translation.CreatedAt = now
+	translation.UpdatedAt = now
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.movieRepo.Touch(ctx, translation.MovieID); err != nil {
+			return err
+		}
+
+		return s.translationsRepo.Upsert(ctx,
+			[]string{"title", "plot", "tagline", "updated_at"},
+			translation,
+			"movie_id", "locale",
+		)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) RemoveTranslation(ctx context.Context, movieID int64, locale string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.movieRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		err := s.translationsRepo.Delete(ctx, repository.And(
+			repository.Eq("movie_id", movieID),
+			repository.Eq("locale", locale),
+		))
+		if err != nil {
+			return err
+		}
+
+		return s.movieRepo.Touch(ctx, movieID)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package profiles
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+)
+
+// Service manages the viewing profiles of the account in ctx. The account PIN,
+// when set, is required to manage profiles and to switch to a less restricted
+// one or to the whole account. Once the account has a PIN or a restricted
+// profile, sessions must select a profile before browsing.
+type Service interface {
+	List(ctx context.Context) ([]*entity.Profiles, error)
+	Create(ctx context.Context, profile *entity.Profiles, pin string) error
+	Update(ctx context.Context, profile *entity.Profiles, pin string) error
+	Delete(ctx context.Context, id, pin string) error
+	Select(ctx context.Context, id, pin string) (*entity.Profiles, error)
+	Active(ctx context.Context) (*entity.Profiles, error)
+	// AgeLimit is the movie filter of the active profile, see Profiles.AgeLimit
+	AgeLimit(ctx context.Context) (*entity.AgeLimit, error)
+	// VisibleMovies matches the movies the active profile may see, see movies.VisibleFilter
+	VisibleMovies(ctx context.Context) (repository.Filter, error)
+	SetPIN(ctx context.Context, password, pin string) error
+}
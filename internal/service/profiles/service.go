@@ -0,0 +1,363 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/internal/repository/profiles"
+	"github.com/AsaHero/movie-app-server/internal/repository/users"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxPINFailures incorrect PINs in a row lock the account's PIN for pinLockout
+	maxPINFailures = 5
+	pinLockout     = 15 * time.Minute
+)
+
+type pinAttempts struct {
+	failures    int
+	lockedUntil time.Time
+}
+
+type service struct {
+	contextTimeout time.Duration
+	userRepo       users.Repository
+	profilesRepo   profiles.Repository
+
+	mu       sync.Mutex
+	attempts map[string]*pinAttempts
+}
+
+func New(contextTimeout time.Duration, userRepo users.Repository, profilesRepo profiles.Repository) Service {
+	return &service{
+		contextTimeout: contextTimeout,
+		userRepo:       userRepo,
+		profilesRepo:   profilesRepo,
+		attempts:       make(map[string]*pinAttempts),
+	}
+}
+
+func (s *service) List(ctx context.Context) ([]*entity.Profiles, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	_, result, err := s.profilesRepo.FindAll(ctx, 0, 0, "created_at",
+		repository.Eq("user_id", security.UserIDFromContext(ctx)),
+	)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return result, nil
+}
+
+func (s *service) Create(ctx context.Context, profile *entity.Profiles, pin string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.authorize(ctx, pin); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	profile.ID = uuid.New().String()
+	profile.UserID = security.UserIDFromContext(ctx)
+	profile.CreatedAt = now
+	profile.UpdatedAt = now
+	setMaxAge(profile)
+
+	if err := s.profilesRepo.Create(ctx, profile); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Update(ctx context.Context, profile *entity.Profiles, pin string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.authorize(ctx, pin); err != nil {
+		return err
+	}
+
+	existing, err := s.find(ctx, profile.ID)
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	profile.UserID = existing.UserID
+	profile.CreatedAt = existing.CreatedAt
+	profile.UpdatedAt = time.Now()
+	setMaxAge(profile)
+
+	if err := s.profilesRepo.Update(ctx, profile); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Delete(ctx context.Context, id, pin string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.authorize(ctx, pin); err != nil {
+		return err
+	}
+
+	err := s.profilesRepo.Delete(ctx, repository.And(
+		repository.Eq("id", id),
+		repository.Eq("user_id", security.UserIDFromContext(ctx)),
+	))
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// Select checks that the session may switch to the profile, or to the whole
+// account for security.AccountProfileID. Switching to one that sees more than
+// the active profile needs the PIN when one is set, and a session without a
+// selection on a protected account may only pick a restricted profile freely.
+func (s *service) Select(ctx context.Context, id, pin string) (*entity.Profiles, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	var target *entity.Profiles
+	if id != security.AccountProfileID {
+		profile, err := s.find(ctx, id)
+		if err != nil {
+			return nil, inerr.Err(err)
+		}
+		target = profile
+	}
+
+	var covered bool
+	active, err := s.active(ctx)
+	switch {
+	case errors.Is(err, inerr.ErrorProfileRequired):
+		covered = target.IsRestricted()
+	case err != nil:
+		return nil, err
+	default:
+		covered = active.Covers(target)
+	}
+
+	if !covered {
+		if err := s.authorize(ctx, pin); err != nil {
+			return nil, err
+		}
+	}
+
+	return target, nil
+}
+
+// Active returns the profile selected for the session, nil for the whole account
+func (s *service) Active(ctx context.Context) (*entity.Profiles, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	return s.active(ctx)
+}
+
+// AgeLimit returns the age limit of the viewing profile selected for the
+// session, nil for the whole account or an unrestricted profile
+func (s *service) AgeLimit(ctx context.Context) (*entity.AgeLimit, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	profile, err := s.active(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.AgeLimit(), nil
+}
+
+func (s *service) VisibleMovies(ctx context.Context) (repository.Filter, error) {
+	limit, err := s.AgeLimit(ctx)
+	if err != nil {
+		return repository.Filter{}, err
+	}
+
+	return movies.VisibleFilter(limit), nil
+}
+
+// SetPIN sets the account PIN after checking the password, an empty pin removes it
+func (s *service) SetPIN(ctx context.Context, password, pin string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	user, err := s.userRepo.FindOne(ctx, repository.Eq("id", security.UserIDFromContext(ctx)))
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	if !security.CheckPasswordHash(password, user.PasswordHash) {
+		return inerr.ErrorIncorrectPassword
+	}
+
+	var pinHash *string
+	if pin != "" {
+		hash, err := security.HashPassword(pin)
+		if err != nil {
+			return inerr.Err(err)
+		}
+		pinHash = &hash
+	}
+
+	err = s.userRepo.UpdateDataWhere(ctx, map[string]any{
+		"pin_hash":   pinHash,
+		"updated_at": time.Now(),
+	}, repository.Eq("id", user.ID))
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	s.resetAttempts(user.ID)
+
+	return nil
+}
+
+func (s *service) find(ctx context.Context, id string) (*entity.Profiles, error) {
+	return s.profilesRepo.FindOne(ctx, repository.And(
+		repository.Eq("id", id),
+		repository.Eq("user_id", security.UserIDFromContext(ctx)),
+	))
+}
+
+// active resolves the session's selection. Sessions without one only see
+// everything on accounts that are not protected.
+func (s *service) active(ctx context.Context) (*entity.Profiles, error) {
+	id := security.ProfileIDFromContext(ctx)
+	switch id {
+	case security.AccountProfileID:
+		return nil, nil
+	case "":
+		protected, err := s.protected(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if protected {
+			return nil, inerr.ErrorProfileRequired
+		}
+		return nil, nil
+	}
+
+	profile, err := s.find(ctx, id)
+	if inerr.IsErrNotFound(err) {
+		return nil, inerr.ErrorProfileRequired
+	}
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return profile, nil
+}
+
+// protected reports whether the account has a PIN or a restricted profile
+func (s *service) protected(ctx context.Context) (bool, error) {
+	userID := security.UserIDFromContext(ctx)
+
+	user, err := s.userRepo.FindOne(ctx, repository.Eq("id", userID))
+	if err != nil {
+		return false, inerr.Err(err)
+	}
+
+	if user.HasPIN() {
+		return true, nil
+	}
+
+	restricted, _, err := s.profilesRepo.FindAll(ctx, 1, 1, "created_at", repository.And(
+		repository.Eq("user_id", userID),
+		repository.IsNotNull("max_age"),
+	))
+	if err != nil {
+		return false, inerr.Err(err)
+	}
+
+	return restricted > 0, nil
+}
+
+// authorize checks the account PIN. Accounts without a PIN are not protected.
+func (s *service) authorize(ctx context.Context, pin string) error {
+	user, err := s.userRepo.FindOne(ctx, repository.Eq("id", security.UserIDFromContext(ctx)))
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	if !user.HasPIN() {
+		return nil
+	}
+
+	if pin == "" {
+		return inerr.ErrorPINRequired
+	}
+
+	if s.locked(user.ID) {
+		return inerr.ErrorTooManyPINAttempts
+	}
+
+	if !security.CheckPasswordHash(pin, *user.PINHash) {
+		s.recordFailure(user.ID)
+		return inerr.ErrorIncorrectPIN
+	}
+
+	s.resetAttempts(user.ID)
+
+	return nil
+}
+
+func (s *service) locked(userID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts, ok := s.attempts[userID]
+	return ok && time.Now().Before(attempts.lockedUntil)
+}
+
+func (s *service) recordFailure(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts, ok := s.attempts[userID]
+	if !ok {
+		attempts = &pinAttempts{}
+		s.attempts[userID] = attempts
+	}
+
+	attempts.failures++
+	if attempts.failures >= maxPINFailures {
+		attempts.failures = 0
+		attempts.lockedUntil = time.Now().Add(pinLockout)
+	}
+}
+
+func (s *service) resetAttempts(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, userID)
+}
+
+// setMaxAge derives the comparable age from the profile's certification
+func setMaxAge(profile *entity.Profiles) {
+	profile.MaxAge = nil
+	if profile.MaxCertification == nil || profile.CertificationCountry == nil {
+		return
+	}
+
+	if age, ok := entity.CertificationAge(*profile.CertificationCountry, *profile.MaxCertification); ok {
+		profile.MaxAge = &age
+	}
+}
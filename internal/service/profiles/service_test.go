@@ -0,0 +1,41 @@
+package profiles
+
+import "testing"
+
+func TestPINLockout(t *testing.T) {
+	s := &service{attempts: make(map[string]*pinAttempts)}
+
+	for i := 1; i < maxPINFailures; i++ {
+		s.recordFailure("user")
+		if s.locked("user") {
+			t.Fatalf("locked after %d failures, want %d", i, maxPINFailures)
+		}
+	}
+
+	s.recordFailure("user")
+	if !s.locked("user") {
+		t.Fatalf("not locked after %d failures", maxPINFailures)
+	}
+	if s.locked("other") {
+		t.Error("lockout leaked to another account")
+	}
+
+	s.resetAttempts("user")
+	if s.locked("user") {
+		t.Error("still locked after reset")
+	}
+}
+
+func TestPINFailuresResetOnSuccess(t *testing.T) {
+	s := &service{attempts: make(map[string]*pinAttempts)}
+
+	for i := 1; i < maxPINFailures; i++ {
+		s.recordFailure("user")
+	}
+	s.resetAttempts("user")
+	s.recordFailure("user")
+
+	if s.locked("user") {
+		t.Error("failures before a correct PIN still counted")
+	}
+}
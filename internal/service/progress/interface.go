@@ -0,0 +1,19 @@
+package progress
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// Service keeps the playback positions of the user in ctx. Shows carry no
+// certifications, so restricted viewing profiles cannot track episodes.
+type Service interface {
+	// Save records a playback heartbeat. The video is marked as completed once the
+	// position is past completePercent of its duration, or when the client says so.
+	Save(ctx context.Context, progress *entity.WatchProgress, completePercent float64) error
+	Get(ctx context.Context, watchableType entity.WatchableType, watchableID int64) (*entity.WatchProgress, error)
+	Remove(ctx context.Context, watchableType entity.WatchableType, watchableID int64) error
+	// ContinueWatching returns the unfinished videos, most recently watched first
+	ContinueWatching(ctx context.Context, limit, page uint64) (int64, []*entity.WatchProgress, error)
+}
@@ -0,0 +1,219 @@
+package progress
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/episodes"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/internal/repository/watch_progress"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+)
+
+type service struct {
+	contextTimeout  time.Duration
+	progressRepo    watch_progress.Repository
+	movieRepo       movies.Repository
+	episodesRepo    episodes.Repository
+	profilesService profiles.Service
+}
+
+func New(
+	contextTimeout time.Duration,
+	progressRepo watch_progress.Repository,
+	movieRepo movies.Repository,
+	episodesRepo episodes.Repository,
+	profilesService profiles.Service,
+) Service {
+	return &service{
+		contextTimeout:  contextTimeout,
+		progressRepo:    progressRepo,
+		movieRepo:       movieRepo,
+		episodesRepo:    episodesRepo,
+		profilesService: profilesService,
+	}
+}
+
+// Save upserts the position so repeated heartbeats never race into duplicates,
+// and leaves progress as stored. WatchedAt is only written when the video is
+// completed, starting it over keeps the last time it was watched through.
+func (s *service) Save(ctx context.Context, progress *entity.WatchProgress, completePercent float64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	durationSeconds, err := s.duration(ctx, progress.WatchableType, progress.WatchableID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	progress.UserID = security.UserIDFromContext(ctx)
+	progress.Completed = progress.Completed || entity.CompletedAt(progress.PositionSeconds, durationSeconds, completePercent)
+	progress.UpdatedAt = now
+
+	columns := []string{"position_seconds", "completed", "updated_at"}
+	if progress.Completed {
+		progress.WatchedAt = &now
+		columns = append(columns, "watched_at")
+	}
+
+	if err := s.progressRepo.Upsert(ctx, columns, progress, "user_id", "watchable_type", "watchable_id"); err != nil {
+		return inerr.Err(err)
+	}
+
+	if !progress.Completed {
+		// report when it was last watched through, if ever
+		saved, err := s.progressRepo.FindOne(ctx, progressFilter(progress.UserID, progress.WatchableType, progress.WatchableID))
+		if err != nil {
+			return inerr.Err(err)
+		}
+		progress.WatchedAt = saved.WatchedAt
+	}
+
+	return nil
+}
+
+func (s *service) Get(ctx context.Context, watchableType entity.WatchableType, watchableID int64) (*entity.WatchProgress, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	progress, err := s.progressRepo.FindOne(ctx, progressFilter(security.UserIDFromContext(ctx), watchableType, watchableID))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return progress, nil
+}
+
+func (s *service) Remove(ctx context.Context, watchableType entity.WatchableType, watchableID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.progressRepo.Delete(ctx, progressFilter(security.UserIDFromContext(ctx), watchableType, watchableID)); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// ContinueWatching leaves out movies the viewing profile may not see, and
+// episodes altogether for restricted profiles
+func (s *service) ContinueWatching(ctx context.Context, limit, page uint64) (int64, []*entity.WatchProgress, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	ageLimit, err := s.profilesService.AgeLimit(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	total, progress, err := s.progressRepo.ContinueWatching(ctx, security.UserIDFromContext(ctx), limit, page, movies.VisibleFilter(ageLimit), ageLimit == nil)
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	if err := s.loadWatchables(ctx, progress); err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return total, progress, nil
+}
+
+// duration returns the length in seconds of a video the viewing profile may
+// see, zero when it is unknown
+func (s *service) duration(ctx context.Context, watchableType entity.WatchableType, watchableID int64) (int, error) {
+	ageLimit, err := s.profilesService.AgeLimit(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	switch watchableType {
+	case entity.WatchableMovie:
+		movie, err := s.movieRepo.FindOne(ctx, repository.Eq("id", watchableID).And(movies.VisibleFilter(ageLimit)))
+		if err != nil {
+			return 0, inerr.Err(err)
+		}
+		return int(movie.DurationMinutes) * 60, nil
+	case entity.WatchableEpisode:
+		if ageLimit != nil {
+			return 0, inerr.NewErrNotFound("Episodes")
+		}
+
+		episode, err := s.episodesRepo.FindOne(ctx, repository.Eq("id", watchableID))
+		if err != nil {
+			return 0, inerr.Err(err)
+		}
+		if episode.RuntimeMinutes == nil {
+			return 0, nil
+		}
+		return int(*episode.RuntimeMinutes) * 60, nil
+	}
+
+	return 0, inerr.NewErrNotFound("WatchProgress")
+}
+
+// loadWatchables sets the movie or the episode, with its season and show, of each position
+func (s *service) loadWatchables(ctx context.Context, progress []*entity.WatchProgress) error {
+	var movieIDs, episodeIDs []int64
+	for _, p := range progress {
+		switch p.WatchableType {
+		case entity.WatchableMovie:
+			movieIDs = append(movieIDs, p.WatchableID)
+		case entity.WatchableEpisode:
+			episodeIDs = append(episodeIDs, p.WatchableID)
+		}
+	}
+
+	moviesByID := make(map[int64]*entity.Movies, len(movieIDs))
+	if len(movieIDs) > 0 {
+		_, found, err := s.movieRepo.FindAll(ctx, 0, 1, "", repository.In("id", movieIDs), "Translations")
+		if err != nil {
+			return err
+		}
+		for _, movie := range found {
+			moviesByID[movie.ID] = movie
+		}
+	}
+
+	episodesByID := make(map[int64]*entity.Episodes, len(episodeIDs))
+	if len(episodeIDs) > 0 {
+		_, found, err := s.episodesRepo.FindAll(ctx, 0, 1, "", repository.In("id", episodeIDs), "Season", "Season.Show")
+		if err != nil {
+			return err
+		}
+		for _, episode := range found {
+			episodesByID[episode.ID] = episode
+		}
+	}
+
+	for _, p := range progress {
+		switch p.WatchableType {
+		case entity.WatchableMovie:
+			p.Movie = moviesByID[p.WatchableID]
+		case entity.WatchableEpisode:
+			p.Episode = episodesByID[p.WatchableID]
+		}
+	}
+
+	return nil
+}
+
+func progressFilter(userID string, watchableType entity.WatchableType, watchableID int64) repository.Filter {
+	return repository.And(
+		repository.Eq("user_id", userID),
+		repository.Eq("watchable_type", watchableType),
+		repository.Eq("watchable_id", watchableID),
+	)
+}
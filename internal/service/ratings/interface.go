@@ -0,0 +1,14 @@
+package ratings
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// Service keeps the movie ratings of the user in ctx
+type Service interface {
+	Rate(ctx context.Context, rating *entity.MovieRatings) error
+	Get(ctx context.Context, movieID int64) (*entity.MovieRatings, error)
+	Remove(ctx context.Context, movieID int64) error
+}
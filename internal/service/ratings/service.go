@@ -0,0 +1,92 @@
+package ratings
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_ratings"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+)
+
+type service struct {
+	contextTimeout  time.Duration
+	ratingsRepo     movie_ratings.Repository
+	movieRepo       movies.Repository
+	profilesService profiles.Service
+}
+
+func New(
+	contextTimeout time.Duration,
+	ratingsRepo movie_ratings.Repository,
+	movieRepo movies.Repository,
+	profilesService profiles.Service,
+) Service {
+	return &service{
+		contextTimeout:  contextTimeout,
+		ratingsRepo:     ratingsRepo,
+		movieRepo:       movieRepo,
+		profilesService: profilesService,
+	}
+}
+
+// Rate sets the user's score for a movie the viewing profile may see, rating it
+// again replaces the score
+func (s *service) Rate(ctx context.Context, rating *entity.MovieRatings) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	visible, err := s.profilesService.VisibleMovies(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.movieRepo.FindOne(ctx, repository.Eq("id", rating.MovieID).And(visible)); err != nil {
+		return inerr.Err(err)
+	}
+
+	now := time.Now()
+	rating.UserID = security.UserIDFromContext(ctx)
+	rating.CreatedAt = now
+	rating.UpdatedAt = now
+
+	if err := s.ratingsRepo.Upsert(ctx, []string{"score", "updated_at"}, rating, "user_id", "movie_id"); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Get(ctx context.Context, movieID int64) (*entity.MovieRatings, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	rating, err := s.ratingsRepo.FindOne(ctx, ratingFilter(security.UserIDFromContext(ctx), movieID))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return rating, nil
+}
+
+func (s *service) Remove(ctx context.Context, movieID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.ratingsRepo.Delete(ctx, ratingFilter(security.UserIDFromContext(ctx), movieID)); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func ratingFilter(userID string, movieID int64) repository.Filter {
+	return repository.And(
+		repository.Eq("user_id", userID),
+		repository.Eq("movie_id", movieID),
+	)
+}
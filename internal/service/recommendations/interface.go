@@ -0,0 +1,17 @@
+package recommendations
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// Service recommends movies to the user in ctx from what they rated and
+// watched, and keeps the movie similarities it relies on up to date
+type Service interface {
+	// Recommend returns movies the user has not started nor rated yet, best first,
+	// with the movie each was picked for loaded in Because
+	Recommend(ctx context.Context, limit uint64) ([]*entity.Recommendation, error)
+	// RebuildSimilarities recomputes every movie similarity and returns how many were stored
+	RebuildSimilarities(ctx context.Context) (int64, error)
+}
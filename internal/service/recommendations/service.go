@@ -0,0 +1,127 @@
+package recommendations
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/movie_similarities"
+	"github.com/AsaHero/movie-app-server/internal/repository/movies"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+	"github.com/AsaHero/movie-app-server/pkg/security"
+)
+
+// similarityWeights favour movies liked by the same people, genres break ties
+// and give neighbours to movies nobody else liked yet
+var similarityWeights = entity.SimilarityWeights{
+	CoLiked:    0.7,
+	Genres:     0.3,
+	Neighbours: 50,
+}
+
+// coldStartLikes is how many liked movies make personal taste weigh as much as
+// popularity, users with fewer lean on what everyone likes
+const coldStartLikes = 3
+
+type service struct {
+	contextTimeout   time.Duration
+	similaritiesRepo movie_similarities.Repository
+	movieRepo        movies.Repository
+	profilesService  profiles.Service
+}
+
+func New(
+	contextTimeout time.Duration,
+	similaritiesRepo movie_similarities.Repository,
+	movieRepo movies.Repository,
+	profilesService profiles.Service,
+) Service {
+	return &service{
+		contextTimeout:   contextTimeout,
+		similaritiesRepo: similaritiesRepo,
+		movieRepo:        movieRepo,
+		profilesService:  profilesService,
+	}
+}
+
+func (s *service) Recommend(ctx context.Context, limit uint64) ([]*entity.Recommendation, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	filter, err := s.profilesService.VisibleMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := security.UserIDFromContext(ctx)
+
+	liked, err := s.similaritiesRepo.CountLiked(ctx, userID, filter)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	personal := float64(liked) / float64(liked+coldStartLikes)
+
+	recommendations, err := s.similaritiesRepo.Recommend(ctx, userID, limit, personal, filter)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	if err := s.loadMovies(ctx, recommendations); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return recommendations, nil
+}
+
+func (s *service) RebuildSimilarities(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	stored, err := s.similaritiesRepo.Rebuild(ctx, similarityWeights)
+	if err != nil {
+		return 0, inerr.Err(err)
+	}
+
+	return stored, nil
+}
+
+// loadMovies sets the recommended movie and the one it was picked for
+func (s *service) loadMovies(ctx context.Context, recommendations []*entity.Recommendation) error {
+	if len(recommendations) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(recommendations)*2)
+	for _, recommendation := range recommendations {
+		ids = append(ids, recommendation.MovieID)
+		if recommendation.BecauseMovieID != nil {
+			ids = append(ids, *recommendation.BecauseMovieID)
+		}
+	}
+
+	_, found, err := s.movieRepo.FindAll(ctx, 0, 1, "", repository.In("id", ids), "Translations")
+	if err != nil {
+		return err
+	}
+
+	moviesByID := make(map[int64]*entity.Movies, len(found))
+	for _, movie := range found {
+		moviesByID[movie.ID] = movie
+	}
+
+	for _, recommendation := range recommendations {
+		recommendation.Movie = moviesByID[recommendation.MovieID]
+		if recommendation.BecauseMovieID != nil {
+			recommendation.Because = moviesByID[*recommendation.BecauseMovieID]
+		}
+	}
+
+	return nil
+}
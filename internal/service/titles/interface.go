@@ -0,0 +1,25 @@
+package titles
+
+import (
+	"context"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+)
+
+// Service manages TV shows with their seasons and episodes, and searches
+// movies and shows together. Shows carry no certifications, so restricted
+// viewing profiles do not see them.
+type Service interface {
+	ListShows(ctx context.Context, limit, page uint64, search string, genreIDs []int64) (int64, []*entity.Shows, error)
+	GetShow(ctx context.Context, id int64) (*entity.Shows, error)
+	CreateShow(ctx context.Context, show *entity.Shows, genreIDs []int64) error
+	UpdateShow(ctx context.Context, show *entity.Shows, genreIDs []int64) error
+	DeleteShow(ctx context.Context, id int64) error
+	GetSeason(ctx context.Context, showID int64, number int) (*entity.Seasons, error)
+	SetSeason(ctx context.Context, season *entity.Seasons) error
+	DeleteSeason(ctx context.Context, showID int64, number int) error
+	GetEpisode(ctx context.Context, showID int64, seasonNumber, number int) (*entity.Episodes, error)
+	SetEpisode(ctx context.Context, showID int64, seasonNumber int, episode *entity.Episodes) error
+	DeleteEpisode(ctx context.Context, showID int64, seasonNumber, number int) error
+	Search(ctx context.Context, limit, page uint64, search entity.TitleSearch) (int64, []entity.TitleSearchResult, error)
+}
@@ -0,0 +1,384 @@
+package titles
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/inerr"
+	"github.com/AsaHero/movie-app-server/internal/repository"
+	"github.com/AsaHero/movie-app-server/internal/repository/episodes"
+	"github.com/AsaHero/movie-app-server/internal/repository/seasons"
+	"github.com/AsaHero/movie-app-server/internal/repository/shows"
+	"github.com/AsaHero/movie-app-server/internal/repository/title_genres"
+	"github.com/AsaHero/movie-app-server/internal/repository/titles"
+	"github.com/AsaHero/movie-app-server/internal/service/profiles"
+)
+
+// showDetails are the relations loaded for a single show
+var showDetails = []string{"ShowGenres", "ShowGenres.Genre", "ShowGenres.Genre.Translations", "Seasons", "Seasons.Episodes"}
+
+type service struct {
+	contextTimeout  time.Duration
+	showsRepo       shows.Repository
+	seasonsRepo     seasons.Repository
+	episodesRepo    episodes.Repository
+	titleGenresRepo title_genres.Repository
+	titlesRepo      titles.Repository
+	profilesService profiles.Service
+}
+
+func New(
+	contextTimeout time.Duration,
+	showsRepo shows.Repository,
+	seasonsRepo seasons.Repository,
+	episodesRepo episodes.Repository,
+	titleGenresRepo title_genres.Repository,
+	titlesRepo titles.Repository,
+	profilesService profiles.Service,
+) Service {
+	return &service{
+		contextTimeout:  contextTimeout,
+		showsRepo:       showsRepo,
+		seasonsRepo:     seasonsRepo,
+		episodesRepo:    episodesRepo,
+		titleGenresRepo: titleGenresRepo,
+		titlesRepo:      titlesRepo,
+		profilesService: profilesService,
+	}
+}
+
+func (s *service) ListShows(ctx context.Context, limit, page uint64, search string, genreIDs []int64) (int64, []*entity.Shows, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	ageLimit, err := s.profilesService.AgeLimit(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	if ageLimit != nil {
+		return 0, []*entity.Shows{}, nil
+	}
+
+	filter := repository.Filter{}
+	if search != "" {
+		filter = filter.And(repository.ILike("shows.title", "%"+search+"%"))
+	}
+	if len(genreIDs) > 0 {
+		filter = filter.And(shows.GenresFilter(genreIDs))
+	}
+
+	total, result, err := s.showsRepo.FindAll(ctx, limit, page, "title, id", filter,
+		"ShowGenres", "ShowGenres.Genre", "ShowGenres.Genre.Translations")
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return int64(total), result, nil
+}
+
+func (s *service) GetShow(ctx context.Context, id int64) (*entity.Shows, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.visible(ctx); err != nil {
+		return nil, err
+	}
+
+	show, err := s.showsRepo.FindOne(ctx, repository.Eq("id", id), showDetails...)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	slices.SortFunc(show.Seasons, func(a, b entity.Seasons) int {
+		return cmp.Compare(a.SeasonNumber, b.SeasonNumber)
+	})
+	for i := range show.Seasons {
+		sortEpisodes(show.Seasons[i].Episodes)
+	}
+
+	return show, nil
+}
+
+func (s *service) CreateShow(ctx context.Context, show *entity.Shows, genreIDs []int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	show.CreatedAt = now
+	show.UpdatedAt = now
+
+	err := s.showsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.showsRepo.Create(ctx, show); err != nil {
+			return err
+		}
+
+		show.ShowGenres = showGenres(show.ID, genreIDs)
+		if len(show.ShowGenres) == 0 {
+			return nil
+		}
+
+		links := make([]*entity.TitleGenres, 0, len(show.ShowGenres))
+		for i := range show.ShowGenres {
+			links = append(links, &show.ShowGenres[i])
+		}
+
+		return s.titleGenresRepo.BatchCreate(ctx, links)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// UpdateShow replaces the show's fields and genres, its seasons are left untouched
+func (s *service) UpdateShow(ctx context.Context, show *entity.Shows, genreIDs []int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.showsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.showsRepo.FindOne(ctx, repository.Eq("id", show.ID))
+		if err != nil {
+			return err
+		}
+
+		show.CreatedAt = existing.CreatedAt
+		show.UpdatedAt = time.Now()
+
+		if err := s.showsRepo.Update(ctx, show); err != nil {
+			return err
+		}
+
+		show.ShowGenres = showGenres(show.ID, genreIDs)
+		return s.showsRepo.ReplaceGenres(ctx, show)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// DeleteShow removes the show with its seasons, episodes and genre links
+func (s *service) DeleteShow(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.showsRepo.Delete(ctx, repository.Eq("id", id)); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) GetSeason(ctx context.Context, showID int64, number int) (*entity.Seasons, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.visible(ctx); err != nil {
+		return nil, err
+	}
+
+	season, err := s.seasonsRepo.FindOne(ctx, seasonFilter(showID, number), "Episodes")
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	sortEpisodes(season.Episodes)
+
+	return season, nil
+}
+
+// SetSeason creates or replaces the season numbered season.SeasonNumber of season.ShowID
+func (s *service) SetSeason(ctx context.Context, season *entity.Seasons) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	season.CreatedAt = now
+	season.UpdatedAt = now
+
+	err := s.showsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.showsRepo.FindOne(ctx, repository.Eq("id", season.ShowID)); err != nil {
+			return err
+		}
+
+		return s.seasonsRepo.Upsert(ctx,
+			[]string{"title", "plot", "air_date", "updated_at"},
+			season,
+			"show_id", "season_number",
+		)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// DeleteSeason removes the season with its episodes
+func (s *service) DeleteSeason(ctx context.Context, showID int64, number int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.seasonsRepo.Delete(ctx, seasonFilter(showID, number)); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) GetEpisode(ctx context.Context, showID int64, seasonNumber, number int) (*entity.Episodes, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.visible(ctx); err != nil {
+		return nil, err
+	}
+
+	season, err := s.seasonsRepo.FindOne(ctx, seasonFilter(showID, seasonNumber))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	episode, err := s.episodesRepo.FindOne(ctx, episodeFilter(season.ID, number))
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return episode, nil
+}
+
+// SetEpisode creates or replaces an episode of an existing season
+func (s *service) SetEpisode(ctx context.Context, showID int64, seasonNumber int, episode *entity.Episodes) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	episode.CreatedAt = now
+	episode.UpdatedAt = now
+
+	err := s.showsRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		season, err := s.seasonsRepo.FindOne(ctx, seasonFilter(showID, seasonNumber))
+		if err != nil {
+			return err
+		}
+		episode.SeasonID = season.ID
+
+		return s.episodesRepo.Upsert(ctx,
+			[]string{"title", "plot", "air_date", "runtime_minutes", "updated_at"},
+			episode,
+			"season_id", "episode_number",
+		)
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) DeleteEpisode(ctx context.Context, showID int64, seasonNumber, number int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	season, err := s.seasonsRepo.FindOne(ctx, seasonFilter(showID, seasonNumber))
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	if err := s.episodesRepo.Delete(ctx, episodeFilter(season.ID, number)); err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// Search looks titles up across movies and shows. Restricted profiles only
+// search the movies they may see.
+func (s *service) Search(ctx context.Context, limit, page uint64, search entity.TitleSearch) (int64, []entity.TitleSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	ageLimit, err := s.profilesService.AgeLimit(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	if ageLimit != nil {
+		if !search.Includes(entity.TitleTypeMovie) {
+			return 0, []entity.TitleSearchResult{}, nil
+		}
+		search.Types = []entity.TitleType{entity.TitleTypeMovie}
+		search.AgeLimit = ageLimit
+	}
+
+	total, results, err := s.titlesRepo.Search(ctx, limit, page, search)
+	if err != nil {
+		return 0, nil, inerr.Err(err)
+	}
+
+	return total, results, nil
+}
+
+// visible reports shows as not found to restricted profiles
+func (s *service) visible(ctx context.Context) error {
+	limit, err := s.profilesService.AgeLimit(ctx)
+	if err != nil {
+		return err
+	}
+	if limit != nil {
+		return inerr.NewErrNotFound("Shows")
+	}
+	return nil
+}
+
+func showGenres(showID int64, genreIDs []int64) []entity.TitleGenres {
+	links := make([]entity.TitleGenres, 0, len(genreIDs))
+	for _, genreID := range genreIDs {
+		links = append(links, entity.TitleGenres{
+			TitleType: entity.TitleTypeShow,
+			TitleID:   showID,
+			GenreID:   genreID,
+		})
+	}
+	return links
+}
+
+func seasonFilter(showID int64, number int) repository.Filter {
+	return repository.And(
+		repository.Eq("show_id", showID),
+		repository.Eq("season_number", number),
+	)
+}
+
+func episodeFilter(seasonID int64, number int) repository.Filter {
+	return repository.And(
+		repository.Eq("season_id", seasonID),
+		repository.Eq("episode_number", number),
+	)
+}
+
+func sortEpisodes(episodes []entity.Episodes) {
+	slices.SortFunc(episodes, func(a, b entity.Episodes) int {
+		return cmp.Compare(a.EpisodeNumber, b.EpisodeNumber)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/AsaHero/movie-app-server/internal/entity"
+	"github.com/AsaHero/movie-app-server/internal/repository"
 	"github.com/AsaHero/movie-app-server/internal/repository/users"
 	"github.com/google/uuid"
 )
@@ -38,7 +39,7 @@ func (s *service) GetByID(ctx context.Context, id string) (*entity.Users, error)
 	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
 	defer cancel()
 
-	user, err := s.userRepo.FindOne(ctx, map[string]any{"id": id})
+	user, err := s.userRepo.FindOne(ctx, repository.Eq("id", id))
 	if err != nil {
 		return nil, err
 	}
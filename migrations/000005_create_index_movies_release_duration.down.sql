@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_movies_release;
+
+DROP INDEX IF EXISTS idx_movies_duration_minutes;
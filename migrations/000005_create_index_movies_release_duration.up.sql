@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_movies_release ON movies(release);
+
+CREATE INDEX IF NOT EXISTS idx_movies_duration_minutes ON movies(duration_minutes);
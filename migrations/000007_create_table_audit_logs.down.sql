@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_audit_logs_entity;
+
+DROP TABLE IF EXISTS audit_logs CASCADE;
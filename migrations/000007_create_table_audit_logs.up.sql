@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS audit_logs(
+    id bigserial PRIMARY KEY,
+    entity_type varchar(50) NOT NULL,
+    entity_id bigint NOT NULL,
+    action varchar(50) NOT NULL,
+    actor_id uuid,
+    before jsonb,
+    after jsonb,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id, id);
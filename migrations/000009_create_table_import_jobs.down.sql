@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS import_jobs CASCADE;
+
+DROP INDEX IF EXISTS idx_movies_external_id;
+
+ALTER TABLE movies DROP COLUMN IF EXISTS external_id;
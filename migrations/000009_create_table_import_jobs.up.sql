@@ -0,0 +1,21 @@
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS external_id varchar(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_movies_external_id ON movies(external_id);
+
+CREATE TABLE IF NOT EXISTS import_jobs(
+    id uuid PRIMARY KEY,
+    status varchar(50) NOT NULL,
+    dry_run boolean NOT NULL DEFAULT false,
+    create_genres boolean NOT NULL DEFAULT false,
+    total int NOT NULL DEFAULT 0,
+    processed int NOT NULL DEFAULT 0,
+    created int NOT NULL DEFAULT 0,
+    updated int NOT NULL DEFAULT 0,
+    failed int NOT NULL DEFAULT 0,
+    errors jsonb NOT NULL DEFAULT '[]',
+    created_by uuid,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    finished_at timestamptz,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
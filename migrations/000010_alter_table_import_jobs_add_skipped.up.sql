@@ -0,0 +1 @@
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS skipped int NOT NULL DEFAULT 0;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS movie_external_ids(
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL,
+    source varchar(50) NOT NULL,
+    value varchar(255) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    UNIQUE (source, value),
+    UNIQUE (movie_id, source)
+);
+
+-- Dataset imports key movies by "<source>:<value>", carry those over
+INSERT INTO movie_external_ids (movie_id, source, value)
+SELECT id, split_part(external_id, ':', 1), substr(external_id, length(split_part(external_id, ':', 1)) + 2)
+FROM movies
+WHERE split_part(external_id, ':', 1) IN ('imdb', 'tmdb')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS movie_images(
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL,
+    kind varchar(20) NOT NULL,
+    variant varchar(20) NOT NULL,
+    storage_key text NOT NULL,
+    content_type varchar(50) NOT NULL,
+    width int NOT NULL,
+    height int NOT NULL,
+    size bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    UNIQUE (movie_id, kind, variant)
+);
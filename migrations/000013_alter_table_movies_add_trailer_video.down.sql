@@ -0,0 +1,2 @@
+ALTER TABLE movies DROP COLUMN IF EXISTS trailer_video_id;
+ALTER TABLE movies DROP COLUMN IF EXISTS trailer_provider;
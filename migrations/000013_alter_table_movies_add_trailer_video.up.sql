@@ -0,0 +1,2 @@
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS trailer_provider varchar(20);
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS trailer_video_id varchar(64);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS genre_translations;
+DROP TABLE IF EXISTS movie_translations;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS movie_translations(
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL,
+    locale varchar(20) NOT NULL,
+    title varchar(255) NOT NULL,
+    plot text,
+    tagline varchar(255),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    UNIQUE (movie_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS genre_translations(
+    id bigserial PRIMARY KEY,
+    genre_id int NOT NULL,
+    locale varchar(20) NOT NULL,
+    name varchar(100) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    FOREIGN KEY (genre_id) REFERENCES genres(id) ON DELETE CASCADE,
+    UNIQUE (genre_id, locale)
+);
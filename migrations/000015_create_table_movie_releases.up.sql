@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS movie_releases(
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL,
+    country char(2) NOT NULL,
+    type varchar(20) NOT NULL,
+    release_date date NOT NULL,
+    certification varchar(20),
+    min_age smallint,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    UNIQUE (movie_id, country, type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_releases_country_min_age ON movie_releases(country, min_age);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS profiles;
+
+ALTER TABLE users DROP COLUMN IF EXISTS pin_hash;
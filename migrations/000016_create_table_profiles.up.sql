@@ -0,0 +1,14 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pin_hash varchar(255);
+
+CREATE TABLE IF NOT EXISTS profiles(
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL,
+    name varchar(100) NOT NULL,
+    max_certification varchar(20),
+    certification_country char(2),
+    max_age smallint,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, name)
+);
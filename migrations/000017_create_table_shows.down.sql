@@ -0,0 +1,27 @@
+DROP TRIGGER IF EXISTS trg_shows_delete_title_genres ON shows;
+DROP TRIGGER IF EXISTS trg_movies_delete_title_genres ON movies;
+DROP FUNCTION IF EXISTS delete_title_genres();
+
+DROP TABLE IF EXISTS episodes;
+DROP TABLE IF EXISTS seasons;
+DROP TABLE IF EXISTS shows;
+
+CREATE TABLE IF NOT EXISTS movie_genres(
+    movie_id bigint NOT NULL,
+    genre_id int NOT NULL,
+    created_at timestamptz DEFAULT now(),
+    PRIMARY KEY (movie_id, genre_id),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (genre_id) REFERENCES genres(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_genres_movie_id ON movie_genres(movie_id);
+
+CREATE INDEX IF NOT EXISTS idx_movie_genres_genre_id ON movie_genres(genre_id);
+
+INSERT INTO movie_genres (movie_id, genre_id, created_at)
+SELECT title_id, genre_id, created_at FROM title_genres
+WHERE title_type = 'movie' AND title_id IN (SELECT id FROM movies)
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS title_genres;
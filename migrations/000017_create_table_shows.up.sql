@@ -0,0 +1,69 @@
+CREATE TABLE IF NOT EXISTS title_genres(
+    title_type varchar(20) NOT NULL,
+    title_id bigint NOT NULL,
+    genre_id int NOT NULL,
+    created_at timestamptz DEFAULT now(),
+    PRIMARY KEY (title_type, title_id, genre_id),
+    FOREIGN KEY (genre_id) REFERENCES genres(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_title_genres_genre_id ON title_genres(genre_id);
+
+INSERT INTO title_genres (title_type, title_id, genre_id, created_at)
+SELECT 'movie', movie_id, genre_id, created_at FROM movie_genres
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS movie_genres;
+
+CREATE TABLE IF NOT EXISTS shows(
+    id bigserial PRIMARY KEY,
+    title varchar(255) NOT NULL,
+    plot text,
+    poster_url text,
+    first_air_date date,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shows_title ON shows(title);
+
+CREATE TABLE IF NOT EXISTS seasons(
+    id bigserial PRIMARY KEY,
+    show_id bigint NOT NULL,
+    season_number int NOT NULL,
+    title varchar(255),
+    plot text,
+    air_date date,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now(),
+    FOREIGN KEY (show_id) REFERENCES shows(id) ON DELETE CASCADE,
+    UNIQUE (show_id, season_number)
+);
+
+CREATE TABLE IF NOT EXISTS episodes(
+    id bigserial PRIMARY KEY,
+    season_id bigint NOT NULL,
+    episode_number int NOT NULL,
+    title varchar(255) NOT NULL,
+    plot text,
+    air_date date,
+    runtime_minutes smallint,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now(),
+    FOREIGN KEY (season_id) REFERENCES seasons(id) ON DELETE CASCADE,
+    UNIQUE (season_id, episode_number)
+);
+
+-- title_genres cannot reference two tables, so links are removed with their title here
+CREATE OR REPLACE FUNCTION delete_title_genres() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM title_genres WHERE title_type = TG_ARGV[0] AND title_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_movies_delete_title_genres AFTER DELETE ON movies
+    FOR EACH ROW EXECUTE FUNCTION delete_title_genres('movie');
+
+CREATE TRIGGER trg_shows_delete_title_genres AFTER DELETE ON shows
+    FOR EACH ROW EXECUTE FUNCTION delete_title_genres('show');
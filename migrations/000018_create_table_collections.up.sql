@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS collections(
+    id bigserial PRIMARY KEY,
+    name varchar(255) UNIQUE NOT NULL,
+    description text,
+    poster_url text,
+    backdrop_url text,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now()
+);
+
+-- a movie belongs to at most one collection
+CREATE TABLE IF NOT EXISTS collection_movies(
+    collection_id bigint NOT NULL,
+    movie_id bigint NOT NULL,
+    position int NOT NULL,
+    PRIMARY KEY (collection_id, movie_id),
+    FOREIGN KEY (collection_id) REFERENCES collections(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    UNIQUE (collection_id, position),
+    UNIQUE (movie_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_list_follows;
+DROP TABLE IF EXISTS user_list_items;
+DROP TABLE IF EXISTS user_lists;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS user_lists(
+    id bigserial PRIMARY KEY,
+    user_id uuid NOT NULL,
+    name varchar(255) NOT NULL,
+    slug varchar(100) UNIQUE NOT NULL,
+    description text,
+    visibility varchar(20) NOT NULL DEFAULT 'private',
+    followers_count int NOT NULL DEFAULT 0,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_lists_popularity ON user_lists(visibility, followers_count DESC);
+
+CREATE TABLE IF NOT EXISTS user_list_items(
+    list_id bigint NOT NULL,
+    movie_id bigint NOT NULL,
+    position int NOT NULL,
+    note text,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now(),
+    PRIMARY KEY (list_id, movie_id),
+    FOREIGN KEY (list_id) REFERENCES user_lists(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_list_items_position ON user_list_items(list_id, position);
+
+CREATE TABLE IF NOT EXISTS user_list_follows(
+    list_id bigint NOT NULL,
+    user_id uuid NOT NULL,
+    created_at timestamptz DEFAULT now(),
+    PRIMARY KEY (list_id, user_id),
+    FOREIGN KEY (list_id) REFERENCES user_lists(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_list_follows_user_id ON user_list_follows(user_id);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_episodes_delete_watch_progress ON episodes;
+DROP TRIGGER IF EXISTS trg_movies_delete_watch_progress ON movies;
+DROP FUNCTION IF EXISTS delete_watch_progress();
+DROP TABLE IF EXISTS watch_progress;
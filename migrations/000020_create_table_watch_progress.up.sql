@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS watch_progress(
+    user_id uuid NOT NULL,
+    watchable_type varchar(20) NOT NULL,
+    watchable_id bigint NOT NULL,
+    position_seconds int NOT NULL DEFAULT 0,
+    completed boolean NOT NULL DEFAULT false,
+    watched_at timestamptz,
+    updated_at timestamptz DEFAULT now(),
+    PRIMARY KEY (user_id, watchable_type, watchable_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- continue watching: the unfinished videos of a user, most recent first
+CREATE INDEX IF NOT EXISTS idx_watch_progress_recent ON watch_progress(user_id, updated_at DESC) WHERE NOT completed;
+
+-- watch_progress cannot reference two tables, so positions are removed with their video here
+CREATE OR REPLACE FUNCTION delete_watch_progress() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM watch_progress WHERE watchable_type = TG_ARGV[0] AND watchable_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_movies_delete_watch_progress AFTER DELETE ON movies
+    FOR EACH ROW EXECUTE FUNCTION delete_watch_progress('movie');
+
+CREATE TRIGGER trg_episodes_delete_watch_progress AFTER DELETE ON episodes
+    FOR EACH ROW EXECUTE FUNCTION delete_watch_progress('episode');
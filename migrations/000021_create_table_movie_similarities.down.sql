@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS movie_similarities;
+DROP VIEW IF EXISTS movie_interactions;
+DROP TABLE IF EXISTS movie_ratings;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS movie_ratings(
+    user_id uuid NOT NULL,
+    movie_id bigint NOT NULL,
+    score smallint NOT NULL CHECK (score BETWEEN 1 AND 10),
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now(),
+    PRIMARY KEY (user_id, movie_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_ratings_movie_id ON movie_ratings(movie_id);
+
+-- movie_interactions are the movies each user liked, weighted in (0, 1]: a rating
+-- of 6 or more by its score, a movie watched through and not rated by 0.6.
+-- A rating always wins over watching, so a low rating cancels a watched movie.
+CREATE OR REPLACE VIEW movie_interactions AS
+SELECT movie_ratings.user_id, movie_ratings.movie_id, movie_ratings.score / 10.0 AS weight
+FROM movie_ratings
+WHERE movie_ratings.score >= 6
+UNION ALL
+SELECT watch_progress.user_id, watch_progress.watchable_id AS movie_id, 0.6 AS weight
+FROM watch_progress
+WHERE watch_progress.watchable_type = 'movie'
+  AND watch_progress.watched_at IS NOT NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM movie_ratings
+    WHERE movie_ratings.user_id = watch_progress.user_id AND movie_ratings.movie_id = watch_progress.watchable_id
+  );
+
+-- movie_similarities keep the closest neighbours of each liked movie, rebuilt by a background job
+CREATE TABLE IF NOT EXISTS movie_similarities(
+    movie_id bigint NOT NULL,
+    similar_movie_id bigint NOT NULL,
+    score double precision NOT NULL,
+    computed_at timestamptz DEFAULT now(),
+    PRIMARY KEY (movie_id, similar_movie_id),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (similar_movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
@@ -0,0 +1,31 @@
+-- movie_events are the raw engagement signals: a movie's details fetched (view)
+-- or its player opened (open). They feed movie_popularity and are purged once
+-- older than the longest chart period can look back.
+CREATE TABLE IF NOT EXISTS movie_events(
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL,
+    user_id uuid NOT NULL,
+    kind varchar(20) NOT NULL,
+    created_at timestamptz DEFAULT now(),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_events_created_at ON movie_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_movie_events_movie_id ON movie_events(movie_id);
+
+-- movie_popularity keeps the time-decayed scores of each movie per chart period,
+-- recomputed by a background job
+CREATE TABLE IF NOT EXISTS movie_popularity(
+    movie_id bigint NOT NULL,
+    period varchar(10) NOT NULL,
+    score double precision NOT NULL,
+    trending_score double precision NOT NULL,
+    events bigint NOT NULL,
+    computed_at timestamptz DEFAULT now(),
+    PRIMARY KEY (movie_id, period),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_popularity_score ON movie_popularity(period, score DESC);
+CREATE INDEX IF NOT EXISTS idx_movie_popularity_trending ON movie_popularity(period, trending_score DESC);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS comment_bans;
+DROP TABLE IF EXISTS movie_comment_reports;
+DROP TABLE IF EXISTS movie_comment_likes;
+DROP TABLE IF EXISTS movie_comments;
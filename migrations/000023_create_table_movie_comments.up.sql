@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS movie_comments(
+    id bigserial PRIMARY KEY,
+    movie_id bigint NOT NULL,
+    user_id uuid NOT NULL,
+    root_id bigint,
+    parent_id bigint,
+    body text NOT NULL,
+    likes_count int NOT NULL DEFAULT 0,
+    replies_count int NOT NULL DEFAULT 0,
+    edited_at timestamptz,
+    deleted_at timestamptz,
+    hidden_at timestamptz,
+    hidden_by uuid,
+    locked_at timestamptz,
+    locked_by uuid,
+    created_at timestamptz DEFAULT now(),
+    updated_at timestamptz DEFAULT now(),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (root_id) REFERENCES movie_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES movie_comments(id) ON DELETE CASCADE
+);
+
+-- threads of a movie, newest first, and the replies of a thread in order
+CREATE INDEX IF NOT EXISTS idx_movie_comments_threads ON movie_comments(movie_id, created_at DESC, id DESC) WHERE root_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_movie_comments_replies ON movie_comments(root_id, created_at, id) WHERE root_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_movie_comments_parent_id ON movie_comments(parent_id);
+
+CREATE TABLE IF NOT EXISTS movie_comment_likes(
+    comment_id bigint NOT NULL,
+    user_id uuid NOT NULL,
+    created_at timestamptz DEFAULT now(),
+    PRIMARY KEY (comment_id, user_id),
+    FOREIGN KEY (comment_id) REFERENCES movie_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_comment_reports(
+    comment_id bigint NOT NULL,
+    user_id uuid NOT NULL,
+    reason varchar(500) NOT NULL,
+    created_at timestamptz DEFAULT now(),
+    resolved_at timestamptz,
+    resolved_by uuid,
+    PRIMARY KEY (comment_id, user_id),
+    FOREIGN KEY (comment_id) REFERENCES movie_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- the moderation queue, oldest open reports first
+CREATE INDEX IF NOT EXISTS idx_movie_comment_reports_open ON movie_comment_reports(created_at) WHERE resolved_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS comment_bans(
+    user_id uuid PRIMARY KEY,
+    banned_by uuid NOT NULL,
+    reason varchar(500),
+    expires_at timestamptz,
+    created_at timestamptz DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
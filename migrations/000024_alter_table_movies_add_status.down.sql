@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_movies_publish_at;
+DROP INDEX IF EXISTS idx_movies_status;
+
+ALTER TABLE movies DROP COLUMN IF EXISTS published_by;
+ALTER TABLE movies DROP COLUMN IF EXISTS published_at;
+ALTER TABLE movies DROP COLUMN IF EXISTS scheduled_by;
+ALTER TABLE movies DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE movies DROP CONSTRAINT IF EXISTS movies_status_check;
+ALTER TABLE movies DROP COLUMN IF EXISTS status;
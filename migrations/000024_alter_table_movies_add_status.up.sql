@@ -0,0 +1,17 @@
+-- status is where a movie is in the editorial workflow, only published movies
+-- are public. Movies that exist already stay published, new ones start as drafts.
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'published';
+ALTER TABLE movies ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE movies ADD CONSTRAINT movies_status_check CHECK (status IN ('draft', 'in_review', 'scheduled', 'published'));
+
+-- publish_at is when a scheduled movie goes live and scheduled_by who scheduled
+-- it, published_at and published_by record the publication of a published movie
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS publish_at timestamptz;
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS scheduled_by uuid REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS published_at timestamptz;
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS published_by uuid REFERENCES users(id) ON DELETE SET NULL;
+
+UPDATE movies SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_movies_status ON movies(status);
+CREATE INDEX IF NOT EXISTS idx_movies_publish_at ON movies(publish_at) WHERE status = 'scheduled';
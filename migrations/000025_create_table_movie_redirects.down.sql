@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS movie_duplicate_dismissals;
+DROP TABLE IF EXISTS movie_redirects;
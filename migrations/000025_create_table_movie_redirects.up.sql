@@ -0,0 +1,27 @@
+-- movie_redirects point the id of a movie merged into another one at the movie
+-- that absorbed it. The merged movie may be purged later, so movie_id has no
+-- foreign key.
+CREATE TABLE IF NOT EXISTS movie_redirects(
+    movie_id bigint PRIMARY KEY,
+    target_id bigint NOT NULL,
+    merged_by uuid,
+    created_at timestamptz DEFAULT now(),
+    FOREIGN KEY (target_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (merged_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_redirects_target_id ON movie_redirects(target_id);
+
+-- movie_duplicate_dismissals are duplicate candidates an admin reviewed and
+-- found to be different movies, the lower id always comes first
+CREATE TABLE IF NOT EXISTS movie_duplicate_dismissals(
+    movie_id bigint NOT NULL,
+    duplicate_id bigint NOT NULL,
+    dismissed_by uuid,
+    created_at timestamptz DEFAULT now(),
+    PRIMARY KEY (movie_id, duplicate_id),
+    CHECK (movie_id < duplicate_id),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (duplicate_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (dismissed_by) REFERENCES users(id) ON DELETE SET NULL
+);
@@ -0,0 +1,17 @@
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS external_id varchar(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_movies_external_id ON movies(external_id);
+
+UPDATE movies SET external_id = movie_external_ids.value
+FROM movie_external_ids
+WHERE movie_external_ids.movie_id = movies.id AND movie_external_ids.source = 'import';
+
+UPDATE movies SET external_id = movie_external_ids.source || ':' || movie_external_ids.value
+FROM movie_external_ids
+WHERE movie_external_ids.movie_id = movies.id AND movie_external_ids.source = 'tmdb' AND movies.external_id IS NULL;
+
+UPDATE movies SET external_id = movie_external_ids.source || ':' || movie_external_ids.value
+FROM movie_external_ids
+WHERE movie_external_ids.movie_id = movies.id AND movie_external_ids.source = 'imdb' AND movies.external_id IS NULL;
+
+DELETE FROM movie_external_ids WHERE source = 'import';
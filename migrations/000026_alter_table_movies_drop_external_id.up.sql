@@ -0,0 +1,22 @@
+-- movie_external_ids is the only place external ids live. Import keys naming a
+-- partner catalogue, e.g. "tmdb:603", become that source's id, any other key is
+-- kept whole under the import source.
+INSERT INTO movie_external_ids (movie_id, source, value)
+SELECT id, split_part(external_id, ':', 1), substr(external_id, length(split_part(external_id, ':', 1)) + 2)
+FROM movies
+WHERE split_part(external_id, ':', 1) IN ('imdb', 'tmdb')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO movie_external_ids (movie_id, source, value)
+SELECT id, 'import', external_id
+FROM movies
+WHERE external_id IS NOT NULL AND NOT EXISTS (
+    SELECT 1 FROM movie_external_ids
+    WHERE movie_external_ids.movie_id = movies.id
+      AND movie_external_ids.source || ':' || movie_external_ids.value = movies.external_id
+)
+ON CONFLICT DO NOTHING;
+
+DROP INDEX IF EXISTS idx_movies_external_id;
+
+ALTER TABLE movies DROP COLUMN IF EXISTS external_id;
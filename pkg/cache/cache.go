@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// Cache is an in-process map whose entries expire after a fixed TTL. Once it
+// holds maxEntries, expired entries are dropped and then arbitrary ones.
+type Cache[K comparable, V any] struct {
+	mu         sync.Mutex
+	entries    map[K]entry[V]
+	ttl        time.Duration
+	maxEntries int
+}
+
+func New[K comparable, V any](ttl time.Duration, maxEntries int) *Cache[K, V] {
+	return &Cache[K, V]{
+		entries:    make(map[K]entry[V]),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+// Get returns the value stored for key unless it expired
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		var zero V
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+
+	c.entries[key] = entry[V]{value: value, expires: time.Now().Add(c.ttl)}
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// evict makes room for one entry, c.mu must be held
+func (c *Cache[K, V]) evict() {
+	now := time.Now()
+	for key, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, key)
+		}
+	}
+
+	// map iteration order is random, so this drops an arbitrary entry
+	for key := range c.entries {
+		if len(c.entries) < c.maxEntries {
+			break
+		}
+		delete(c.entries, key)
+	}
+}
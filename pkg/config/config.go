@@ -47,6 +47,48 @@ type Config struct {
 	Token struct {
 		Secret string
 	}
+
+	Concurrency struct {
+		RequireIfMatch bool
+	}
+
+	Import struct {
+		AsyncThreshold string
+	}
+
+	Trash struct {
+		Retention     string
+		PurgeInterval string
+	}
+
+	Media struct {
+		StorageDriver string
+		LocalDir      string
+		PublicPath    string
+		MaxUploadSize string
+		PosterHosts   string
+	}
+
+	I18n struct {
+		DefaultLocale  string
+		DefaultCountry string
+	}
+
+	Progress struct {
+		CompletePercent string
+	}
+
+	Recommendations struct {
+		RebuildInterval string
+	}
+
+	Charts struct {
+		RebuildInterval string
+	}
+
+	Publishing struct {
+		Interval string
+	}
 }
 
 func New() *Config {
@@ -81,6 +123,40 @@ func New() *Config {
 	// token configuration
 	config.Token.Secret = getEnv("TOKEN_SECRET", "secret")
 
+	// concurrency configuration
+	config.Concurrency.RequireIfMatch = getEnv("REQUIRE_IF_MATCH", "false") == "true"
+
+	// import configuration
+	config.Import.AsyncThreshold = getEnv("IMPORT_ASYNC_THRESHOLD", "500")
+
+	// trash configuration
+	config.Trash.Retention = getEnv("TRASH_RETENTION", "720h")
+	config.Trash.PurgeInterval = getEnv("TRASH_PURGE_INTERVAL", "1h")
+
+	// media configuration
+	config.Media.StorageDriver = getEnv("MEDIA_STORAGE_DRIVER", "local")
+	config.Media.LocalDir = getEnv("MEDIA_LOCAL_DIR", "./uploads")
+	config.Media.PublicPath = getEnv("MEDIA_PUBLIC_PATH", "/media")
+	config.Media.MaxUploadSize = getEnv("MEDIA_MAX_UPLOAD_SIZE", "10485760")
+	config.Media.PosterHosts = getEnv("MEDIA_POSTER_HOSTS", "image.tmdb.org,m.media-amazon.com,upload.wikimedia.org")
+
+	// i18n configuration, the locale the base movie and genre fields are written in
+	config.I18n.DefaultLocale = getEnv("DEFAULT_LOCALE", "en")
+	// country used for release dates and certifications when the caller names none
+	config.I18n.DefaultCountry = getEnv("DEFAULT_COUNTRY", "")
+
+	// watch progress configuration, share of a video after which it counts as watched
+	config.Progress.CompletePercent = getEnv("WATCH_COMPLETE_PERCENT", "90")
+
+	// recommendations configuration, how often movie similarities are recomputed
+	config.Recommendations.RebuildInterval = getEnv("RECOMMENDATIONS_REBUILD_INTERVAL", "6h")
+
+	// charts configuration, how often popularity scores are recomputed from movie events
+	config.Charts.RebuildInterval = getEnv("CHARTS_REBUILD_INTERVAL", "15m")
+
+	// publishing configuration, how often scheduled movies are checked and published when due
+	config.Publishing.Interval = getEnv("PUBLISH_INTERVAL", "1m")
+
 	return &config
 }
 
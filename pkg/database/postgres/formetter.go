@@ -13,6 +13,11 @@ const (
 	OpLessThanOrEqual    Operator = "<="
 	OpBetween            Operator = "between"
 	OpIn                 Operator = "in"
+	OpNotIn              Operator = "not in"
+	OpLike               Operator = "like"
+	OpILike              Operator = "ilike"
+	OpIsNull             Operator = "is null"
+	OpIsNotNull          Operator = "is not null"
 )
 
 type TimeCondition map[Operator]time.Time
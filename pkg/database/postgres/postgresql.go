@@ -32,6 +32,9 @@ func New(cfg *config.Config) (*gorm.DB, error) {
 	// Open connection
 	connection, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: newLogger,
+		// Translate constraint violations into gorm errors so Error can tell a
+		// duplicate (409) from a dangling reference (400)
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, err
@@ -43,8 +46,10 @@ func Error[T any](err error, operation string, entity T) error {
 	switch err {
 	case gorm.ErrRecordNotFound:
 		return inerr.NewErrNotFound(utility.GetTypeName(entity))
-	case gorm.ErrDuplicatedKey, gorm.ErrForeignKeyViolated, gorm.ErrCheckConstraintViolated:
+	case gorm.ErrDuplicatedKey, gorm.ErrCheckConstraintViolated:
 		return inerr.NewErrConflict(utility.GetTypeName(entity))
+	case gorm.ErrForeignKeyViolated:
+		return inerr.NewErrInvalidReference(utility.GetTypeName(entity))
 	default:
 		if err.Error() == "no rows affected" {
 			return inerr.NewErrNoChanges(utility.GetTypeName(entity))
@@ -0,0 +1,78 @@
+package imaging
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Fit scales img down to width keeping its aspect ratio. Images that are
+// already narrower are returned unchanged, they are never scaled up.
+func Fit(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	if width <= 0 || bounds.Dx() <= width {
+		return img
+	}
+
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	return Resize(img, width, height)
+}
+
+// Resize scales img to width x height by averaging the source pixels covered by
+// each destination pixel (box filter), which keeps downscaled images smooth.
+func Resize(img image.Image, width, height int) *image.RGBA {
+	src := toRGBA(img)
+	srcW, srcH := src.Rect.Dx(), src.Rect.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0, y1 := span(y, height, srcH)
+
+		for x := 0; x < width; x++ {
+			x0, x1 := span(x, width, srcW)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					b += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i+0] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// span returns the source range [from, to) covered by destination index i
+func span(i, dstSize, srcSize int) (int, int) {
+	from := i * srcSize / dstSize
+	to := (i + 1) * srcSize / dstSize
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
+
+// toRGBA returns a zero based premultiplied copy, averaging premultiplied values avoids dark fringes
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Rect, img, bounds.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,134 @@
+package locale
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// tagPattern accepts the language[-Script][-REGION] subset of BCP 47 we store, e.g. en, pt-BR, zh-Hant-TW, es-419
+var tagPattern = regexp.MustCompile(`^([A-Za-z]{2,3})(?:-([A-Za-z]{4}))?(?:-([A-Za-z]{2}|[0-9]{3}))?$`)
+
+// Normalize validates tag and returns it in canonical case, e.g. PT-br becomes pt-BR
+func Normalize(tag string) (string, bool) {
+	parts := tagPattern.FindStringSubmatch(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if parts == nil {
+		return "", false
+	}
+
+	normalized := strings.ToLower(parts[1])
+	if parts[2] != "" {
+		normalized += "-" + strings.ToUpper(parts[2][:1]) + strings.ToLower(parts[2][1:])
+	}
+	if parts[3] != "" {
+		normalized += "-" + strings.ToUpper(parts[3])
+	}
+
+	return normalized, true
+}
+
+// Language returns the primary language subtag, e.g. pt for pt-BR
+func Language(tag string) string {
+	language, _, _ := strings.Cut(tag, "-")
+	return strings.ToLower(language)
+}
+
+// Region returns the two letter region subtag, e.g. BR for pt-BR, or an empty
+// string when tag has none. Numeric regions such as 419 are not countries.
+func Region(tag string) string {
+	parts := tagPattern.FindStringSubmatch(tag)
+	if parts == nil || len(parts[3]) != 2 {
+		return ""
+	}
+	return strings.ToUpper(parts[3])
+}
+
+// NormalizeCountry validates an ISO 3166-1 alpha-2 code and returns it upper-cased
+func NormalizeCountry(code string) (string, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != 2 || !isLetter(code[0]) || !isLetter(code[1]) {
+		return "", false
+	}
+	return strings.ToUpper(code), true
+}
+
+func isLetter(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// ParseAcceptLanguage returns the tags of an Accept-Language header ordered by
+// preference. Wildcards, malformed tags and tags with q=0 are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(part, ";")
+
+		tag, ok := Normalize(value)
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, raw, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(key) != "q" {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			quality = q
+		}
+
+		if quality > 0 {
+			items = append(items, weighted{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].quality > items[j].quality
+	})
+
+	tags := make([]string, 0, len(items))
+	for _, item := range items {
+		tags = append(tags, item.tag)
+	}
+
+	return tags
+}
+
+// Match picks the best of available for the preferred tags, in order of preference.
+// Each preferred tag is tried exactly first, then by its language alone, then against
+// any regional variant of that language, so pt-BR falls back to pt and pt to pt-PT.
+func Match(preferred, available []string) (string, bool) {
+	for _, tag := range preferred {
+		for _, candidate := range available {
+			if strings.EqualFold(candidate, tag) {
+				return candidate, true
+			}
+		}
+
+		language := Language(tag)
+		for _, candidate := range available {
+			if strings.EqualFold(candidate, language) {
+				return candidate, true
+			}
+		}
+
+		for _, candidate := range available {
+			if Language(candidate) == language {
+				return candidate, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AsaHero/movie-app-server/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// Job is a unit of periodic background work
+type Job func(ctx context.Context) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	fn       Job
+}
+
+// Scheduler runs registered jobs on fixed intervals until stopped
+type Scheduler struct {
+	mu     sync.Mutex
+	jobs   []job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers fn to run each interval. Jobs registered after Start are ignored.
+func (s *Scheduler) Every(name string, interval time.Duration, fn Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, job{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+	})
+}
+
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.run(ctx, j)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.execute(ctx, j)
+		}
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, j job) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("scheduled job panicked", logrus.Fields{
+				"job":   j.name,
+				"panic": r,
+			})
+		}
+	}()
+
+	started := time.Now()
+	if err := j.fn(ctx); err != nil {
+		logger.Error("scheduled job failed", logrus.Fields{
+			"job":   j.name,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logger.Debug("scheduled job finished", logrus.Fields{
+		"job":      j.name,
+		"duration": time.Since(started).String(),
+	})
+}
@@ -0,0 +1,36 @@
+package security
+
+import "context"
+
+type ctxKey string
+
+// AccountProfileID is the profile claim of a session that selected the account
+// itself, it sees every title like an unrestricted profile
+const AccountProfileID = "account"
+
+const (
+	ctxUserIDKey    ctxKey = "user_id"
+	ctxProfileIDKey ctxKey = "profile_id"
+)
+
+// WithUserID stores the authenticated user id so services can attribute changes
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, ctxUserIDKey, userID)
+}
+
+// UserIDFromContext returns the authenticated user id or an empty string
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(ctxUserIDKey).(string)
+	return userID
+}
+
+// WithProfileID stores the viewing profile selected for the session
+func WithProfileID(ctx context.Context, profileID string) context.Context {
+	return context.WithValue(ctx, ctxProfileIDKey, profileID)
+}
+
+// ProfileIDFromContext returns the selected viewing profile id or an empty string
+func ProfileIDFromContext(ctx context.Context) string {
+	profileID, _ := ctx.Value(ctxProfileIDKey).(string)
+	return profileID
+}
@@ -10,6 +10,7 @@ import (
 
 type TokenClaims struct {
 	UserID    string
+	ProfileID string
 	TokenType string
 	ExpiresAt int64
 	IssuedAt  int64
@@ -18,14 +19,19 @@ type TokenClaims struct {
 
 // GenerateTokenPair generates both access and refresh JWTs
 func GenerateTokenPair(userID string, secret string) (string, string, error) {
+	return GenerateProfileTokenPair(userID, "", secret)
+}
+
+// GenerateProfileTokenPair generates both JWTs with the selected viewing profile as a claim
+func GenerateProfileTokenPair(userID, profileID string, secret string) (string, string, error) {
 	// Generate access token
-	accessToken, err := generateAccessToken(userID, secret)
+	accessToken, err := generateAccessToken(userID, profileID, secret)
 	if err != nil {
 		return "", "", fmt.Errorf("error generating access token: %w", err)
 	}
 
 	// Generate refresh token
-	refreshToken, err := generateRefreshToken(userID, secret)
+	refreshToken, err := generateRefreshToken(userID, profileID, secret)
 	if err != nil {
 		return "", "", fmt.Errorf("error generating refresh token: %w", err)
 	}
@@ -34,20 +40,23 @@ func GenerateTokenPair(userID string, secret string) (string, string, error) {
 }
 
 // generateAccessToken creates a short-lived JWT token for API access
-func generateAccessToken(userID string, secret string) (string, error) {
+func generateAccessToken(userID, profileID string, secret string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"exp":     time.Now().Add(time.Hour * 168).Unix(),
 		"type":    "access",
 		"iat":     time.Now().Unix(),
 	}
+	if profileID != "" {
+		claims["profile_id"] = profileID
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
 }
 
 // generateRefreshToken creates a long-lived JWT token for obtaining new access tokens
-func generateRefreshToken(userID string, secret string) (string, error) {
+func generateRefreshToken(userID, profileID string, secret string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"exp":     time.Now().Add(time.Hour * 720).Unix(), //30 days
@@ -55,6 +64,9 @@ func generateRefreshToken(userID string, secret string) (string, error) {
 		"iat":     time.Now().Unix(),
 		// "jti":     uuid.New().String(),
 	}
+	if profileID != "" {
+		claims["profile_id"] = profileID
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
@@ -124,8 +136,11 @@ func ParseAndValidateToken(tokenString string, secret string, expectedType strin
 	}
 
 	// Extract claims
+	profileID, _ := claims["profile_id"].(string)
+
 	tokenClaims := &TokenClaims{
 		UserID:    claims["user_id"].(string),
+		ProfileID: profileID,
 		TokenType: tokenType,
 		ExpiresAt: int64(claims["exp"].(float64)),
 		IssuedAt:  int64(claims["iat"].(float64)),
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/AsaHero/movie-app-server/pkg/config"
+)
+
+const DriverLocal = "local"
+
+// Storage keeps uploaded files under slash separated keys, e.g. "movies/1/poster/abc-w342.jpg"
+type Storage interface {
+	Save(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL is the public address of the file
+	URL(key string) string
+}
+
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.Media.StorageDriver {
+	case DriverLocal:
+		return NewLocal(cfg.Media.LocalDir, cfg.AppURL+cfg.Media.PublicPath)
+	default:
+		return nil, fmt.Errorf("unknown media storage driver %q", cfg.Media.StorageDriver)
+	}
+}
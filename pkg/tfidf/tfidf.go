@@ -0,0 +1,91 @@
+package tfidf
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Vector is a document as L2 normalized TF-IDF weights by term
+type Vector map[string]float64
+
+// stopWords are common English words that say nothing about a plot
+var stopWords = map[string]struct{}{
+	"the": {}, "and": {}, "for": {}, "with": {}, "his": {}, "her": {}, "their": {}, "they": {},
+	"from": {}, "into": {}, "that": {}, "this": {}, "who": {}, "when": {}, "where": {}, "while": {},
+	"after": {}, "before": {}, "but": {}, "not": {}, "are": {}, "was": {}, "were": {}, "has": {},
+	"have": {}, "had": {}, "its": {}, "one": {}, "two": {}, "out": {}, "about": {}, "over": {},
+	"him": {}, "she": {}, "them": {}, "what": {}, "which": {}, "will": {}, "must": {}, "can": {},
+	"all": {}, "own": {}, "new": {}, "more": {}, "than": {}, "then": {}, "only": {}, "also": {},
+	"there": {}, "been": {}, "being": {}, "each": {}, "other": {}, "between": {}, "through": {},
+}
+
+// Tokenize lowercases text and splits it into words of three letters or more,
+// stop words left out
+func Tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) < 3 {
+			continue
+		}
+		if _, ok := stopWords[word]; ok {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+
+	return tokens
+}
+
+// Vectorize weighs the terms of each document by their frequency in it and
+// their rarity across docs, empty documents get empty vectors
+func Vectorize(docs []string) []Vector {
+	counts := make([]map[string]int, len(docs))
+	frequencies := make(map[string]int)
+	for i, doc := range docs {
+		counts[i] = make(map[string]int)
+		for _, token := range Tokenize(doc) {
+			counts[i][token]++
+		}
+		for term := range counts[i] {
+			frequencies[term]++
+		}
+	}
+
+	vectors := make([]Vector, len(docs))
+	for i, terms := range counts {
+		vector := make(Vector, len(terms))
+		var norm float64
+		for term, count := range terms {
+			weight := (1 + math.Log(float64(count))) * math.Log(1+float64(len(docs))/float64(frequencies[term]))
+			vector[term] = weight
+			norm += weight * weight
+		}
+
+		norm = math.Sqrt(norm)
+		for term := range vector {
+			vector[term] /= norm
+		}
+		vectors[i] = vector
+	}
+
+	return vectors
+}
+
+// Cosine returns the similarity of two normalized vectors, in [0, 1]
+func Cosine(a, b Vector) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	var dot float64
+	for term, weight := range a {
+		dot += weight * b[term]
+	}
+
+	return dot
+}
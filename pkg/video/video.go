@@ -0,0 +1,105 @@
+package video
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+type Provider string
+
+const (
+	ProviderYouTube Provider = "youtube"
+	ProviderVimeo   Provider = "vimeo"
+)
+
+var (
+	youtubeID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoID   = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Video identifies a video on a hosting provider
+type Video struct {
+	Provider Provider
+	ID       string
+}
+
+// Parse recognizes YouTube and Vimeo links in their common shapes:
+// watch/short/embed/shorts links for YouTube, page/channel/player links for Vimeo.
+func Parse(rawURL string) (Video, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return Video{}, false
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+
+	switch host {
+	case "youtube.com", "m.youtube.com", "music.youtube.com", "youtube-nocookie.com":
+		if len(segments) == 1 && segments[0] == "watch" {
+			return youtube(u.Query().Get("v"))
+		}
+		if len(segments) == 2 {
+			switch segments[0] {
+			case "embed", "shorts", "v", "live":
+				return youtube(segments[1])
+			}
+		}
+	case "youtu.be":
+		if len(segments) == 1 {
+			return youtube(segments[0])
+		}
+	case "vimeo.com":
+		// vimeo.com/{id}, vimeo.com/{id}/{hash}, vimeo.com/channels/{name}/{id}, vimeo.com/groups/{name}/videos/{id}
+		for _, segment := range segments {
+			if vimeoID.MatchString(segment) {
+				return Video{Provider: ProviderVimeo, ID: segment}, true
+			}
+		}
+	case "player.vimeo.com":
+		if len(segments) == 2 && segments[0] == "video" {
+			return vimeo(segments[1])
+		}
+	}
+
+	return Video{}, false
+}
+
+// WatchURL is the canonical page of the video
+func (v Video) WatchURL() string {
+	switch v.Provider {
+	case ProviderYouTube:
+		return "https://www.youtube.com/watch?v=" + v.ID
+	case ProviderVimeo:
+		return "https://vimeo.com/" + v.ID
+	default:
+		return ""
+	}
+}
+
+// EmbedURL is the address of the provider's player for iframes
+func (v Video) EmbedURL() string {
+	switch v.Provider {
+	case ProviderYouTube:
+		return "https://www.youtube-nocookie.com/embed/" + v.ID
+	case ProviderVimeo:
+		return "https://player.vimeo.com/video/" + v.ID
+	default:
+		return ""
+	}
+}
+
+func youtube(id string) (Video, bool) {
+	if !youtubeID.MatchString(id) {
+		return Video{}, false
+	}
+	return Video{Provider: ProviderYouTube, ID: id}, true
+}
+
+func vimeo(id string) (Video, bool) {
+	if !vimeoID.MatchString(id) {
+		return Video{}, false
+	}
+	return Video{Provider: ProviderVimeo, ID: id}, true
+}
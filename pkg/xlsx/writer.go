@@ -0,0 +1,188 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	// ContentType is the MIME type of .xlsx files
+	ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	// maxSheetNameLength is the limit Excel puts on worksheet names
+	maxSheetNameLength = 31
+)
+
+var ErrClosed = errors.New("xlsx: writer is closed")
+
+// Writer streams a single-sheet workbook. Rows are written straight into the
+// zip entry, so memory use does not grow with the number of rows.
+// Strings are stored inline, there is no shared string table.
+type Writer struct {
+	zw     *zip.Writer
+	sheet  io.Writer
+	row    int
+	closed bool
+}
+
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	if len(sheetName) > maxSheetNameLength {
+		sheetName = sheetName[:maxSheetNameLength]
+	}
+
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", relsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, escape(sheetName))},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(sheet, sheetHeaderXML); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		zw:    zw,
+		sheet: sheet,
+	}, nil
+}
+
+// WriteRow appends a row. Numbers and booleans are written as numeric cells,
+// time.Time as an ISO 8601 string, nil as an empty cell and anything else via fmt.
+func (w *Writer) WriteRow(cells ...any) error {
+	if w.closed {
+		return ErrClosed
+	}
+
+	w.row++
+
+	buf := make([]byte, 0, 64*len(cells)+32)
+	buf = append(buf, `<row r="`...)
+	buf = strconv.AppendInt(buf, int64(w.row), 10)
+	buf = append(buf, `">`...)
+
+	for i, cell := range cells {
+		if cell == nil {
+			continue
+		}
+
+		ref := columnName(i) + strconv.Itoa(w.row)
+
+		switch v := cell.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			buf = append(buf, `<c r="`+ref+`"><v>`...)
+			buf = fmt.Appendf(buf, "%v", v)
+			buf = append(buf, `</v></c>`...)
+		case bool:
+			value := "0"
+			if v {
+				value = "1"
+			}
+			buf = append(buf, `<c r="`+ref+`" t="b"><v>`+value+`</v></c>`...)
+		case time.Time:
+			buf = appendInlineString(buf, ref, v.Format(time.RFC3339))
+		case string:
+			buf = appendInlineString(buf, ref, v)
+		default:
+			buf = appendInlineString(buf, ref, fmt.Sprint(v))
+		}
+	}
+
+	buf = append(buf, `</row>`...)
+
+	_, err := w.sheet.Write(buf)
+	return err
+}
+
+// Close finishes the sheet and the zip archive, it does not close the underlying writer
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if _, err := io.WriteString(w.sheet, sheetFooterXML); err != nil {
+		return err
+	}
+
+	return w.zw.Close()
+}
+
+func appendInlineString(buf []byte, ref, value string) []byte {
+	buf = append(buf, `<c r="`+ref+`" t="inlineStr"><is><t xml:space="preserve">`...)
+	buf = append(buf, escape(value)...)
+	return append(buf, `</t></is></c>`...)
+}
+
+// columnName converts a zero based index into a column letter: 0 → A, 26 → AA
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func escape(value string) string {
+	w := &stringWriter{}
+	_ = xml.EscapeText(w, []byte(value))
+	return string(w.buf)
+}
+
+type stringWriter struct {
+	buf []byte
+}
+
+func (w *stringWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+const contentTypesXML = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const relsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookXML = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const workbookRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
+
+const sheetHeaderXML = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetFooterXML = `</sheetData></worksheet>`